@@ -0,0 +1,90 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/evilsocket/takuan/core"
+)
+
+func blocklistCommand(args []string) error {
+	var (
+		format    = "plain"
+		output    = ""
+		setName   = ""
+		minEvents = 0
+		minScore  = 0
+		since     = ""
+		sensors   = ""
+		rules     = ""
+		countries = ""
+		tags      = ""
+		excluded  = ""
+	)
+
+	formats := make([]string, 0)
+	for name := range core.BlocklistFormats {
+		formats = append(formats, name)
+	}
+
+	flags := flag.NewFlagSet("blocklist", flag.ExitOnError)
+	flags.StringVar(&format, "format", format, fmt.Sprintf("Output format, one of: %s.", strings.Join(formats, ", ")))
+	flags.StringVar(&output, "output", output, "Output file or empty for standard output.")
+	flags.StringVar(&setName, "set", setName, "Name of the ipset/nftables set or iptables chain.")
+	flags.IntVar(&minEvents, "min-events", minEvents, "Only include addresses with at least this number of events.")
+	flags.IntVar(&minScore, "min-score", minScore, "Only include addresses with at least this score.")
+	flags.StringVar(&since, "since", since, "Only consider events after this time (RFC3339, YYYY-MM-DD or a duration like 24h).")
+	flags.StringVar(&sensors, "sensors", sensors, "Comma separated list of sensors to consider.")
+	flags.StringVar(&rules, "rules", rules, "Comma separated list of rules to consider.")
+	flags.StringVar(&countries, "countries", countries, "Comma separated list of country codes to consider.")
+	flags.StringVar(&tags, "tags", tags, "Comma separated list of intel tags, only include addresses with any of them.")
+	flags.StringVar(&excluded, "exclude-tags", excluded, "Comma separated list of intel tags, exclude addresses with any of them.")
+	flags.Parse(args)
+
+	query := url.Values{}
+	query.Set("min_events", fmt.Sprintf("%d", minEvents))
+	query.Set("min_score", fmt.Sprintf("%d", minScore))
+	query.Set("since", since)
+	query.Set("sensor", sensors)
+	query.Set("rule", rules)
+	query.Set("country", countries)
+	query.Set("tag", tags)
+	query.Set("exclude_tag", excluded)
+
+	filter, err := core.ParseBlocklistFilter(query)
+	if err != nil {
+		return err
+	}
+
+	conf, err := core.Parse(confFile)
+	if err != nil {
+		return err
+	}
+
+	if setName == "" && conf.Blocklist != nil {
+		setName = conf.Blocklist.SetName
+	}
+
+	db, err := conf.Database.Open()
+	if err != nil {
+		return err
+	}
+
+	entries, err := core.GenerateBlocklist(db, conf.RuleScores(), filter)
+	if err != nil {
+		return err
+	}
+
+	out := os.Stdout
+	if output != "" {
+		if out, err = os.Create(output); err != nil {
+			return err
+		}
+		defer out.Close()
+	}
+
+	return core.WriteBlocklist(out, format, entries, setName)
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/evilsocket/islazy/fs"
+
+	"github.com/evilsocket/takuan/core"
+)
+
+func keygenCommand(args []string) error {
+	var (
+		secretKey = "takuan.key"
+		pubKey    = "takuan.pub"
+		force     = false
+	)
+
+	flags := flag.NewFlagSet("keygen", flag.ExitOnError)
+	flags.StringVar(&secretKey, "key", secretKey, "Output file of the secret key.")
+	flags.StringVar(&pubKey, "pubkey", pubKey, "Output file of the public key.")
+	flags.BoolVar(&force, "force", force, "Overwrite existing key files.")
+	flags.Parse(args)
+
+	if !force && (fs.Exists(secretKey) || fs.Exists(pubKey)) {
+		return fmt.Errorf("%s or %s already exist, use -force to overwrite them", secretKey, pubKey)
+	}
+
+	secret, public, err := core.GenerateKeyPair()
+	if err != nil {
+		return err
+	}
+
+	if err = ioutil.WriteFile(secretKey, secret, 0600); err != nil {
+		return err
+	} else if err = ioutil.WriteFile(pubKey, public, 0644); err != nil {
+		return err
+	}
+
+	fmt.Printf("secret key saved to %s, public key saved to %s\n", secretKey, pubKey)
+	return nil
+}
@@ -13,6 +13,13 @@ var (
 	aggregator = (*core.Aggregator)(nil)
 )
 
+var commands = map[string]func(args []string) error{
+	"blocklist": blocklistCommand,
+	"keygen":    keygenCommand,
+	"relocate":  relocateCommand,
+	"verify":    verifyCommand,
+}
+
 func main() {
 	var err error
 
@@ -21,16 +28,40 @@ func main() {
 	setup()
 	defer cleanup()
 
-	conf, err = core.Load(confFile)
+	// -geo is kept for backwards compatibility
+	if geoLocate {
+		if err = relocateCommand(nil); err != nil {
+			log.Fatal("%v", err)
+		}
+		return
+	}
+
+	if flag.NArg() > 0 {
+		command, found := commands[flag.Arg(0)]
+		if !found {
+			log.Fatal("unknown command '%s'", flag.Arg(0))
+		}
+		if err = command(flag.Args()[1:]); err != nil {
+			log.Fatal("%v", err)
+		}
+		return
+	}
+
+	conf, err = core.Parse(confFile)
 	if err != nil {
 		log.Fatal("%v", err)
 	}
 
+	conf.DryRun = conf.DryRun || dryRun
+	if err = conf.Init(); err != nil {
+		log.Fatal("%v", err)
+	}
+
 	aggregator = core.NewAggregator(conf)
 
 	log.Info("takuan service starting for node <%s> ...", conf.NodeName)
 
-	if err := aggregator.Start(geoLocate); err != nil {
+	if err := aggregator.Start(); err != nil {
 		log.Fatal("%v", err)
 	}
 }
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/evilsocket/islazy/log"
+
+	"github.com/evilsocket/takuan/core"
+)
+
+func relocateCommand(args []string) error {
+	var (
+		since     = ""
+		emptyOnly = false
+		dryRun    = false
+		chunkSize = 1000
+		stateFile = ""
+	)
+
+	flags := flag.NewFlagSet("relocate", flag.ExitOnError)
+	flags.StringVar(&since, "since", since, "Only update events after this time (RFC3339, YYYY-MM-DD or a duration like 30d).")
+	flags.BoolVar(&emptyOnly, "empty-only", emptyOnly, "Only update events without a country.")
+	flags.BoolVar(&dryRun, "dry-run", dryRun, "Only count the events that would be updated.")
+	flags.IntVar(&chunkSize, "chunk", chunkSize, "Number of distinct addresses processed per chunk.")
+	flags.StringVar(&stateFile, "state", stateFile, "If set, save progress to this file and resume from it.")
+	flags.Parse(args)
+
+	conf, err := core.Parse(confFile)
+	if err != nil {
+		return err
+	}
+
+	geo, err := core.OpenGeo(conf.Database)
+	if err != nil {
+		return err
+	}
+	defer geo.Close()
+
+	db, err := conf.Database.Open()
+	if err != nil {
+		return err
+	}
+
+	relocator := core.NewRelocator(db, geo)
+	relocator.EmptyOnly = emptyOnly
+	relocator.DryRun = dryRun
+	relocator.ChunkSize = chunkSize
+	relocator.StateFile = stateFile
+	relocator.Verbose = true
+
+	if since != "" {
+		if relocator.Since, err = core.ParseTime(since); err != nil {
+			return err
+		}
+	}
+
+	started := time.Now()
+	log.Info("updating IP locations ...")
+
+	updated, err := relocator.Run()
+	if err != nil {
+		return fmt.Errorf("error after %d updated events: %v", updated, err)
+	}
+
+	if dryRun {
+		log.Info("done: %d events would be updated (%s)", updated, time.Since(started))
+	} else {
+		log.Info("done: %d events updated (%s)", updated, time.Since(started))
+	}
+
+	return nil
+}
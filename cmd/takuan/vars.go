@@ -10,14 +10,16 @@ var (
 	debug     = false
 	confFile  = "config.yml"
 	geoLocate = false
+	dryRun    = false
 )
 
 func init() {
 	flag.BoolVar(&debug, "debug", debug, "Enable debug logs.")
 	flag.StringVar(&log.Output, "log", log.Output, "Log file path or empty for standard output.")
 	flag.StringVar(&confFile, "config", confFile, "Configuration file.")
+	flag.BoolVar(&dryRun, "dry-run", dryRun, "Write reports to a scratch directory, log notifications and don't mark events as reported.")
 
-	flag.BoolVar(&geoLocate, "geo", geoLocate, "Update IP address locations using the latest maxmind db.")
+	flag.BoolVar(&geoLocate, "geo", geoLocate, "Update IP address locations using the latest maxmind db (deprecated, use the relocate command).")
 }
 
 func setup() {
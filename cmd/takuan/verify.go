@@ -0,0 +1,64 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/evilsocket/takuan/core"
+)
+
+func verifyCommand(args []string) error {
+	var (
+		pubKey    = "takuan.pub"
+		signature = ""
+	)
+
+	flags := flag.NewFlagSet("verify", flag.ExitOnError)
+	flags.StringVar(&pubKey, "pubkey", pubKey, "Public key file or its base64 encoded key.")
+	flags.StringVar(&signature, "signature", signature, "Signature file, defaults to the report file name followed by .minisig.")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: takuan verify [options] <report file>\n\n")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return fmt.Errorf("no report file specified")
+	}
+
+	fileName := flags.Arg(0)
+	if signature == "" {
+		signature = fileName + ".minisig"
+	}
+
+	keyData, err := ioutil.ReadFile(pubKey)
+	if err != nil {
+		// the key itself can be passed on the command line
+		keyData = []byte(pubKey)
+	}
+
+	key, err := core.ParsePublicKey(keyData)
+	if err != nil {
+		return fmt.Errorf("error loading public key %s: %v", pubKey, err)
+	}
+
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return err
+	}
+
+	sig, err := ioutil.ReadFile(signature)
+	if err != nil {
+		return err
+	}
+
+	trusted, err := key.Verify(data, sig)
+	if err != nil {
+		return fmt.Errorf("%s: %v", fileName, err)
+	}
+
+	fmt.Printf("%s: signature and comment signature verified\ntrusted comment: %s\n", fileName, trusted)
+	return nil
+}
@@ -3,16 +3,12 @@ package core
 import (
 	"errors"
 	"fmt"
-	"net"
-	"os"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/evilsocket/islazy/log"
-	"github.com/oschwald/geoip2-golang"
-	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
 
 	"github.com/evilsocket/takuan/models"
 )
@@ -26,8 +22,43 @@ type Aggregator struct {
 
 	conf   *Config
 	db     *gorm.DB
-	geoip  *geoip2.Reader
+	geo    *Geo
 	buffer []models.Event
+
+	started    time.Time
+	statusLock sync.Mutex
+	state      aggregatorStatus
+}
+
+type aggregatorStatus struct {
+	flushedAt  time.Time
+	flushErr   error
+	reportedAt time.Time
+	reportErr  error
+}
+
+func (r *Aggregator) status() aggregatorStatus {
+	r.statusLock.Lock()
+	defer r.statusLock.Unlock()
+	return r.state
+}
+
+func (r *Aggregator) setFlushStatus(err error) {
+	r.statusLock.Lock()
+	defer r.statusLock.Unlock()
+	r.state.flushErr = err
+	if err == nil {
+		r.state.flushedAt = time.Now()
+	}
+}
+
+func (r *Aggregator) setReportStatus(err error) {
+	r.statusLock.Lock()
+	defer r.statusLock.Unlock()
+	r.state.reportErr = err
+	if err == nil {
+		r.state.reportedAt = time.Now()
+	}
 }
 
 func NewAggregator(conf *Config) *Aggregator {
@@ -41,9 +72,24 @@ func NewAggregator(conf *Config) *Aggregator {
 }
 
 func (r *Aggregator) addEvent(e models.Event) {
+	if r.conf.Responder != nil && r.conf.Responder.Enabled {
+		r.conf.Responder.OnEvent(e)
+	}
+
+	if r.conf.Alerts != nil && r.conf.Alerts.Enabled && r.conf.Alerts.Alerting(e) {
+		// events are located when saved, alerts can't wait for it
+		alerted := e
+		alerted.NodeName = r.conf.NodeName
+		if loc, err := r.geo.Locate(alerted.Address); err == nil {
+			loc.Apply(&alerted)
+		}
+		r.conf.Alerts.OnEvent(alerted)
+	}
+
 	r.Lock()
 	defer r.Unlock()
 	r.buffer = append(r.buffer, e)
+	metricBuffered.Set(float64(len(r.buffer)))
 }
 
 func (r *Aggregator) onNewBatch() {
@@ -51,6 +97,11 @@ func (r *Aggregator) onNewBatch() {
 	defer r.Unlock()
 
 	num := len(r.buffer)
+	var lastErr error
+
+	defer func() {
+		r.setFlushStatus(lastErr)
+	}()
 
 	if num > 0 {
 		log.Debug("saving %d new events", num)
@@ -59,65 +110,116 @@ func (r *Aggregator) onNewBatch() {
 
 		for i, event := range r.buffer {
 			event.NodeName = r.conf.NodeName
-			country, err := r.geoip.Country(net.ParseIP(event.Address))
-			if err == nil {
-				event.CountryCode = country.Country.IsoCode
-				event.CountryName = country.Country.Names["en"]
+			if loc, err := r.geo.Locate(event.Address); err == nil {
+				loc.Apply(&event)
+			} else {
+				metricGeoIPFailures.Inc()
 			}
 
-			/*
-				SLOW
+			if r.conf.Intel != nil && r.conf.Intel.Enabled {
+				event.Tags = strings.Join(r.conf.Intel.Match(event.Address), ",")
+			}
 
-				names, err := net.LookupAddr(event.Address)
-				if err == nil {
-					event.Hostname = names[0]
-				}
-			*/
+			// hostnames are resolved in background, then saved when available
+			resolve := false
+			if r.conf.RDNS != nil && r.conf.RDNS.Enabled {
+				hostname, cached := r.conf.RDNS.Cached(event.Address)
+				event.Hostname, resolve = hostname, !cached
+			}
 
 			if err := r.db.Create(&event).Error; err != nil {
 				log.Error("error saving event: %v", err)
+				metricFlushFailures.Inc()
+				lastErr = err
+			} else if resolve {
+				r.conf.RDNS.Enqueue(event.Address)
 			}
 
 			r.buffer[i] = event
 		}
 
 		log.Info("%d events saved in %s", num, time.Since(started))
+		metricFlushDuration.Observe(time.Since(started).Seconds())
 
 		r.buffer = make([]models.Event, 0)
+		metricBuffered.Set(0)
 	}
 }
 
-func (r *Aggregator) onReport() {
-	var unreported []models.Event
-    var reportURL string
-
-	err := r.db.Where("reported_at IS NULL").Find(&unreported).Error
+func (r *Aggregator) onHostname(address string, hostname string) {
+	log.Debug("%s -> %s", address, hostname)
+	err := r.db.Model(&models.Event{}).
+		Where("address = ? AND hostname = ?", address, "").
+		Update("hostname", hostname).Error
 	if err != nil {
-		log.Error("error getting unreported events: %v", err)
+		log.Error("error updating hostname for %s: %v", address, err)
+	}
+}
+
+func (r *Aggregator) onGeoReload() {
+	if hours := r.conf.Database.GeoIPRelocateHours; hours > 0 {
+		started := time.Now()
+		since := started.Add(-time.Duration(hours) * time.Hour)
+		log.Info("updating locations of events since %s ...", since.Format(time.RFC3339))
+		relocator := NewRelocator(r.db, r.geo)
+		relocator.Since = since
+		if updated, err := relocator.Run(); err != nil {
+			log.Error("error updating locations: %v", err)
+		} else {
+			log.Info("%d events updated in %s", updated, time.Since(started))
+		}
+	}
+}
+
+// onReport publishes the reports of the unreported events of each window, and the ones of the
+// events older than the first window that arrived after their window was reported. It returns
+// the number of windows fully reported, the others are left to the next cycle.
+func (r *Aggregator) onReport(windows []window) (reported int) {
+	var err error
+
+	started := time.Now()
+	defer func() {
+		metricReportDuration.Observe(time.Since(started).Seconds())
+		r.setReportStatus(err)
+	}()
+
+	if r.conf.Reporter.DryRun {
+		for _, w := range windows {
+			if err = r.dryRun(w); err != nil {
+				log.Error("%v", err)
+				metricReportFailures.Inc()
+				return
+			}
+			reported++
+		}
 		return
 	}
 
-	numUnreported := len(unreported)
-	if numUnreported > 0 {
-		log.Info("%d unreported events", numUnreported)
+	if err = r.republish(); err != nil {
+		log.Error("%v", err)
+		metricReportFailures.Inc()
+		return
+	}
 
-		if reportURL, err = r.conf.Reporter.OnBatch(unreported); err != nil {
+	if len(windows) > 0 && !windows[0].Start.IsZero() {
+		late := window{End: windows[0].Start}
+		if err = r.reportWindow(late, ReportName(late.End)+"_late"); err != nil {
 			log.Error("%v", err)
+			metricReportFailures.Inc()
 			return
 		}
+	}
 
-		now := time.Now()
-		for _, event := range unreported {
-			event.ReportedAt = &now
-			if err := r.db.Save(event).Error; err != nil {
-				log.Error("error updating event reported field: %v", err)
-			}
-		}
-
-		if reportURL != "" {
-			r.conf.Twitter.OnBatch(unreported, reportURL)
+	for _, w := range windows {
+		if err = r.reportWindow(w, ReportName(w.End)); err != nil {
+			log.Error("%v", err)
+			metricReportFailures.Inc()
+			return
 		}
+		reported++
 	}
+
+	return
 }
 
 func (r *Aggregator) sensorStateByName(sensorName string) int64 {
@@ -150,62 +252,57 @@ func (r *Aggregator) updateState(state models.SensorState) {
 	}
 }
 
-func (r *Aggregator) Start(geoLocate bool) (err error) {
-	r.geoip, err = geoip2.Open(r.conf.Database.GeoIP)
+func (r *Aggregator) Start() (err error) {
+	r.started = time.Now()
+
+	r.geo, err = OpenGeo(r.conf.Database)
 	if err != nil {
 		return err
 	}
 
-	r.db, err = gorm.Open(mysql.Open(r.conf.Database.URL), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Silent),
-	})
+	r.db, err = r.conf.Database.Open()
 	if err != nil {
 		return err
 	}
 
 	log.Debug("connected to the database")
 
-	err = r.db.AutoMigrate(&models.Event{}, &models.SensorState{})
+	err = r.db.AutoMigrate(&models.Event{}, &models.SensorState{}, &models.Report{}, &models.ReportState{}, &models.Digest{})
 	if err != nil {
 		return fmt.Errorf("error performing database migration: %v", err)
 	}
 
+	r.geo.Watch(r.onGeoReload)
 
-	if geoLocate {
-		log.Info("updating IP locations ...")
-
-		var events []models.Event
-
-		if err := r.db.Find(&events).Error; err != nil {
-			log.Fatal("error getting events: %v", err)
+	for _, notifier := range r.conf.Notifiers {
+		if notifier.Enabled {
+			notifier.Start()
 		}
+	}
 
-		num := len(events)
-		changed := 0
-		errors := 0
-
-		log.Info("processing %d events ...", num)
-
-		for _, event := range events {
-			if country, err := r.geoip.Country(net.ParseIP(event.Address)); err != nil {
-				log.Error("error locating %s: %v", event.Address, err)
-				errors++
-			} else if event.CountryCode != country.Country.IsoCode {
-				log.Info("%s : '%s' -> '%s'", event.Address, event.CountryName, country.Country.Names["en"])
-				event.CountryCode = country.Country.IsoCode
-				event.CountryName = country.Country.Names["en"]
-				if err := r.db.Save(event).Error; err != nil {
-					log.Error("error saving event: %v", err)
-					errors++
-				} else {
-					changed++
-				}
-			}
+	if r.conf.API != nil && r.conf.API.Enabled {
+		if err = r.conf.API.Start(r); err != nil {
+			return err
 		}
+		if r.conf.Blocklist != nil && r.conf.Blocklist.Enabled {
+			r.conf.Blocklist.Attach(r.conf.API, r.db, r.conf.RuleScores())
+		}
+	}
 
-		log.Info("done: %d changed, %d errors", changed, errors)
+	if r.conf.Responder != nil && r.conf.Responder.Enabled {
+		r.conf.Responder.Start()
+	}
 
-		os.Exit(0)
+	if r.conf.RDNS != nil && r.conf.RDNS.Enabled {
+		r.conf.RDNS.Start(r.onHostname)
+	}
+
+	if r.conf.Alerts != nil && r.conf.Alerts.Enabled {
+		r.conf.Alerts.Start()
+	}
+
+	if r.conf.Intel != nil && r.conf.Intel.Enabled {
+		r.conf.Intel.Start()
 	}
 
 	for _, sensor := range r.conf.Sensors {
@@ -225,15 +322,13 @@ func (r *Aggregator) Start(geoLocate bool) (err error) {
 	}()
 
 	if r.conf.Reporter.Enabled {
-		go func() {
-			log.Info("reporting every %d seconds", r.conf.Reporter.PeriodSecs)
-			// warm up period for parsers to generate data
-			time.Sleep(time.Duration(120) * time.Second)
-			for {
-				r.onReport()
-				time.Sleep(time.Duration(r.conf.Reporter.PeriodSecs) * time.Second)
-			}
-		}()
+		if r.conf.Reporter.Schedule != "" {
+			log.Info("reporting at '%s' after %d seconds", r.conf.Reporter.Schedule, r.conf.Reporter.WarmUpSecs)
+		} else {
+			log.Info("reporting every %d seconds after %d seconds", r.conf.Reporter.PeriodSecs, r.conf.Reporter.WarmUpSecs)
+		}
+		go r.reportLoop()
+		go r.digestLoop()
 	} else {
 		log.Info("reporting is disabled for this node")
 	}
@@ -247,9 +342,8 @@ func (r *Aggregator) Start(geoLocate bool) (err error) {
 			r.addEvent(event)
 
 		case err := <-r.ErrorBus:
+			metricErrors.WithLabelValues(errorType(err)).Inc()
 			log.Error("%v", err)
 		}
 	}
-
-	return nil
 }
@@ -0,0 +1,305 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/evilsocket/islazy/log"
+
+	"github.com/evilsocket/takuan/models"
+)
+
+const (
+	defaultAlertMinSeverity     = "high"
+	defaultAlertAddressCooldown = 3600
+	defaultAlertRuleCooldown    = 60
+	defaultAlertMaxAge          = 600
+	defaultAlertWindow          = 60
+)
+
+var severities = map[string]int{
+	"info":     0,
+	"low":      1,
+	"medium":   2,
+	"high":     3,
+	"critical": 4,
+}
+
+// Alert is a real-time notification of a rule match, available to the alert templates.
+type Alert struct {
+	Node        string    `json:"node"`
+	Sensor      string    `json:"sensor"`
+	Rule        string    `json:"rule"`
+	Description string    `json:"description"`
+	Severity    string    `json:"severity"`
+	Address     string    `json:"address,omitempty"`
+	CountryCode string    `json:"country_code,omitempty"`
+	CountryName string    `json:"country_name,omitempty"`
+	ASN         string    `json:"asn,omitempty"`
+	ASOrg       string    `json:"as_org,omitempty"`
+	Payload     string    `json:"payload,omitempty"`
+	DetectedAt  time.Time `json:"detected_at"`
+	// matches of the address in the window of the rule threshold
+	Events int           `json:"events"`
+	Window time.Duration `json:"-"`
+	// alerts of the same rule not sent because of the rule cooldown, 0 if this is the first one,
+	// Address, its location, Payload and Events are empty when this is a summary of the suppressed alerts
+	Suppressed int `json:"suppressed"`
+}
+
+// per rule cooldown state
+type ruleCooldown struct {
+	sentAt     time.Time
+	suppressed int
+	last       *Alert
+}
+
+// Alerts notifies rule matches as soon as they are parsed, for the rules with an alert
+// threshold or with at least MinSeverity.
+type Alerts struct {
+	sync.Mutex
+
+	Enabled     bool   `yaml:"enabled"`
+	MinSeverity string `yaml:"min_severity"`
+	// seconds before another alert for the same address and rule, and for the same rule
+	AddressCooldownSecs int `yaml:"address_cooldown"`
+	RuleCooldownSecs    int `yaml:"rule_cooldown"`
+	// older events, like old log lines parsed at startup, never alert
+	MaxAgeSecs int `yaml:"max_age"`
+	// names of the notifiers receiving the alerts, every enabled notifier if empty
+	Notifiers []string `yaml:"notifiers"`
+
+	rules     map[string]*Rule
+	notifiers []*ReportNotifier
+	hits      map[string][]time.Time
+	sent      map[string]time.Time
+	cooldowns map[string]*ruleCooldown
+}
+
+func (a *Alerts) Init(sensors []*Sensor, notifiers []*ReportNotifier) error {
+	if a.MinSeverity == "" {
+		a.MinSeverity = defaultAlertMinSeverity
+	} else if _, found := severities[a.MinSeverity]; !found {
+		return fmt.Errorf("unknown alerts min_severity '%s'", a.MinSeverity)
+	}
+	if a.AddressCooldownSecs <= 0 {
+		a.AddressCooldownSecs = defaultAlertAddressCooldown
+	}
+	if a.RuleCooldownSecs <= 0 {
+		a.RuleCooldownSecs = defaultAlertRuleCooldown
+	}
+	if a.MaxAgeSecs <= 0 {
+		a.MaxAgeSecs = defaultAlertMaxAge
+	}
+
+	a.rules = make(map[string]*Rule)
+	for _, sensor := range sensors {
+		for _, rule := range sensor.Rules {
+			if rule.Severity != "" {
+				if _, found := severities[rule.Severity]; !found {
+					return fmt.Errorf("unknown severity '%s' for rule %s/%s", rule.Severity, sensor.Name, rule.Name)
+				}
+			}
+			if rule.Alert != nil {
+				if rule.Alert.Events <= 0 {
+					rule.Alert.Events = 1
+				}
+				if rule.Alert.WindowSecs <= 0 {
+					rule.Alert.WindowSecs = defaultAlertWindow
+				}
+			}
+			if a.alerting(rule) {
+				a.rules[sensor.Name+"/"+rule.Name] = rule
+			}
+		}
+	}
+
+	a.notifiers = make([]*ReportNotifier, 0)
+	for _, notifier := range notifiers {
+		if notifier.Enabled && (len(a.Notifiers) == 0 || hasTag(a.Notifiers, notifier.Name)) {
+			a.notifiers = append(a.notifiers, notifier)
+		}
+	}
+	for _, name := range a.Notifiers {
+		found := false
+		for _, notifier := range a.notifiers {
+			found = found || notifier.Name == name
+		}
+		if !found {
+			return fmt.Errorf("alerts notifier '%s' is not configured or not enabled", name)
+		}
+	}
+
+	a.hits = make(map[string][]time.Time)
+	a.sent = make(map[string]time.Time)
+	a.cooldowns = make(map[string]*ruleCooldown)
+
+	return nil
+}
+
+func (a *Alerts) alerting(rule *Rule) bool {
+	return rule.Alert != nil || (rule.Severity != "" && severities[rule.Severity] >= severities[a.MinSeverity])
+}
+
+// rules without their own threshold alert at the first match
+func (a *Alerts) thresholdFor(rule *Rule) *Threshold {
+	if rule.Alert != nil {
+		return rule.Alert
+	}
+	return &Threshold{Events: 1}
+}
+
+// Alerting returns true if the rule of the event can alert, before it is located.
+func (a *Alerts) Alerting(event models.Event) bool {
+	_, found := a.rules[event.Sensor+"/"+event.Rule]
+	return found
+}
+
+func (a *Alerts) OnEvent(event models.Event) {
+	a.Lock()
+	defer a.Unlock()
+
+	ruleName := event.Sensor + "/" + event.Rule
+	rule, found := a.rules[ruleName]
+	if !found || time.Since(event.CreatedAt) > time.Duration(a.MaxAgeSecs)*time.Second {
+		return
+	}
+
+	// windows and cooldowns use the detection time, log datetimes can be skewed or in the future
+	now := event.DetectedAt
+	key := event.Address + "/" + ruleName
+	if sentAt, found := a.sent[key]; found && now.Sub(sentAt) < time.Duration(a.AddressCooldownSecs)*time.Second {
+		return
+	}
+
+	threshold := a.thresholdFor(rule)
+	window := time.Duration(threshold.WindowSecs) * time.Second
+	hits := make([]time.Time, 0)
+	for _, t := range append(a.hits[key], now) {
+		if window == 0 || now.Sub(t) <= window {
+			hits = append(hits, t)
+		}
+	}
+	if len(hits) < threshold.Events {
+		a.hits[key] = hits
+		return
+	}
+
+	delete(a.hits, key)
+	a.sent[key] = now
+
+	alert := &Alert{
+		Node:        event.NodeName,
+		Sensor:      event.Sensor,
+		Rule:        ruleName,
+		Description: rule.Description,
+		Severity:    rule.Severity,
+		Address:     event.Address,
+		CountryCode: event.CountryCode,
+		CountryName: event.CountryName,
+		ASN:         asnString(event.ASN),
+		ASOrg:       event.ASOrg,
+		Payload:     event.Payload,
+		DetectedAt:  event.CreatedAt,
+		Events:      len(hits),
+		Window:      window,
+	}
+
+	// bursts on the same rule are summarized once its cooldown expires
+	cooldown, found := a.cooldowns[ruleName]
+	if !found {
+		cooldown = &ruleCooldown{}
+		a.cooldowns[ruleName] = cooldown
+	}
+	if now.Sub(cooldown.sentAt) < time.Duration(a.RuleCooldownSecs)*time.Second {
+		cooldown.suppressed++
+		cooldown.last = alert
+		metricAlerts.WithLabelValues(ruleName, "suppressed").Inc()
+		return
+	}
+
+	alert.Suppressed = cooldown.suppressed
+	cooldown.sentAt = now
+	cooldown.suppressed = 0
+	cooldown.last = nil
+	a.enqueue(alert)
+}
+
+func (a *Alerts) enqueue(alert *Alert) {
+	notification := &Notification{
+		ID:    fmt.Sprintf("%s-alert-%s-%d", alert.Node, strings.Replace(alert.Rule, "/", "-", -1), time.Now().UnixNano()),
+		Node:  alert.Node,
+		Alert: alert,
+	}
+
+	// each notifier retries with a backoff from its own queue, an unreachable one doesn't delay the others
+	queued := false
+	for _, notifier := range a.notifiers {
+		if notifier.enqueue(notification) {
+			queued = true
+		}
+	}
+
+	if queued {
+		log.Info("alert: %s %s from %s (%d events, %d suppressed)", alert.Severity, alert.Rule, alert.Address, alert.Events, alert.Suppressed)
+		metricAlerts.WithLabelValues(alert.Rule, "sent").Inc()
+	} else {
+		log.Warning("notifier queues are full, dropping %s alert for %s", alert.Rule, alert.Address)
+		metricAlerts.WithLabelValues(alert.Rule, "dropped").Inc()
+	}
+}
+
+// onExpiry sends the summaries of the rules whose cooldown expired and cleans up the state.
+func (a *Alerts) onExpiry() {
+	a.Lock()
+	defer a.Unlock()
+
+	now := time.Now()
+	for ruleName, cooldown := range a.cooldowns {
+		if now.Sub(cooldown.sentAt) < time.Duration(a.RuleCooldownSecs)*time.Second {
+			continue
+		} else if cooldown.suppressed > 0 {
+			// only the rule is summarized, not the last address
+			summary := *cooldown.last
+			summary.Address = ""
+			summary.CountryCode, summary.CountryName = "", ""
+			summary.ASN, summary.ASOrg = "", ""
+			summary.Payload = ""
+			summary.Events = 0
+			summary.Suppressed = cooldown.suppressed
+			cooldown.sentAt = now
+			cooldown.suppressed = 0
+			cooldown.last = nil
+			a.enqueue(&summary)
+		} else {
+			delete(a.cooldowns, ruleName)
+		}
+	}
+
+	for key, sentAt := range a.sent {
+		if now.Sub(sentAt) >= time.Duration(a.AddressCooldownSecs)*time.Second {
+			delete(a.sent, key)
+		}
+	}
+
+	for key, hits := range a.hits {
+		rule := a.rules[key[strings.Index(key, "/")+1:]]
+		window := time.Duration(a.thresholdFor(rule).WindowSecs) * time.Second
+		if len(hits) > 0 && window > 0 && now.Sub(hits[len(hits)-1]) > window {
+			delete(a.hits, key)
+		}
+	}
+}
+
+func (a *Alerts) Start() {
+	log.Info("alerts enabled for %d rules (min_severity=%s) to %d notifiers", len(a.rules), a.MinSeverity, len(a.notifiers))
+
+	go func() {
+		ticker := time.NewTicker(time.Duration(10) * time.Second)
+		for range ticker.C {
+			a.onExpiry()
+		}
+	}()
+}
@@ -0,0 +1,437 @@
+package core
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/evilsocket/islazy/log"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"gorm.io/gorm"
+
+	"github.com/evilsocket/takuan/models"
+)
+
+const (
+	defaultPerPage    = 100
+	defaultMaxPerPage = 1000
+)
+
+type API struct {
+	Enabled    bool   `yaml:"enabled"`
+	Address    string `yaml:"address"`
+	MaxPerPage int    `yaml:"max_per_page"`
+	Health     Health `yaml:"health"`
+
+	agg *Aggregator
+	db  *gorm.DB
+	mux *http.ServeMux
+}
+
+// anything that can be rendered as a csv table
+type csvTable interface {
+	csvHeader() []string
+	csvRecords() [][]string
+}
+
+type page struct {
+	Total   int64       `json:"total"`
+	Page    int         `json:"page"`
+	PerPage int         `json:"per_page"`
+	Items   interface{} `json:"items"`
+}
+
+type eventList []models.Event
+
+func (l eventList) csvHeader() []string {
+	return []string{"created_at", "detected_at", "node_name", "address", "country_code", "country_name", "city", "asn", "as_org", "hostname", "tags", "sensor", "rule", "payload"}
+}
+
+func (l eventList) csvRecords() [][]string {
+	records := make([][]string, 0, len(l))
+	for _, e := range l {
+		records = append(records, []string{
+			e.CreatedAt.Format(time.RFC3339),
+			e.DetectedAt.Format(time.RFC3339),
+			e.NodeName,
+			e.Address,
+			e.CountryCode,
+			e.CountryName,
+			e.City,
+			asnString(e.ASN),
+			e.ASOrg,
+			e.Hostname,
+			e.Tags,
+			e.Sensor,
+			e.Rule,
+			e.Payload,
+		})
+	}
+	return records
+}
+
+type offender struct {
+	Address     string    `json:"address"`
+	CountryCode string    `json:"country_code"`
+	CountryName string    `json:"country_name"`
+	Events      int64     `json:"events"`
+	FirstSeen   time.Time `json:"first_seen"`
+	LastSeen    time.Time `json:"last_seen"`
+}
+
+type offenderList []offender
+
+func (l offenderList) csvHeader() []string {
+	return []string{"address", "country_code", "country_name", "events", "first_seen", "last_seen"}
+}
+
+func (l offenderList) csvRecords() [][]string {
+	records := make([][]string, 0, len(l))
+	for _, o := range l {
+		records = append(records, []string{
+			o.Address,
+			o.CountryCode,
+			o.CountryName,
+			fmt.Sprintf("%d", o.Events),
+			o.FirstSeen.Format(time.RFC3339),
+			o.LastSeen.Format(time.RFC3339),
+		})
+	}
+	return records
+}
+
+type counter struct {
+	Name      string `json:"name"`
+	Events    int64  `json:"events"`
+	Addresses int64  `json:"addresses"`
+}
+
+type counterList []counter
+
+func (l counterList) csvHeader() []string {
+	return []string{"name", "events", "addresses"}
+}
+
+func (l counterList) csvRecords() [][]string {
+	records := make([][]string, 0, len(l))
+	for _, c := range l {
+		records = append(records, []string{
+			c.Name,
+			fmt.Sprintf("%d", c.Events),
+			fmt.Sprintf("%d", c.Addresses),
+		})
+	}
+	return records
+}
+
+type addressSummary struct {
+	Address     string           `json:"address"`
+	CountryCode string           `json:"country_code"`
+	CountryName string           `json:"country_name"`
+	Events      int64            `json:"events"`
+	FirstSeen   time.Time        `json:"first_seen"`
+	LastSeen    time.Time        `json:"last_seen"`
+	Rules       map[string]int64 `json:"rules"`
+	Sensors     map[string]int64 `json:"sensors"`
+	Nodes       map[string]int64 `json:"nodes"`
+}
+
+func (s addressSummary) csvHeader() []string {
+	return []string{"address", "country_code", "country_name", "events", "first_seen", "last_seen", "rules", "sensors", "nodes"}
+}
+
+func joinCounters(m map[string]int64) string {
+	parts := make([]string, 0, len(m))
+	for name, count := range m {
+		parts = append(parts, fmt.Sprintf("%s:%d", name, count))
+	}
+	return strings.Join(parts, "|")
+}
+
+func (s addressSummary) csvRecords() [][]string {
+	return [][]string{{
+		s.Address,
+		s.CountryCode,
+		s.CountryName,
+		fmt.Sprintf("%d", s.Events),
+		s.FirstSeen.Format(time.RFC3339),
+		s.LastSeen.Format(time.RFC3339),
+		joinCounters(s.Rules),
+		joinCounters(s.Sensors),
+		joinCounters(s.Nodes),
+	}}
+}
+
+func (a *API) Start(agg *Aggregator) error {
+	if a.MaxPerPage <= 0 {
+		a.MaxPerPage = defaultMaxPerPage
+	}
+
+	a.agg = agg
+	a.db = agg.db
+	a.mux = http.NewServeMux()
+
+	a.Handle("/api/v1/events", a.onEvents)
+	a.Handle("/api/v1/addresses/", a.onAddress)
+	a.Handle("/api/v1/offenders", a.onOffenders)
+	a.Handle("/api/v1/stats/rules", a.onStats("rule"))
+	a.Handle("/api/v1/stats/countries", a.onStats("country_code"))
+	a.Handle("/api/v1/stats/asns", a.onStats("asn"))
+	a.Handle("/metrics", promhttp.Handler().ServeHTTP)
+	a.Handle("/healthz", a.onHealth(false))
+	a.Handle("/readyz", a.onHealth(true))
+
+	listener, err := net.Listen("tcp", a.Address)
+	if err != nil {
+		return fmt.Errorf("error starting api on %s: %v", a.Address, err)
+	}
+
+	log.Info("api listening on %s", a.Address)
+
+	go func() {
+		if err := http.Serve(listener, a.mux); err != nil {
+			log.Error("api server stopped: %v", err)
+		}
+	}()
+
+	return nil
+}
+
+// the api is read only, so only GET and HEAD requests are routed
+func (a *API) Handle(pattern string, handler http.HandlerFunc) {
+	a.mux.HandleFunc(pattern, func(w http.ResponseWriter, req *http.Request) {
+		log.Debug("api: %s %s %s", req.RemoteAddr, req.Method, req.URL)
+		if req.Method != http.MethodGet && req.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			a.onError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", req.Method))
+			return
+		}
+		handler(w, req)
+	})
+}
+
+func (a *API) onError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
+
+func wantsCSV(req *http.Request) bool {
+	if format := req.URL.Query().Get("format"); format != "" {
+		return format == "csv"
+	}
+	return strings.Contains(req.Header.Get("Accept"), "text/csv")
+}
+
+func (a *API) respond(w http.ResponseWriter, req *http.Request, payload interface{}, table csvTable) {
+	if wantsCSV(req) {
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		writer := csv.NewWriter(w)
+		writer.Write(table.csvHeader())
+		writer.WriteAll(table.csvRecords())
+		writer.Flush()
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(payload); err != nil {
+		log.Error("api: error encoding response: %v", err)
+	}
+}
+
+func (a *API) pagination(req *http.Request) (pageNum int, perPage int, err error) {
+	q := req.URL.Query()
+
+	pageNum, perPage = 1, defaultPerPage
+	if v := q.Get("page"); v != "" {
+		if pageNum, err = strconv.Atoi(v); err != nil || pageNum < 1 {
+			return 0, 0, fmt.Errorf("invalid page '%s'", v)
+		}
+	}
+	if v := q.Get("per_page"); v != "" {
+		if perPage, err = strconv.Atoi(v); err != nil || perPage < 1 {
+			return 0, 0, fmt.Errorf("invalid per_page '%s'", v)
+		}
+	}
+	if perPage > a.MaxPerPage {
+		perPage = a.MaxPerPage
+	}
+	return
+}
+
+// gorm statements can't be reused after a finisher, so every query gets its own
+func (a *API) events(filter *EventFilter) *gorm.DB {
+	return a.db.Model(&models.Event{}).Scopes(filter.Scope)
+}
+
+func (a *API) onEvents(w http.ResponseWriter, req *http.Request) {
+	pageNum, perPage, err := a.pagination(req)
+	if err != nil {
+		a.onError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	filter, err := ParseEventFilter(req.URL.Query())
+	if err != nil {
+		a.onError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var total int64
+	if err = a.events(filter).Count(&total).Error; err != nil {
+		a.onError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	events := make(eventList, 0)
+	err = a.events(filter).Order("id DESC").Limit(perPage).Offset((pageNum - 1) * perPage).Find(&events).Error
+	if err != nil {
+		a.onError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.Header().Set("X-Total-Count", fmt.Sprintf("%d", total))
+	a.respond(w, req, page{
+		Total:   total,
+		Page:    pageNum,
+		PerPage: perPage,
+		Items:   events,
+	}, events)
+}
+
+func (a *API) onAddress(w http.ResponseWriter, req *http.Request) {
+	address := strings.Trim(strings.TrimPrefix(req.URL.Path, "/api/v1/addresses/"), "/")
+	if net.ParseIP(address) == nil {
+		a.onError(w, http.StatusBadRequest, fmt.Errorf("invalid address '%s'", address))
+		return
+	}
+
+	var o offender
+	err := a.db.Model(&models.Event{}).Where("address = ?", address).Select("address, MAX(country_code) AS country_code, MAX(country_name) AS country_name, " +
+		"COUNT(*) AS events, MIN(created_at) AS first_seen, MAX(created_at) AS last_seen").
+		Group("address").
+		Scan(&o).Error
+	if err != nil {
+		a.onError(w, http.StatusInternalServerError, err)
+		return
+	} else if o.Events == 0 {
+		a.onError(w, http.StatusNotFound, fmt.Errorf("no events for %s", address))
+		return
+	}
+
+	summary := addressSummary{
+		Address:     o.Address,
+		CountryCode: o.CountryCode,
+		CountryName: o.CountryName,
+		Events:      o.Events,
+		FirstSeen:   o.FirstSeen,
+		LastSeen:    o.LastSeen,
+	}
+
+	for field, dest := range map[string]*map[string]int64{
+		"rule":      &summary.Rules,
+		"sensor":    &summary.Sensors,
+		"node_name": &summary.Nodes,
+	} {
+		if *dest, err = a.countBy(a.db.Model(&models.Event{}).Where("address = ?", address), field); err != nil {
+			a.onError(w, http.StatusInternalServerError, err)
+			return
+		}
+	}
+
+	a.respond(w, req, summary, summary)
+}
+
+func (a *API) countBy(query *gorm.DB, field string) (map[string]int64, error) {
+	var counters []counter
+	err := query.Select(fmt.Sprintf("%s AS name, COUNT(*) AS events", field)).
+		Group(field).
+		Scan(&counters).Error
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]int64)
+	for _, c := range counters {
+		byName[c.Name] = c.Events
+	}
+	return byName, nil
+}
+
+func (a *API) onOffenders(w http.ResponseWriter, req *http.Request) {
+	pageNum, perPage, err := a.pagination(req)
+	if err != nil {
+		a.onError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// top-n is just the first page with a custom size
+	if limit := req.URL.Query().Get("limit"); limit != "" {
+		if perPage, err = strconv.Atoi(limit); err != nil || perPage < 1 {
+			a.onError(w, http.StatusBadRequest, fmt.Errorf("invalid limit '%s'", limit))
+			return
+		} else if perPage > a.MaxPerPage {
+			perPage = a.MaxPerPage
+		}
+	}
+
+	filter, err := ParseEventFilter(req.URL.Query())
+	if err != nil {
+		a.onError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var total int64
+	if err = a.events(filter).Distinct("address").Count(&total).Error; err != nil {
+		a.onError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	offenders := make(offenderList, 0)
+	err = a.events(filter).Select("address, MAX(country_code) AS country_code, MAX(country_name) AS country_name, " +
+		"COUNT(*) AS events, MIN(created_at) AS first_seen, MAX(created_at) AS last_seen").
+		Group("address").
+		Order("events DESC, address").
+		Limit(perPage).
+		Offset((pageNum - 1) * perPage).
+		Scan(&offenders).Error
+	if err != nil {
+		a.onError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.Header().Set("X-Total-Count", fmt.Sprintf("%d", total))
+	a.respond(w, req, page{
+		Total:   total,
+		Page:    pageNum,
+		PerPage: perPage,
+		Items:   offenders,
+	}, offenders)
+}
+
+func (a *API) onStats(field string) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		filter, err := ParseEventFilter(req.URL.Query())
+		if err != nil {
+			a.onError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		counters := make(counterList, 0)
+		err = a.events(filter).Select(fmt.Sprintf("%s AS name, COUNT(*) AS events, COUNT(DISTINCT address) AS addresses", field)).
+			Group(field).
+			Order("events DESC").
+			Scan(&counters).Error
+		if err != nil {
+			a.onError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		a.respond(w, req, counters, counters)
+	}
+}
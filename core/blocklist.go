@@ -0,0 +1,497 @@
+package core
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/evilsocket/takuan/models"
+)
+
+const (
+	defaultSetName           = "takuan"
+	maxBlocklistCacheEntries = 1024
+)
+
+type Blocklist struct {
+	sync.Mutex
+
+	Enabled   bool   `yaml:"enabled"`
+	SetName   string `yaml:"set_name"`
+	CacheSecs int    `yaml:"cache"`
+	MinEvents int    `yaml:"min_events"`
+
+	db       *gorm.DB
+	scores   map[string]int64
+	cache    map[string]*blocklistCacheEntry
+	inflight map[string]*blocklistCall
+}
+
+type BlocklistFilter struct {
+	EventFilter
+	MinEvents int
+	MinScore  int
+}
+
+type BlocklistEntry struct {
+	Address     string    `json:"address"`
+	CountryCode string    `json:"country_code"`
+	Events      int64     `json:"events"`
+	Score       int64     `json:"score"`
+	FirstSeen   time.Time `json:"first_seen"`
+	LastSeen    time.Time `json:"last_seen"`
+	Rules       []string  `json:"rules"`
+}
+
+func ParseBlocklistFilter(q url.Values) (*BlocklistFilter, error) {
+	filter, err := ParseEventFilter(q)
+	if err != nil {
+		return nil, err
+	}
+
+	f := &BlocklistFilter{EventFilter: *filter}
+	if v := q.Get("min_events"); v != "" {
+		if f.MinEvents, err = strconv.Atoi(v); err != nil {
+			return nil, fmt.Errorf("invalid min_events '%s'", v)
+		}
+	}
+	if v := q.Get("min_score"); v != "" {
+		if f.MinScore, err = strconv.Atoi(v); err != nil {
+			return nil, fmt.Errorf("invalid min_score '%s'", v)
+		}
+	}
+	return f, nil
+}
+
+// the score of an address is the sum of the scores of the rules it triggered,
+// each rule scoring 1 per event unless configured otherwise
+func (c *Config) RuleScores() map[string]int64 {
+	scores := make(map[string]int64)
+	for _, sensor := range c.Sensors {
+		for _, rule := range sensor.Rules {
+			score := int64(rule.Score)
+			if score == 0 {
+				score = 1
+			}
+			scores[sensor.Name+"/"+rule.Name] = score
+		}
+	}
+	return scores
+}
+
+func GenerateBlocklist(db *gorm.DB, scores map[string]int64, filter *BlocklistFilter) ([]*BlocklistEntry, error) {
+	rows, err := db.Model(&models.Event{}).
+		Scopes(filter.Scope).
+		Select("address, sensor, rule, MAX(country_code), COUNT(*), MIN(created_at), MAX(created_at)").
+		Group("address, sensor, rule").
+		Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	byAddress := make(map[string]*BlocklistEntry)
+	for rows.Next() {
+		var address, sensor, rule, country string
+		var count int64
+		var first, last time.Time
+
+		if err = rows.Scan(&address, &sensor, &rule, &country, &count, &first, &last); err != nil {
+			return nil, err
+		}
+
+		entry, found := byAddress[address]
+		if !found {
+			entry = &BlocklistEntry{
+				Address:   address,
+				FirstSeen: first,
+				LastSeen:  last,
+				Rules:     make([]string, 0),
+			}
+			byAddress[address] = entry
+		}
+
+		if country != "" {
+			entry.CountryCode = country
+		}
+		if first.Before(entry.FirstSeen) {
+			entry.FirstSeen = first
+		}
+		if last.After(entry.LastSeen) {
+			entry.LastSeen = last
+		}
+
+		score, found := scores[sensor+"/"+rule]
+		if !found {
+			score = 1
+		}
+
+		entry.Events += count
+		entry.Score += score * count
+		entry.Rules = append(entry.Rules, sensor+"/"+rule)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	entries := make([]*BlocklistEntry, 0, len(byAddress))
+	for _, entry := range byAddress {
+		if entry.Events >= int64(filter.MinEvents) && entry.Score >= int64(filter.MinScore) && net.ParseIP(entry.Address) != nil {
+			sort.Strings(entry.Rules)
+			entries = append(entries, entry)
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Score != entries[j].Score {
+			return entries[i].Score > entries[j].Score
+		}
+		return entries[i].Address < entries[j].Address
+	})
+
+	return entries, nil
+}
+
+type blocklistWriter func(w io.Writer, entries []*BlocklistEntry, setName string) error
+
+var BlocklistFormats = map[string]blocklistWriter{
+	"plain":     writePlainBlocklist,
+	"cidr":      writeCIDRBlocklist,
+	"ipset":     writeIPSetBlocklist,
+	"nft":       writeNFTBlocklist,
+	"iptables":  writeIPTablesBlocklist(false),
+	"ip6tables": writeIPTablesBlocklist(true),
+	"json":      writeJSONBlocklist,
+	"csv":       writeCSVBlocklist,
+}
+
+var blocklistContentTypes = map[string]string{
+	"json": "application/json",
+	"csv":  "text/csv; charset=utf-8",
+}
+
+func WriteBlocklist(w io.Writer, format string, entries []*BlocklistEntry, setName string) error {
+	writer, found := BlocklistFormats[format]
+	if !found {
+		return fmt.Errorf("unknown blocklist format '%s'", format)
+	}
+	if setName == "" {
+		setName = defaultSetName
+	}
+	return writer(w, entries, setName)
+}
+
+func blocklistHeader(w io.Writer, entries []*BlocklistEntry) {
+	// no timestamps here, the same list must always generate the same etag
+	fmt.Fprintf(w, "# takuan blocklist, %d addresses\n", len(entries))
+}
+
+// networks by family, ipv4 first
+func blocklistNetworks(entries []*BlocklistEntry) (v4 []*net.IPNet, v6 []*net.IPNet) {
+	ips := make([]net.IP, 0, len(entries))
+	for _, entry := range entries {
+		ips = append(ips, net.ParseIP(entry.Address))
+	}
+
+	for _, network := range AggregateCIDRs(ips) {
+		if network.IP.To4() != nil {
+			v4 = append(v4, network)
+		} else {
+			v6 = append(v6, network)
+		}
+	}
+	return
+}
+
+func joinNetworks(networks []*net.IPNet) string {
+	parts := make([]string, 0, len(networks))
+	for _, network := range networks {
+		parts = append(parts, network.String())
+	}
+	return strings.Join(parts, ", ")
+}
+
+func writePlainBlocklist(w io.Writer, entries []*BlocklistEntry, setName string) error {
+	blocklistHeader(w, entries)
+	for _, entry := range entries {
+		if _, err := fmt.Fprintln(w, entry.Address); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeCIDRBlocklist(w io.Writer, entries []*BlocklistEntry, setName string) error {
+	blocklistHeader(w, entries)
+	v4, v6 := blocklistNetworks(entries)
+	for _, network := range append(v4, v6...) {
+		if _, err := fmt.Fprintln(w, network.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// to be loaded with: ipset restore -exist < blocklist
+func writeIPSetBlocklist(w io.Writer, entries []*BlocklistEntry, setName string) error {
+	blocklistHeader(w, entries)
+	v4, v6 := blocklistNetworks(entries)
+	for _, family := range []struct {
+		name     string
+		family   string
+		networks []*net.IPNet
+	}{
+		{setName, "inet", v4},
+		{setName + "6", "inet6", v6},
+	} {
+		fmt.Fprintf(w, "create %s hash:net family %s -exist\n", family.name, family.family)
+		fmt.Fprintf(w, "flush %s\n", family.name)
+		for _, network := range family.networks {
+			if _, err := fmt.Fprintf(w, "add %s %s\n", family.name, network); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// to be loaded with: nft -f blocklist
+func writeNFTBlocklist(w io.Writer, entries []*BlocklistEntry, setName string) error {
+	blocklistHeader(w, entries)
+	v4, v6 := blocklistNetworks(entries)
+	fmt.Fprintf(w, "add table inet %s\n", setName)
+	for _, family := range []struct {
+		name     string
+		addrType string
+		networks []*net.IPNet
+	}{
+		{setName + "_v4", "ipv4_addr", v4},
+		{setName + "_v6", "ipv6_addr", v6},
+	} {
+		fmt.Fprintf(w, "add set inet %s %s { type %s; flags interval; }\n", setName, family.name, family.addrType)
+		fmt.Fprintf(w, "flush set inet %s %s\n", setName, family.name)
+		// nft does not accept empty element lists
+		if len(family.networks) > 0 {
+			if _, err := fmt.Fprintf(w, "add element inet %s %s { %s }\n", setName, family.name, joinNetworks(family.networks)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// to be loaded with: iptables-restore --noflush < blocklist
+func writeIPTablesBlocklist(v6 bool) blocklistWriter {
+	return func(w io.Writer, entries []*BlocklistEntry, setName string) error {
+		blocklistHeader(w, entries)
+		v4nets, v6nets := blocklistNetworks(entries)
+		networks := v4nets
+		if v6 {
+			networks = v6nets
+		}
+
+		chain := strings.ToUpper(setName)
+		fmt.Fprintf(w, "*filter\n:%s - [0:0]\n-F %s\n", chain, chain)
+		for _, network := range networks {
+			if _, err := fmt.Fprintf(w, "-A %s -s %s -j DROP\n", chain, network); err != nil {
+				return err
+			}
+		}
+		_, err := fmt.Fprintln(w, "COMMIT")
+		return err
+	}
+}
+
+func writeJSONBlocklist(w io.Writer, entries []*BlocklistEntry, setName string) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(entries)
+}
+
+func writeCSVBlocklist(w io.Writer, entries []*BlocklistEntry, setName string) error {
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"address", "country_code", "events", "score", "first_seen", "last_seen", "rules"})
+	for _, entry := range entries {
+		writer.Write([]string{
+			entry.Address,
+			entry.CountryCode,
+			fmt.Sprintf("%d", entry.Events),
+			fmt.Sprintf("%d", entry.Score),
+			entry.FirstSeen.Format(time.RFC3339),
+			entry.LastSeen.Format(time.RFC3339),
+			strings.Join(entry.Rules, "|"),
+		})
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+type blocklistCacheEntry struct {
+	body      []byte
+	etag      string
+	modified  time.Time
+	generated time.Time
+}
+
+// a list being generated, concurrent requests for the same query wait for it
+type blocklistCall struct {
+	done  chan struct{}
+	entry *blocklistCacheEntry
+	err   error
+}
+
+func (b *Blocklist) generate(query url.Values) (*blocklistCacheEntry, error) {
+	format := query.Get("format")
+	if format == "" {
+		format = "plain"
+	}
+
+	filter, err := ParseBlocklistFilter(query)
+	if err != nil {
+		return nil, err
+	} else if query.Get("min_events") == "" {
+		filter.MinEvents = b.MinEvents
+	}
+
+	entries, err := GenerateBlocklist(b.db, b.scores, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := bytes.Buffer{}
+	if err = WriteBlocklist(&buf, format, entries, b.SetName); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &blocklistCacheEntry{
+		body:      buf.Bytes(),
+		etag:      fmt.Sprintf("\"%x\"", sha1.Sum(buf.Bytes())),
+		modified:  now,
+		generated: now,
+	}, nil
+}
+
+// returns the cached list for this query if still fresh, or generates it again without
+// holding the lock, once for all the concurrent requests of the same query
+func (b *Blocklist) get(query url.Values) (*blocklistCacheEntry, error) {
+	key := query.Encode()
+
+	b.Lock()
+	if cached, found := b.cache[key]; found && time.Since(cached.generated) < time.Duration(b.CacheSecs)*time.Second {
+		b.Unlock()
+		return cached, nil
+	} else if call, running := b.inflight[key]; running {
+		b.Unlock()
+		<-call.done
+		return call.entry, call.err
+	}
+	call := &blocklistCall{done: make(chan struct{})}
+	b.inflight[key] = call
+	b.Unlock()
+
+	fresh, err := b.generate(query)
+
+	b.Lock()
+	delete(b.inflight, key)
+	if call.err = err; err == nil {
+		call.entry = b.store(key, fresh)
+	}
+	b.Unlock()
+
+	close(call.done)
+	return call.entry, call.err
+}
+
+// store caches a generated list, the modification time only changes when the contents do
+func (b *Blocklist) store(key string, fresh *blocklistCacheEntry) *blocklistCacheEntry {
+	if cached, found := b.cache[key]; found && fresh.etag == cached.etag {
+		cached.generated = fresh.generated
+		return cached
+	}
+
+	// don't let random query strings grow the cache forever
+	if len(b.cache) >= maxBlocklistCacheEntries {
+		oldest := ""
+		for k, e := range b.cache {
+			if time.Since(e.generated) >= time.Duration(b.CacheSecs)*time.Second {
+				delete(b.cache, k)
+			} else if oldest == "" || e.generated.Before(b.cache[oldest].generated) {
+				oldest = k
+			}
+		}
+		if len(b.cache) >= maxBlocklistCacheEntries {
+			delete(b.cache, oldest)
+		}
+	}
+
+	b.cache[key] = fresh
+	return fresh
+}
+
+func (b *Blocklist) Attach(api *API, db *gorm.DB, scores map[string]int64) {
+	b.db = db
+	b.scores = scores
+	b.cache = make(map[string]*blocklistCacheEntry)
+	b.inflight = make(map[string]*blocklistCall)
+	api.Handle("/api/v1/blocklist", b.onRequest(api))
+}
+
+func (b *Blocklist) onRequest(api *API) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		query := req.URL.Query()
+		format := query.Get("format")
+		if _, err := ParseBlocklistFilter(query); err != nil {
+			api.onError(w, http.StatusBadRequest, err)
+			return
+		} else if _, found := BlocklistFormats[format]; format != "" && !found {
+			api.onError(w, http.StatusBadRequest, fmt.Errorf("unknown blocklist format '%s'", format))
+			return
+		}
+
+		// the query is valid, anything failing from here on is on our side
+		entry, err := b.get(query)
+		if err != nil {
+			api.onError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		contentType, found := blocklistContentTypes[format]
+		if !found {
+			contentType = "text/plain; charset=utf-8"
+		}
+
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("ETag", entry.etag)
+		w.Header().Set("Last-Modified", entry.modified.UTC().Format(http.TimeFormat))
+		w.Header().Set("Cache-Control", fmt.Sprintf("max-age=%d", b.CacheSecs))
+
+		if match := req.Header.Get("If-None-Match"); match != "" {
+			if match == entry.etag || match == "*" {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+		} else if since := req.Header.Get("If-Modified-Since"); since != "" {
+			if t, err := http.ParseTime(since); err == nil && !entry.modified.Truncate(time.Second).After(t) {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+		}
+
+		w.Write(entry.body)
+	}
+}
@@ -0,0 +1,567 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/evilsocket/islazy/log"
+	"github.com/wcharczuk/go-chart/v2"
+	"github.com/wcharczuk/go-chart/v2/drawing"
+	"gorm.io/gorm"
+
+	"github.com/evilsocket/takuan/models"
+)
+
+const (
+	defaultChartWidth  = 800
+	defaultChartHeight = 400
+	defaultChartTop    = 10
+	// timelines longer than this have a point per day rather than per hour
+	maxHourlyTimeline = 8 * 24 * time.Hour
+)
+
+var (
+	defaultChartKinds   = []string{"timeline", "countries", "map"}
+	defaultChartFormats = []string{"png"}
+
+	chartContentTypes = map[string]string{
+		"png": "image/png",
+		"svg": "image/svg+xml",
+	}
+
+	chartRenderers = map[string]chart.RendererProvider{
+		"png": chart.PNG,
+		"svg": chart.SVG,
+	}
+
+	chartColor   = drawing.Color{R: 217, G: 0, B: 116, A: 255}
+	mapOcean     = drawing.Color{R: 232, G: 240, B: 247, A: 255}
+	mapLand      = drawing.Color{R: 200, G: 200, B: 200, A: 255}
+	mapTextColor = drawing.Color{R: 51, G: 51, B: 51, A: 255}
+	mapLowColor  = drawing.Color{R: 255, G: 214, B: 102, A: 255}
+	mapHighColor = drawing.Color{R: 160, G: 0, B: 40, A: 255}
+	mapMaxLat    = 85.0
+	mapMinLat    = -60.0
+	mapFontSize  = 10.0
+	mapTitleSize = 12.0
+)
+
+type countryCentroid struct {
+	Lat  float64
+	Lon  float64
+	Area int
+}
+
+// Charts renders the events over time, the top countries and the world map of the reports and
+// of the digests, they're attached to the notifications and published with the digests.
+type Charts struct {
+	Enabled bool `yaml:"enabled"`
+	// timeline, countries and map
+	Kinds []string `yaml:"charts"`
+	// png and svg, for the files of the digests, notifications always attach png images
+	Formats []string `yaml:"formats"`
+	Width   int      `yaml:"width"`
+	Height  int      `yaml:"height"`
+	// number of countries in the bar chart
+	Top int `yaml:"top"`
+	// publish the charts of each report with its files, for the notifiers that link them (slack)
+	Publish bool `yaml:"publish"`
+}
+
+func (c *Charts) Init() error {
+	if len(c.Kinds) == 0 {
+		c.Kinds = defaultChartKinds
+	}
+	for _, kind := range c.Kinds {
+		if kind != "timeline" && kind != "countries" && kind != "map" {
+			return fmt.Errorf("unknown chart '%s', use timeline, countries or map", kind)
+		}
+	}
+	if len(c.Formats) == 0 {
+		c.Formats = defaultChartFormats
+	}
+	for _, format := range c.Formats {
+		if _, found := chartRenderers[format]; !found {
+			return fmt.Errorf("unknown chart format '%s', use png or svg", format)
+		}
+	}
+	if c.Width <= 0 {
+		c.Width = defaultChartWidth
+	}
+	if c.Height <= 0 {
+		c.Height = defaultChartHeight
+	}
+	if c.Top <= 0 {
+		c.Top = defaultChartTop
+	}
+	return nil
+}
+
+// Chart is a rendered chart image.
+type Chart struct {
+	Name        string
+	Title       string
+	Format      string
+	ContentType string
+	Data        []byte
+	// once published
+	File string
+	URL  string
+}
+
+// FileName is the name of the published file, or a default one if not published.
+func (c *Chart) FileName() string {
+	if c.File != "" {
+		return c.File
+	}
+	return c.Name + "." + c.Format
+}
+
+// TimeCount is the number of events in the bucket starting at Time.
+type TimeCount struct {
+	Time  time.Time
+	Count int
+}
+
+// ChartData are the events of a report or of a digest.
+type ChartData struct {
+	Title    string
+	Timeline []TimeCount
+	Bucket   time.Duration
+	// every country, sorted by number of events
+	Countries []StatCounter
+}
+
+// Render renders every configured chart in the given format.
+func (c *Charts) Render(data *ChartData, format string) ([]*Chart, error) {
+	provider := chartRenderers[format]
+	charts := make([]*Chart, 0, len(c.Kinds))
+	for _, kind := range c.Kinds {
+		buf := bytes.Buffer{}
+		title := ""
+		var err error
+		switch kind {
+		case "timeline":
+			title = "events per " + bucketName(data.Bucket)
+			err = c.timeline(data, title, provider, &buf)
+		case "countries":
+			title = "top countries"
+			err = c.countries(data, title, provider, &buf)
+		case "map":
+			title = "events by country"
+			err = c.worldMap(data, title, provider, &buf)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error rendering %s chart: %v", kind, err)
+		}
+
+		charts = append(charts, &Chart{
+			Name:        kind,
+			Title:       fmt.Sprintf("%s, %s", data.Title, title),
+			Format:      format,
+			ContentType: chartContentTypes[format],
+			Data:        buf.Bytes(),
+		})
+	}
+	return charts, nil
+}
+
+func bucketName(bucket time.Duration) string {
+	if bucket >= 24*time.Hour {
+		return "day"
+	}
+	return "hour"
+}
+
+func (c *Charts) timeline(data *ChartData, title string, provider chart.RendererProvider, buf *bytes.Buffer) error {
+	series := chart.TimeSeries{
+		Style: chart.Style{
+			StrokeColor: chartColor,
+			FillColor:   chartColor.WithAlpha(64),
+		},
+	}
+	max := 1.0
+	for _, point := range data.Timeline {
+		series.XValues = append(series.XValues, point.Time)
+		series.YValues = append(series.YValues, float64(point.Count))
+		max = math.Max(max, float64(point.Count))
+	}
+	// the axis needs a range
+	for len(series.XValues) < 2 {
+		last := time.Now().Truncate(data.Bucket)
+		if len(series.XValues) > 0 {
+			last = series.XValues[len(series.XValues)-1]
+		}
+		series.XValues = append(series.XValues, last.Add(data.Bucket))
+		series.YValues = append(series.YValues, 0)
+	}
+
+	formatter := chart.TimeHourValueFormatter
+	if data.Bucket >= 24*time.Hour {
+		formatter = chart.TimeDateValueFormatter
+	}
+
+	graph := chart.Chart{
+		Title:  fmt.Sprintf("%s, %s", data.Title, title),
+		Width:  c.Width,
+		Height: c.Height,
+		XAxis:  chart.XAxis{ValueFormatter: formatter},
+		YAxis: chart.YAxis{
+			Range:          &chart.ContinuousRange{Min: 0, Max: math.Ceil(max * 1.1)},
+			ValueFormatter: intValueFormatter,
+		},
+		Series: []chart.Series{series},
+	}
+	return graph.Render(provider, buf)
+}
+
+func intValueFormatter(v interface{}) string {
+	if f, ok := v.(float64); ok {
+		return fmt.Sprintf("%.0f", f)
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+func (c *Charts) countries(data *ChartData, title string, provider chart.RendererProvider, buf *bytes.Buffer) error {
+	bars := make([]chart.Value, 0, c.Top)
+	max := 1.0
+	for _, country := range top(c.Top, data.Countries) {
+		bars = append(bars, chart.Value{
+			Label: country.Name,
+			Value: float64(country.Count),
+			Style: chart.Style{FillColor: chartColor, StrokeColor: chartColor},
+		})
+		max = math.Max(max, float64(country.Count))
+	}
+	// a bar chart needs at least one bar
+	if len(bars) == 0 {
+		bars = append(bars, chart.Value{Label: "-", Value: 0})
+	}
+
+	graph := chart.BarChart{
+		Title:  fmt.Sprintf("%s, %s", data.Title, title),
+		Width:  c.Width,
+		Height: c.Height,
+		XAxis:  chart.Style{},
+		YAxis: chart.YAxis{
+			Range:          &chart.ContinuousRange{Min: 0, Max: math.Ceil(max * 1.1)},
+			ValueFormatter: intValueFormatter,
+		},
+		BarWidth: (c.Width - 100) / (2 * len(bars)),
+		Bars:     bars,
+	}
+	return graph.Render(provider, buf)
+}
+
+// worldMap draws a choropleth of the countries on an equirectangular projection, colored by
+// their number of events on a logarithmic scale. The countries too small for the outlines are
+// drawn as a square at their centroid if they have events.
+func (c *Charts) worldMap(data *ChartData, title string, provider chart.RendererProvider, buf *bytes.Buffer) error {
+	r, err := provider(c.Width, c.Height)
+	if err != nil {
+		return err
+	}
+	font, err := chart.GetDefaultFont()
+	if err != nil {
+		return err
+	}
+	r.SetDPI(chart.DefaultDPI)
+
+	r.SetFillColor(mapOcean)
+	rect(r, 0, 0, c.Width, c.Height)
+	r.Fill()
+
+	counts := make(map[string]int)
+	max := 0
+	for _, country := range data.Countries {
+		counts[country.Name] = country.Count
+		if country.Count > max {
+			max = country.Count
+		}
+	}
+	colorOf := func(code string) drawing.Color {
+		if count := counts[code]; count > 0 {
+			return scaleColor(count, max)
+		}
+		return mapLand
+	}
+
+	// without the poles, between the title and the footer
+	top, bottom := 30, c.Height-20
+	project := func(lon float64, lat float64) (int, int) {
+		lat = math.Max(mapMinLat, math.Min(mapMaxLat, lat))
+		return int((lon + 180) / 360 * float64(c.Width)), top + int((mapMaxLat-lat)/(mapMaxLat-mapMinLat)*float64(bottom-top))
+	}
+
+	// larger countries first, so that the ones they enclose are drawn on top of them
+	codes := make([]string, 0, len(countryShapes))
+	for code := range countryShapes {
+		codes = append(codes, code)
+	}
+	sort.Slice(codes, func(i, j int) bool {
+		return countryCentroids[codes[i]].Area > countryCentroids[codes[j]].Area
+	})
+
+	r.SetStrokeColor(mapOcean)
+	r.SetStrokeWidth(0.5)
+	for _, code := range codes {
+		r.SetFillColor(colorOf(code))
+		for _, ring := range countryShapes[code] {
+			if !visibleRing(ring) {
+				continue
+			}
+			for i := 0; i < len(ring); i += 2 {
+				x, y := project(float64(ring[i])/10, float64(ring[i+1])/10)
+				if i == 0 {
+					r.MoveTo(x, y)
+				} else {
+					r.LineTo(x, y)
+				}
+			}
+			r.Close()
+			r.FillStroke()
+		}
+	}
+
+	size := int(math.Max(2, 3*float64(c.Width)/800))
+	for code := range counts {
+		centroid, found := countryCentroids[code]
+		if _, outlined := countryShapes[code]; outlined || !found || centroid.Lat < mapMinLat || centroid.Lat > mapMaxLat {
+			continue
+		}
+		x, y := project(centroid.Lon, centroid.Lat)
+		y = int(math.Max(float64(top+size), math.Min(float64(bottom-size), float64(y))))
+		r.SetFillColor(colorOf(code))
+		rect(r, x-size, y-size, x+size, y+size)
+		r.FillStroke()
+	}
+
+	r.SetFont(font)
+	r.SetFontColor(mapTextColor)
+	r.SetFontSize(mapTitleSize)
+	r.Text(fmt.Sprintf("%s, %s", data.Title, title), 10, 20)
+	r.SetFontSize(mapFontSize)
+	r.Text(fmt.Sprintf("%d countries, up to %d events", len(counts), max), 10, c.Height-6)
+
+	return r.Save(buf)
+}
+
+// rings entirely south of the map, like antarctica, are not drawn
+func visibleRing(ring []int16) bool {
+	for i := 1; i < len(ring); i += 2 {
+		if float64(ring[i])/10 > mapMinLat {
+			return true
+		}
+	}
+	return false
+}
+
+func rect(r chart.Renderer, x1 int, y1 int, x2 int, y2 int) {
+	r.MoveTo(x1, y1)
+	r.LineTo(x2, y1)
+	r.LineTo(x2, y2)
+	r.LineTo(x1, y2)
+	r.Close()
+}
+
+func scaleColor(count int, max int) drawing.Color {
+	ratio := 1.0
+	if max > 1 {
+		ratio = math.Log(float64(count)) / math.Log(float64(max))
+	}
+	mix := func(a uint8, b uint8) uint8 {
+		return uint8(float64(a) + (float64(b)-float64(a))*ratio)
+	}
+	return drawing.Color{
+		R: mix(mapLowColor.R, mapHighColor.R),
+		G: mix(mapLowColor.G, mapHighColor.G),
+		B: mix(mapLowColor.B, mapHighColor.B),
+		A: 255,
+	}
+}
+
+// timeline counts the events selected by scope per hour from start to end, if they're not zero,
+// or from the first to the last event, and per day if that's longer than maxHourlyTimeline.
+func timeline(db *gorm.DB, scope func(*gorm.DB) *gorm.DB, start time.Time, end time.Time) ([]TimeCount, time.Duration, error) {
+	rows, err := db.Model(&models.Event{}).
+		Scopes(scope).
+		Select("DATE_FORMAT(created_at, '%Y-%m-%d %H:00:00') AS bucket, COUNT(*)").
+		Group("bucket").
+		Order("bucket").
+		Rows()
+	if err != nil {
+		return nil, time.Hour, err
+	}
+	defer rows.Close()
+
+	hourly := make(map[time.Time]int)
+	for rows.Next() {
+		var name string
+		var count int
+		if err = rows.Scan(&name, &count); err != nil {
+			return nil, time.Hour, err
+		}
+		t, err := time.ParseInLocation("2006-01-02 15:04:05", name, time.Local)
+		if err != nil {
+			return nil, time.Hour, err
+		}
+		hourly[t] = count
+		if start.IsZero() || t.Before(start) {
+			start = t
+		}
+		if end.IsZero() || !t.Before(end) {
+			end = t.Add(time.Hour)
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return nil, time.Hour, err
+	}
+
+	bucket := time.Hour
+	if end.Sub(start) > maxHourlyTimeline {
+		bucket = 24 * time.Hour
+	}
+	counts := make(map[time.Time]int)
+	for t, count := range hourly {
+		counts[truncateLocal(t, bucket)] += count
+	}
+
+	// with the empty buckets too
+	points := make([]TimeCount, 0)
+	for t := truncateLocal(start, bucket); t.Before(end); t = t.Add(bucket) {
+		points = append(points, TimeCount{Time: t, Count: counts[t]})
+	}
+	return points, bucket, nil
+}
+
+// truncates to the hour or to the local midnight
+func truncateLocal(t time.Time, bucket time.Duration) time.Time {
+	if bucket >= 24*time.Hour {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, t.Location())
+}
+
+// siblingURL returns the url of a file published in the same directory of the file at pubURL.
+func siblingURL(pubURL string, name string) string {
+	if idx := strings.LastIndex(pubURL, "/"); idx >= 0 {
+		return pubURL[:idx+1] + name
+	}
+	return ""
+}
+
+func (r *Aggregator) chartsEnabled() bool {
+	return r.conf.Charts != nil && r.conf.Charts.Enabled
+}
+
+func (r *Aggregator) chartData(title string, scope func(*gorm.DB) *gorm.DB, w window, countries []StatCounter) (*ChartData, error) {
+	data := &ChartData{
+		Title:     title,
+		Countries: countries,
+	}
+	var err error
+	if data.Timeline, data.Bucket, err = timeline(r.db, scope, w.Start, w.End); err != nil {
+		return nil, fmt.Errorf("error counting the events of %s over time: %v", title, err)
+	}
+	return data, nil
+}
+
+// reportCharts renders the png charts of a report and, if they're published, adds them to its
+// files. Errors are only logged, charts are not worth failing a report for.
+func (r *Aggregator) reportCharts(report *Report, scope func(*gorm.DB) *gorm.DB, pub *Publication) []*Chart {
+	if !r.chartsEnabled() {
+		return nil
+	}
+
+	w := window{}
+	if report.WindowStart != nil {
+		w.Start = *report.WindowStart
+	}
+	if report.WindowEnd != nil {
+		w.End = *report.WindowEnd
+	}
+
+	data, err := r.chartData(report.Name, scope, w, NewReportStats(report, "", 0).Countries)
+	if err != nil {
+		log.Error("%v", err)
+		return nil
+	}
+
+	charts, err := r.conf.Charts.Render(data, "png")
+	if err != nil {
+		log.Error("%v", err)
+		return nil
+	}
+
+	for _, c := range charts {
+		c.File = fmt.Sprintf("%s_%s.%s", report.Name, c.Name, c.Format)
+		if r.conf.Charts.Publish {
+			pub.Files = append(pub.Files, &ReportFile{
+				Name: c.File,
+				Data: c.Data,
+			})
+		}
+	}
+	return charts
+}
+
+// chartURLs sets the urls of the charts published with the file at pubURL.
+func chartURLs(charts []*Chart, pub *Publication, pubURL string) {
+	for _, c := range charts {
+		for _, file := range pub.Files {
+			if file.Name == c.File {
+				c.URL = siblingURL(pubURL, c.File)
+			}
+		}
+	}
+}
+
+// digestCharts renders the charts of a digest in every configured format, and the png
+// ones attached to its notifications. Errors are only logged.
+func (r *Aggregator) digestCharts(digest *Digest, stats *DigestStats, w window) (charts []*Chart, attached []*Chart) {
+	if !r.chartsEnabled() {
+		return nil, nil
+	}
+
+	filter := *r.conf.Reporter.Filter()
+	countries, err := digest.ranking(r.db, filter, w, "country_code", "MAX(country_name)", "country_code <> ''", 0)
+	if err != nil {
+		log.Error("error ranking the countries of %s digest: %v", digest.Name, err)
+		return nil, nil
+	}
+
+	filter.Since, filter.Until = w.Start, w.End
+	data, err := r.chartData(fmt.Sprintf("%s digest of %s", digest.Name, stats.Node), filter.Scope, w, countries)
+	if err != nil {
+		log.Error("%v", err)
+		return nil, nil
+	}
+
+	for _, format := range r.conf.Charts.Formats {
+		rendered, err := r.conf.Charts.Render(data, format)
+		if err != nil {
+			log.Error("%v", err)
+			return nil, nil
+		}
+		for _, c := range rendered {
+			c.File = fmt.Sprintf("%s_%s.%s", digest.fileName(w), c.Name, c.Format)
+			if c.Format == "png" {
+				attached = append(attached, c)
+			}
+		}
+		charts = append(charts, rendered...)
+	}
+
+	// not published, only attached
+	if attached == nil {
+		if attached, err = r.conf.Charts.Render(data, "png"); err != nil {
+			log.Error("%v", err)
+		}
+	}
+
+	return charts, attached
+}
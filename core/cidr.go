@@ -0,0 +1,90 @@
+package core
+
+import (
+	"math/big"
+	"net"
+	"sort"
+)
+
+type ipRange struct {
+	start *big.Int
+	end   *big.Int
+	bits  int
+}
+
+func ipToInt(ip net.IP) (*big.Int, int) {
+	if v4 := ip.To4(); v4 != nil {
+		return new(big.Int).SetBytes(v4), 32
+	}
+	return new(big.Int).SetBytes(ip.To16()), 128
+}
+
+func intToIP(n *big.Int, bits int) net.IP {
+	buf := n.Bytes()
+	ip := make(net.IP, bits/8)
+	copy(ip[len(ip)-len(buf):], buf)
+	return ip
+}
+
+// AggregateCIDRs merges a list of addresses into the smallest list of networks
+// covering exactly the same addresses, ipv4 networks first.
+func AggregateCIDRs(ips []net.IP) []*net.IPNet {
+	ranges := make([]ipRange, 0, len(ips))
+	for _, ip := range ips {
+		n, bits := ipToInt(ip)
+		ranges = append(ranges, ipRange{start: n, end: n, bits: bits})
+	}
+
+	sort.Slice(ranges, func(i, j int) bool {
+		if ranges[i].bits != ranges[j].bits {
+			return ranges[i].bits < ranges[j].bits
+		}
+		return ranges[i].start.Cmp(ranges[j].start) < 0
+	})
+
+	// merge overlapping and adjacent ranges
+	one := big.NewInt(1)
+	merged := make([]ipRange, 0)
+	for _, r := range ranges {
+		if last := len(merged) - 1; last >= 0 && merged[last].bits == r.bits {
+			next := new(big.Int).Add(merged[last].end, one)
+			if r.start.Cmp(next) <= 0 {
+				if r.end.Cmp(merged[last].end) > 0 {
+					merged[last].end = r.end
+				}
+				continue
+			}
+		}
+		merged = append(merged, r)
+	}
+
+	// split every range in the biggest aligned blocks
+	networks := make([]*net.IPNet, 0)
+	for _, r := range merged {
+		start := new(big.Int).Set(r.start)
+		for start.Cmp(r.end) <= 0 {
+			size := 0
+			for size < r.bits {
+				// the block must be aligned to its size and not go past the end
+				block := new(big.Int).Lsh(one, uint(size+1))
+				if new(big.Int).Mod(start, block).Sign() != 0 {
+					break
+				}
+				last := new(big.Int).Add(start, block)
+				if last.Sub(last, one).Cmp(r.end) > 0 {
+					break
+				}
+				size++
+			}
+
+			networks = append(networks, &net.IPNet{
+				IP:   intToIP(start, r.bits),
+				Mask: net.CIDRMask(r.bits-size, r.bits),
+			})
+
+			start.Add(start, new(big.Int).Lsh(one, uint(size)))
+		}
+	}
+
+	return networks
+}
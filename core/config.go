@@ -1,22 +1,33 @@
 package core
 
 import (
+	"fmt"
 	"io/ioutil"
 
-	"gopkg.in/yaml.v2"
 	"github.com/evilsocket/islazy/log"
+	"gopkg.in/yaml.v2"
 )
 
 type Config struct {
-	NodeName string    `yaml:"name"`
-	Debug    bool      `yaml:"debug"`
-	Database Database  `yaml:"database"`
-	Reporter *Reporter `yaml:"reports"`
-	Twitter  *Twitter  `yaml:"twitter"`
-	Sensors  []*Sensor `yaml:"sensors"`
+	NodeName  string            `yaml:"name"`
+	Debug     bool              `yaml:"debug"`
+	DryRun    bool              `yaml:"dry_run"` // same as dry_run in reports, notifiers and responder
+	Database  Database          `yaml:"database"`
+	Reporter  *Reporter         `yaml:"reports"`
+	Twitter   *Twitter          `yaml:"twitter"` // deprecated, same as a twitter notifier
+	Notifiers []*ReportNotifier `yaml:"notifiers"`
+	API       *API              `yaml:"api"`
+	Blocklist *Blocklist        `yaml:"blocklist"`
+	Responder *Responder        `yaml:"responder"`
+	RDNS      *ReverseDNS       `yaml:"rdns"`
+	Intel     *Intel            `yaml:"intel"`
+	Alerts    *Alerts           `yaml:"alerts"`
+	Charts    *Charts           `yaml:"charts"`
+	Sensors   []*Sensor         `yaml:"sensors"`
 }
 
-func Load(filename string) (*Config, error) {
+// Parse reads and compiles the configuration without initializing any of its components.
+func Parse(filename string) (*Config, error) {
 	data, err := ioutil.ReadFile(filename)
 	if err != nil {
 		return nil, err
@@ -38,17 +49,97 @@ func Load(filename string) (*Config, error) {
 		}
 	}
 
-	if conf.Reporter.Enabled {
-		if err = conf.Reporter.Init(); err != nil {
-			return nil, err
+	return &conf, nil
+}
+
+func Load(filename string) (*Config, error) {
+	conf, err := Parse(filename)
+	if err != nil {
+		return nil, err
+	}
+	if err = conf.Init(); err != nil {
+		return nil, err
+	}
+	return conf, nil
+}
+
+// Init initializes every enabled component.
+func (c *Config) Init() (err error) {
+	if c.DryRun {
+		log.Warning("dry run, nothing will be published, notified or blocked")
+		if c.Reporter != nil {
+			c.Reporter.DryRun = true
+		}
+		for _, notifier := range c.Notifiers {
+			notifier.DryRun = true
+		}
+		if c.Responder != nil {
+			c.Responder.DryRun = true
 		}
 	}
 
-	if conf.Twitter.Enabled {
-		if err = conf.Twitter.Init(); err != nil {
-			return nil, err
+	// the blocklist endpoint is served by the api
+	if c.Blocklist != nil && c.Blocklist.Enabled && (c.API == nil || !c.API.Enabled) {
+		return fmt.Errorf("the blocklist requires the api section to be enabled")
+	} else if c.API == nil || !c.API.Enabled {
+		log.Debug("api disabled, /metrics, /healthz and /readyz are not available")
+	}
+
+	if c.Charts != nil && c.Charts.Enabled {
+		if err = c.Charts.Init(); err != nil {
+			return err
 		}
 	}
 
-	return &conf, nil
+	if c.Reporter.Enabled {
+		if err = c.Reporter.Init(c.NodeName); err != nil {
+			return err
+		}
+	}
+
+	if c.Twitter != nil && c.Twitter.Enabled {
+		c.Notifiers = append([]*ReportNotifier{{
+			Name:    "twitter",
+			Enabled: true,
+			DryRun:  c.DryRun,
+			Twitter: c.Twitter,
+		}}, c.Notifiers...)
+	}
+
+	for i, notifier := range c.Notifiers {
+		if notifier.Name == "" {
+			notifier.Name = fmt.Sprintf("notifier-%d", i)
+		}
+		if notifier.Enabled {
+			if err = notifier.Init(); err != nil {
+				return err
+			}
+		}
+	}
+
+	if c.Alerts != nil && c.Alerts.Enabled {
+		if err = c.Alerts.Init(c.Sensors, c.Notifiers); err != nil {
+			return err
+		}
+	}
+
+	if c.Intel != nil && c.Intel.Enabled {
+		if err = c.Intel.Init(); err != nil {
+			return err
+		}
+	}
+
+	if c.RDNS != nil && c.RDNS.Enabled {
+		if err = c.RDNS.Init(); err != nil {
+			return err
+		}
+	}
+
+	if c.Responder != nil && c.Responder.Enabled {
+		if err = c.Responder.Init(); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
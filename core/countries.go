@@ -0,0 +1,256 @@
+package core
+
+// approximate centroid and area in km² of each country by ISO 3166-1 alpha-2 code, from the
+// pariz/countries dataset (MIT), used to order the outlines of the map and to place the countries
+// too small to have one
+var countryCentroids = map[string]countryCentroid{
+	"AD": {42.6, 1.6, 468},
+	"AE": {23.7, 54.5, 83600},
+	"AF": {33.8, 66.0, 652230},
+	"AG": {17.1, -61.8, 442},
+	"AI": {18.2, -63.0, 91},
+	"AL": {41.1, 20.0, 28748},
+	"AM": {40.3, 44.9, 29743},
+	"AO": {-12.3, 17.5, 1246700},
+	"AQ": {-82.9, -135.0, 14000000},
+	"AR": {-37.1, -64.9, 2780400},
+	"AS": {-14.3, -170.7, 199},
+	"AT": {47.6, 14.1, 83871},
+	"AU": {-25.6, 134.5, 7692024},
+	"AW": {12.5, -70.0, 180},
+	"AX": {60.2, 20.0, 1580},
+	"AZ": {40.3, 47.8, 86600},
+	"BA": {44.2, 17.8, 51209},
+	"BB": {13.2, -59.5, 430},
+	"BD": {23.7, 90.3, 147570},
+	"BE": {50.6, 4.6, 30528},
+	"BF": {12.3, -1.7, 272967},
+	"BG": {42.8, 25.3, 110879},
+	"BH": {26.1, 50.5, 765},
+	"BI": {-3.4, 29.9, 27834},
+	"BJ": {9.6, 2.3, 112622},
+	"BL": {17.9, -62.8, 21},
+	"BM": {32.3, -64.8, 54},
+	"BN": {4.6, 114.7, 5765},
+	"BO": {-16.7, -64.7, 1098581},
+	"BQ": {12.2, -68.2, 328},
+	"BR": {-10.8, -53.0, 8515767},
+	"BS": {25.0, -77.4, 13943},
+	"BT": {27.4, 90.4, 38394},
+	"BV": {-54.4, 3.4, 49},
+	"BW": {-22.2, 23.8, 582000},
+	"BY": {53.5, 28.1, 207600},
+	"BZ": {17.2, -88.7, 22966},
+	"CA": {62.8, -95.9, 9984670},
+	"CC": {-12.2, 96.9, 14},
+	"CD": {-2.9, 23.7, 2344858},
+	"CF": {6.6, 20.5, 622984},
+	"CG": {-2.9, 23.7, 342000},
+	"CH": {46.8, 8.2, 41284},
+	"CI": {7.6, -5.6, 322463},
+	"CK": {-21.2, -159.7, 236},
+	"CL": {-35.8, -71.7, 756102},
+	"CM": {5.7, 12.7, 475442},
+	"CN": {36.6, 104.0, 9706961},
+	"CO": {4.0, -73.3, 1141748},
+	"CR": {9.9, -84.2, 51100},
+	"CU": {22.1, -79.5, 109884},
+	"CV": {15.2, -23.7, 4033},
+	"CW": {12.2, -68.9, 444},
+	"CX": {-10.5, 105.6, 135},
+	"CY": {35.1, 33.5, 9251},
+	"CZ": {49.7, 15.3, 78865},
+	"DE": {51.2, 10.4, 357114},
+	"DJ": {11.7, 42.6, 23200},
+	"DK": {56.1, 9.6, 43094},
+	"DM": {15.4, -61.3, 751},
+	"DO": {19.0, -70.8, 48671},
+	"DZ": {28.2, 2.7, 2381741},
+	"EC": {-1.4, -78.9, 276841},
+	"EE": {58.7, 25.2, 45227},
+	"EG": {26.8, 29.9, 1002450},
+	"EH": {25.0, -13.0, 266000},
+	"ER": {15.4, 39.1, 117600},
+	"ES": {40.4, -3.6, 505992},
+	"ET": {8.6, 39.6, 1104300},
+	"FI": {64.3, 26.0, 338424},
+	"FJ": {-17.7, 178.1, 18272},
+	"FK": {-51.8, -59.7, 12173},
+	"FM": {6.9, 158.2, 702},
+	"FO": {62.0, -6.8, 1393},
+	"FR": {46.6, 2.3, 551695},
+	"GA": {-0.6, 11.7, 267668},
+	"GB": {54.6, -2.2, 242900},
+	"GD": {12.2, -61.6, 344},
+	"GE": {42.3, 43.4, 69700},
+	"GF": {4.1, -53.2, 83534},
+	"GG": {49.7, -2.2, 78},
+	"GH": {7.9, -1.2, 238533},
+	"GI": {36.1, -5.3, 6},
+	"GL": {74.3, -41.1, 2166086},
+	"GM": {13.4, -15.5, 10689},
+	"GN": {10.4, -11.0, 245857},
+	"GP": {16.3, -61.6, 1628},
+	"GQ": {1.5, 10.4, 28051},
+	"GR": {39.7, 21.9, 131990},
+	"GS": {-54.5, -36.4, 3903},
+	"GT": {15.7, -90.3, 108889},
+	"GU": {13.4, 144.7, 549},
+	"GW": {12.1, -14.7, 36125},
+	"GY": {4.9, -58.9, 214969},
+	"HK": {22.3, 114.2, 1104},
+	"HM": {-53.1, 73.6, 412},
+	"HN": {15.0, -86.3, 112492},
+	"HR": {45.4, 15.7, 56594},
+	"HT": {19.1, -72.2, 27750},
+	"HU": {47.2, 19.4, 93028},
+	"ID": {-1.2, 115.4, 1904569},
+	"IE": {53.2, -8.2, 70273},
+	"IL": {31.8, 34.8, 20770},
+	"IM": {54.2, -4.6, 572},
+	"IN": {23.4, 79.5, 3287590},
+	"IO": {-6.2, 71.3, 60},
+	"IQ": {33.0, 43.8, 438317},
+	"IR": {32.5, 54.3, 1648195},
+	"IS": {64.9, -19.0, 103000},
+	"IT": {42.8, 12.5, 301336},
+	"JE": {49.2, -2.1, 116},
+	"JM": {18.1, -77.3, 10991},
+	"JO": {31.3, 36.8, 89342},
+	"JP": {36.3, 139.1, 377930},
+	"KE": {0.6, 37.8, 580367},
+	"KG": {41.5, 74.6, 199951},
+	"KH": {12.6, 104.8, 181035},
+	"KI": {1.8, -157.7, 811},
+	"KM": {-11.9, 43.4, 1862},
+	"KN": {17.2, -62.6, 261},
+	"KP": {40.1, 127.1, 120538},
+	"KR": {40.1, 127.1, 100210},
+	"KW": {29.3, 47.6, 17818},
+	"KY": {19.3, -81.3, 264},
+	"KZ": {48.1, 67.2, 2724900},
+	"LA": {18.7, 104.2, 236800},
+	"LB": {33.9, 35.9, 10452},
+	"LC": {13.9, -61.0, 616},
+	"LI": {47.1, 9.6, 160},
+	"LK": {7.8, 80.7, 65610},
+	"LR": {6.4, -9.3, 111369},
+	"LS": {-29.6, 28.2, 30355},
+	"LT": {55.3, 23.9, 65300},
+	"LU": {49.8, 6.1, 2586},
+	"LV": {56.9, 24.8, 64559},
+	"LY": {27.2, 18.0, 1759540},
+	"MA": {29.1, -9.0, 446550},
+	"MC": {43.7, 7.4, 2},
+	"MD": {47.2, 28.5, 33846},
+	"ME": {42.8, 19.2, 13812},
+	"MF": {18.0, -63.1, 53},
+	"MG": {-19.3, 46.7, 587041},
+	"MH": {7.3, 168.8, 181},
+	"MK": {41.6, 21.7, 25713},
+	"ML": {17.4, -3.5, 1240192},
+	"MM": {20.3, 96.5, 676578},
+	"MN": {46.8, 103.1, 1564110},
+	"MO": {22.1, 113.6, 30},
+	"MP": {15.3, 145.8, 464},
+	"MQ": {14.6, -61.0, 1128},
+	"MR": {20.3, -10.4, 1030700},
+	"MS": {16.7, -62.2, 102},
+	"MT": {35.9, 14.4, 316},
+	"MU": {-20.2, 57.6, 2040},
+	"MV": {4.2, 73.5, 300},
+	"MW": {-13.5, 33.8, 118484},
+	"MX": {23.9, -102.6, 1964375},
+	"MY": {2.5, 103.0, 330803},
+	"MZ": {-17.6, 36.0, 801590},
+	"NA": {-22.2, 17.2, 825615},
+	"NC": {-21.3, 165.3, 18575},
+	"NE": {17.4, 9.4, 1267000},
+	"NF": {-29.0, 168.0, 36},
+	"NG": {9.6, 8.1, 923768},
+	"NI": {12.9, -84.9, 130373},
+	"NL": {52.3, 5.5, 41850},
+	"NO": {66.8, 14.9, 323802},
+	"NP": {28.3, 83.9, 147181},
+	"NR": {-0.5, 166.9, 21},
+	"NU": {-19.0, -169.8, 260},
+	"NZ": {-44.1, 170.4, 270467},
+	"OM": {20.6, 56.2, 309500},
+	"PA": {8.6, -80.5, 75417},
+	"PE": {-9.2, -74.4, 1285216},
+	"PF": {-17.6, -149.5, 4167},
+	"PG": {-6.9, 146.2, 462840},
+	"PH": {11.1, 122.5, 342353},
+	"PK": {29.9, 69.4, 881912},
+	"PL": {52.1, 19.4, 312679},
+	"PM": {46.9, -56.3, 242},
+	"PN": {-24.4, -128.3, 47},
+	"PR": {18.2, -66.6, 8870},
+	"PS": {31.9, 35.3, 6220},
+	"PT": {39.6, -8.0, 92090},
+	"PW": {7.4, 134.5, 459},
+	"PY": {-23.2, -58.4, 406752},
+	"QA": {25.4, 51.3, 11586},
+	"RE": {-21.1, 55.6, 2511},
+	"RO": {45.8, 25.0, 238391},
+	"RS": {44.2, 20.8, 88361},
+	"RU": {63.1, 103.8, 17098242},
+	"RW": {-2.0, 29.9, 26338},
+	"SA": {24.0, 44.4, 2149690},
+	"SB": {-9.5, 160.0, 28896},
+	"SC": {-4.7, 55.5, 452},
+	"SD": {16.1, 30.1, 1886068},
+	"SE": {62.7, 16.8, 450295},
+	"SG": {1.3, 103.8, 710},
+	"SH": {-16.0, -5.7, 394},
+	"SI": {46.1, 14.8, 20273},
+	"SJ": {71.0, -8.2, -1},
+	"SK": {48.7, 19.5, 49037},
+	"SL": {8.5, -11.8, 71740},
+	"SM": {43.9, 12.5, 61},
+	"SN": {14.4, -14.5, 196722},
+	"SO": {5.9, 47.5, 637657},
+	"SR": {4.2, -55.9, 163820},
+	"SS": {7.3, 30.3, 619745},
+	"ST": {0.3, 6.6, 964},
+	"SV": {13.7, -88.9, 21041},
+	"SX": {18.0, -63.1, 34},
+	"SY": {35.0, 38.5, 185180},
+	"SZ": {-26.6, 31.5, 17364},
+	"TC": {21.8, -71.7, 948},
+	"TD": {15.4, 18.7, 1284000},
+	"TF": {-49.6, 69.5, 7747},
+	"TG": {8.5, 1.0, 56785},
+	"TH": {14.5, 100.9, 513120},
+	"TJ": {38.9, 70.9, 143100},
+	"TK": {-9.0, -172.2, 12},
+	"TL": {-8.8, 126.1, 14874},
+	"TM": {39.2, 59.1, 488100},
+	"TN": {34.3, 9.2, 163610},
+	"TO": {-21.1, -175.3, 747},
+	"TR": {39.1, 34.9, 783562},
+	"TT": {10.7, -61.2, 5130},
+	"TV": {-7.5, 178.7, 26},
+	"TW": {23.7, 120.9, 36193},
+	"TZ": {-6.3, 34.9, 945087},
+	"UA": {48.9, 31.5, 603500},
+	"UG": {1.3, 32.4, 241550},
+	"UM": {19.3, 166.6, 34},
+	"US": {39.4, -99.0, 9372610},
+	"UY": {-33.0, -56.1, 181034},
+	"UZ": {41.8, 63.1, 447400},
+	"VA": {41.9, 12.5, 0},
+	"VC": {13.2, -61.2, 389},
+	"VE": {7.7, -66.1, 916445},
+	"VG": {18.4, -64.6, 151},
+	"VI": {17.8, -64.7, 347},
+	"VN": {16.9, 106.8, 331212},
+	"VU": {-16.4, 167.6, 12189},
+	"WF": {-13.3, -176.2, 142},
+	"WS": {-13.7, -172.3, 2842},
+	"YE": {15.9, 47.5, 527968},
+	"YT": {-12.8, 45.1, 374},
+	"ZA": {-29.0, 25.1, 1221037},
+	"ZM": {-13.5, 27.8, 752612},
+	"ZW": {-19.0, 29.9, 390757},
+}
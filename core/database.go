@@ -1,7 +1,27 @@
 package core
 
+import (
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
 type Database struct {
 	URL        string `yaml:"url"`
 	GeoIP      string `yaml:"geoip"`
+	ASN        string `yaml:"asn"`
+	City       string `yaml:"city"`
 	PeriodSecs int    `yaml:"period"`
+	// seconds between checks for updated geoip databases, 0 to disable
+	GeoIPReloadSecs int `yaml:"geoip_reload"`
+	// warn if a geoip database is older than this
+	GeoIPMaxAgeDays int `yaml:"geoip_max_age"`
+	// after a reload, update the location of events of the last hours
+	GeoIPRelocateHours int `yaml:"geoip_relocate"`
+}
+
+func (d Database) Open() (*gorm.DB, error) {
+	return gorm.Open(mysql.Open(d.URL), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
 }
@@ -0,0 +1,133 @@
+package core
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/evilsocket/islazy/log"
+)
+
+// ReportFile is a rendered report format, ready to be published.
+type ReportFile struct {
+	Name string `json:"name"`
+	Data []byte `json:"data"`
+}
+
+// Publication is a set of files published together, like the formats of a report.
+type Publication struct {
+	Name string
+	Node string
+	// what is being published, used as commit message
+	Message string
+	Files   []*ReportFile
+}
+
+// Destination publishes the files of a publication and returns the URL of the first one.
+type Destination interface {
+	Init() error
+	Publish(pub *Publication) (url string, err error)
+}
+
+// ReportDestination wraps exactly one of the supported destinations.
+type ReportDestination struct {
+	sync.Mutex
+
+	Name string `yaml:"name"`
+	// the url of the primary destination is the one linked in notifications
+	Primary bool `yaml:"primary"`
+
+	Local   *LocalDestination   `yaml:"local"`
+	Git     *GitDestination     `yaml:"git"`
+	S3      *S3Destination      `yaml:"s3"`
+	Webhook *WebhookDestination `yaml:"webhook"`
+	SFTP    *SFTPDestination    `yaml:"sftp"`
+
+	impl        Destination
+	publishedAt time.Time
+	publishErr  error
+}
+
+func (d *ReportDestination) Init() error {
+	impls := make([]Destination, 0)
+	if d.Local != nil {
+		impls = append(impls, d.Local)
+	}
+	if d.Git != nil {
+		impls = append(impls, d.Git)
+	}
+	if d.S3 != nil {
+		impls = append(impls, d.S3)
+	}
+	if d.Webhook != nil {
+		impls = append(impls, d.Webhook)
+	}
+	if d.SFTP != nil {
+		impls = append(impls, d.SFTP)
+	}
+
+	if len(impls) != 1 {
+		return fmt.Errorf("report destination '%s' must configure exactly one of local, git, s3, webhook or sftp", d.Name)
+	}
+
+	d.impl = impls[0]
+	if err := d.impl.Init(); err != nil {
+		return fmt.Errorf("error initializing report destination '%s': %v", d.Name, err)
+	}
+	return nil
+}
+
+func (d *ReportDestination) Publish(pub *Publication) (string, error) {
+	url, err := d.impl.Publish(pub)
+
+	d.Lock()
+	defer d.Unlock()
+
+	d.publishErr = err
+	if err != nil {
+		metricPublishFailures.WithLabelValues(d.Name).Inc()
+		return "", fmt.Errorf("error publishing %s to %s: %v", pub.Name, d.Name, err)
+	}
+	d.publishedAt = time.Now()
+
+	log.Info("%s published to %s (%s)", pub.Name, d.Name, url)
+
+	return url, nil
+}
+
+// Status returns the time of the last successful publish and the last error, if any.
+func (d *ReportDestination) Status() (publishedAt time.Time, publishErr error) {
+	d.Lock()
+	defer d.Unlock()
+	return d.publishedAt, d.publishErr
+}
+
+// joins the public base url of a destination with a file name
+func fileURL(base string, name string) string {
+	if base == "" {
+		return ""
+	} else if !strings.HasSuffix(base, "/") {
+		base += "/"
+	}
+	return base + name
+}
+
+// publicURL is true for the urls that can be shared in notifications, not for the file:// and
+// sftp:// paths of the destinations without a public http url.
+func publicURL(url string) bool {
+	return strings.HasPrefix(url, "http://") || strings.HasPrefix(url, "https://")
+}
+
+func contentType(name string) string {
+	switch path.Ext(name) {
+	case ".csv":
+		return "text/csv"
+	case ".json":
+		return "application/json"
+	case ".jsonl":
+		return "application/x-ndjson"
+	}
+	return "application/octet-stream"
+}
@@ -0,0 +1,481 @@
+package core
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/evilsocket/islazy/log"
+	"github.com/robfig/cron/v3"
+	"gorm.io/gorm"
+
+	"github.com/evilsocket/takuan/models"
+)
+
+const defaultDigestTop = 10
+
+var (
+	defaultDigestFormats = []string{"md", "html", "csv"}
+
+	// how the window of each period is called in the digests
+	digestPeriods = map[string]string{
+		"daily":   "day",
+		"weekly":  "week",
+		"monthly": "month",
+	}
+
+	// the end of each window
+	digestSchedules = map[string]string{
+		"daily":   "0 0 * * *",
+		"weekly":  "0 0 * * 1",
+		"monthly": "0 0 1 * *",
+	}
+
+	digestFormats = map[string]string{
+		"md":   ".md",
+		"html": ".html",
+		"csv":  ".csv",
+	}
+
+	digestMarkdown = template.Must(template.New("digest").Funcs(templateFuncs).Parse(`# {{.Name}} digest of {{.Node}}
+
+{{.Start.Format "2006-01-02 15:04"}} - {{.End.Format "2006-01-02 15:04 MST"}}
+
+| | this {{.Period}} | previous | trend |
+|---|---|---|---|
+| events | {{.Events}} | {{.Previous.Events}} | {{trend .Events .Previous.Events}} |
+| addresses | {{.Addresses}} | {{.Previous.Addresses}} | {{trend .Addresses .Previous.Addresses}} |
+| new addresses | {{.New}} | | |
+| recurring addresses | {{.Recurring}} | | |
+{{range .Charts}}
+![{{.Title}}]({{.File}})
+{{end}}{{range .Sections}}
+## Top {{.Title}}
+
+| {{.Title}} | | events |
+|---|---|---|
+{{range .Counters}}| {{.Name}} | {{.Description}} | {{.Count}} |
+{{end}}{{end}}`))
+
+	digestHTML = htmltemplate.Must(htmltemplate.New("digest").Funcs(htmltemplate.FuncMap(templateFuncs)).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Name}} digest of {{.Node}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; }
+</style>
+</head>
+<body>
+<h1>{{.Name}} digest of {{.Node}}</h1>
+<p>{{.Start.Format "2006-01-02 15:04"}} - {{.End.Format "2006-01-02 15:04 MST"}}</p>
+<table>
+<tr><th></th><th>this {{.Period}}</th><th>previous</th><th>trend</th></tr>
+<tr><td>events</td><td>{{.Events}}</td><td>{{.Previous.Events}}</td><td>{{trend .Events .Previous.Events}}</td></tr>
+<tr><td>addresses</td><td>{{.Addresses}}</td><td>{{.Previous.Addresses}}</td><td>{{trend .Addresses .Previous.Addresses}}</td></tr>
+<tr><td>new addresses</td><td>{{.New}}</td><td></td><td></td></tr>
+<tr><td>recurring addresses</td><td>{{.Recurring}}</td><td></td><td></td></tr>
+</table>
+{{range .Charts}}<p><img src="{{.File}}" alt="{{.Title}}"></p>
+{{end}}{{range .Sections}}<h2>Top {{.Title}}</h2>
+<table>
+<tr><th>{{.Title}}</th><th></th><th>events</th></tr>
+{{range .Counters}}<tr><td>{{.Name}}</td><td>{{.Description}}</td><td>{{.Count}}</td></tr>
+{{end}}</table>
+{{end}}</body>
+</html>
+`))
+)
+
+// Digest is a periodic summary of every event in the database, for a daily, weekly or monthly window.
+type Digest struct {
+	Name    string `yaml:"name"`
+	Enabled bool   `yaml:"enabled"`
+	// daily, weekly or monthly, windows start at midnight, on mondays and on the first of the month
+	Period string `yaml:"period"`
+	// md, html and csv
+	Formats []string `yaml:"formats"`
+	// number of rules, countries, ASNs, sensors and nodes in the rankings
+	Top int `yaml:"top"`
+	// send the digest to the notifiers
+	Notify bool `yaml:"notify"`
+	// cron expression, by default at the end of each window
+	Schedule string `yaml:"schedule"`
+
+	schedule cron.Schedule
+}
+
+func (d *Digest) Init() error {
+	if _, err := digestWindow(d.Period, time.Now()); err != nil {
+		return err
+	}
+	if d.Schedule == "" {
+		d.Schedule = digestSchedules[d.Period]
+	}
+	var err error
+	if d.schedule, err = parseSchedule(d.Schedule, 0); err != nil {
+		return err
+	}
+	if d.Name == "" {
+		d.Name = d.Period
+	}
+	if d.Top <= 0 {
+		d.Top = defaultDigestTop
+	}
+	if len(d.Formats) == 0 {
+		d.Formats = defaultDigestFormats
+	}
+	for _, format := range d.Formats {
+		if _, found := digestFormats[format]; !found {
+			return fmt.Errorf("unknown format '%s' for digest %s, use md, html or csv", format, d.Name)
+		}
+	}
+	return nil
+}
+
+// start of the window containing t
+func windowStart(period string, t time.Time) (time.Time, error) {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	switch period {
+	case "daily":
+		return day, nil
+	case "weekly":
+		// weeks start on monday
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7)), nil
+	case "monthly":
+		return day.AddDate(0, 0, 1-day.Day()), nil
+	}
+	return time.Time{}, fmt.Errorf("unknown digest period '%s', use daily, weekly or monthly", period)
+}
+
+type window struct {
+	Start time.Time
+	End   time.Time
+}
+
+// digestWindow returns the last complete window before t.
+func digestWindow(period string, t time.Time) (w window, err error) {
+	if w.End, err = windowStart(period, t); err != nil {
+		return
+	}
+	w.Start, err = windowStart(period, w.End.Add(-time.Second))
+	return
+}
+
+func (d *Digest) fileName(w window) string {
+	return fmt.Sprintf("digest_%s_%s", d.Name, w.Start.Format("2006-01-02"))
+}
+
+type DigestTotals struct {
+	Events    int `json:"events"`
+	Addresses int `json:"addresses"`
+}
+
+type DigestSection struct {
+	Title    string        `json:"title"`
+	Counters []StatCounter `json:"counters"`
+}
+
+// DigestStats are the data of the digest files and of the digest notification templates.
+type DigestStats struct {
+	Node   string    `json:"node"`
+	Name   string    `json:"name"`
+	Period string    `json:"period"`
+	Start  time.Time `json:"start"`
+	End    time.Time `json:"end"`
+	URL    string    `json:"url"`
+	DigestTotals
+	// addresses seen for the first time in this window and addresses seen before it
+	New       int          `json:"new"`
+	Recurring int          `json:"recurring"`
+	Previous  DigestTotals `json:"previous"`
+	// rankings by number of events
+	Rules     []StatCounter   `json:"rules"`
+	Countries []StatCounter   `json:"countries"`
+	ASNs      []StatCounter   `json:"asns"`
+	Sensors   []StatCounter   `json:"sensors"`
+	Nodes     []StatCounter   `json:"nodes"`
+	Sections  []DigestSection `json:"-"`
+	// embedded in the markdown and html files
+	Charts []*Chart `json:"-"`
+}
+
+func (d *Digest) totals(db *gorm.DB, filter EventFilter, w window) (totals DigestTotals, err error) {
+	filter.Since, filter.Until = w.Start, w.End
+	row := db.Model(&models.Event{}).Scopes(filter.Scope).Select("COUNT(*), COUNT(DISTINCT address)").Row()
+	err = row.Scan(&totals.Events, &totals.Addresses)
+	return
+}
+
+func (d *Digest) ranking(db *gorm.DB, filter EventFilter, w window, name string, description string, where string, limit int) ([]StatCounter, error) {
+	filter.Since, filter.Until = w.Start, w.End
+	counters := make([]StatCounter, 0)
+	query := db.Model(&models.Event{}).Scopes(filter.Scope)
+	if where != "" {
+		query = query.Where(where)
+	}
+	// every row if limit is 0
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	err := query.
+		Select(fmt.Sprintf("%s AS name, %s AS description, COUNT(*) AS count", name, description)).
+		Group(name).
+		Order("count DESC").
+		Scan(&counters).Error
+	return counters, err
+}
+
+// Generate computes the stats of the window from the events of every node.
+func (d *Digest) Generate(db *gorm.DB, node string, filter EventFilter, w window) (*DigestStats, error) {
+	stats := &DigestStats{
+		Node:   node,
+		Name:   d.Name,
+		Period: digestPeriods[d.Period],
+		Start:  w.Start,
+		End:    w.End,
+	}
+
+	var err error
+	if stats.DigestTotals, err = d.totals(db, filter, w); err != nil {
+		return nil, fmt.Errorf("error counting events: %v", err)
+	}
+
+	previous, _ := digestWindow(d.Period, w.Start)
+	if stats.Previous, err = d.totals(db, filter, previous); err != nil {
+		return nil, fmt.Errorf("error counting previous events: %v", err)
+	}
+
+	// the addresses of the window that were already seen before it by the same nodes and sensors,
+	// looked up by address rather than scanning the whole history
+	seenFilter := filter
+	seenFilter.Since, seenFilter.Until = time.Time{}, w.Start
+	seen := db.Table("events AS seen").
+		Select("1").
+		Scopes(seenFilter.Scope).
+		Where("seen.address = events.address")
+	windowFilter := filter
+	windowFilter.Since, windowFilter.Until = w.Start, w.End
+	var recurring int64
+	err = db.Model(&models.Event{}).
+		Scopes(windowFilter.Scope).
+		Where("EXISTS (?)", seen).
+		Distinct("address").
+		Count(&recurring).Error
+	if err != nil {
+		return nil, fmt.Errorf("error counting recurring addresses: %v", err)
+	}
+	stats.Recurring = int(recurring)
+	stats.New = stats.Addresses - stats.Recurring
+
+	rankings := []struct {
+		title       string
+		counters    *[]StatCounter
+		name        string
+		description string
+		where       string
+	}{
+		{"rules", &stats.Rules, "CONCAT(sensor, '/', rule)", "''", ""},
+		{"countries", &stats.Countries, "country_code", "MAX(country_name)", "country_code <> ''"},
+		{"ASNs", &stats.ASNs, "CONCAT('AS', asn)", "MAX(as_org)", "asn > 0"},
+		{"sensors", &stats.Sensors, "sensor", "''", ""},
+		{"nodes", &stats.Nodes, "node_name", "''", ""},
+	}
+	for _, ranking := range rankings {
+		if *ranking.counters, err = d.ranking(db, filter, w, ranking.name, ranking.description, ranking.where, d.Top); err != nil {
+			return nil, fmt.Errorf("error ranking %s: %v", ranking.title, err)
+		}
+		stats.Sections = append(stats.Sections, DigestSection{
+			Title:    ranking.title,
+			Counters: *ranking.counters,
+		})
+	}
+
+	return stats, nil
+}
+
+func (d *Digest) writeCSV(stats *DigestStats) ([]byte, error) {
+	buf := bytes.Buffer{}
+	w := csv.NewWriter(&buf)
+	w.Write([]string{"section", "name", "description", "events"})
+	for _, row := range [][]string{
+		{"totals", "events", "", fmt.Sprintf("%d", stats.Events)},
+		{"totals", "addresses", "", fmt.Sprintf("%d", stats.Addresses)},
+		{"totals", "new_addresses", "", fmt.Sprintf("%d", stats.New)},
+		{"totals", "recurring_addresses", "", fmt.Sprintf("%d", stats.Recurring)},
+		{"previous", "events", "", fmt.Sprintf("%d", stats.Previous.Events)},
+		{"previous", "addresses", "", fmt.Sprintf("%d", stats.Previous.Addresses)},
+	} {
+		w.Write(row)
+	}
+	for _, section := range stats.Sections {
+		for _, counter := range section.Counters {
+			w.Write([]string{strings.ToLower(section.Title), counter.Name, counter.Description, fmt.Sprintf("%d", counter.Count)})
+		}
+	}
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+// Render renders the digest in every configured format, with the chart files.
+func (d *Digest) Render(stats *DigestStats, w window, charts []*Chart) (*Publication, error) {
+	name := d.fileName(w)
+	pub := &Publication{
+		Name:    name,
+		Node:    stats.Node,
+		Message: fmt.Sprintf("%s digest, %d events from %d addresses", d.Name, stats.Events, stats.Addresses),
+		Files:   make([]*ReportFile, 0, len(d.Formats)+len(charts)),
+	}
+
+	// the charts of the first format are embedded in the markdown and html files
+	stats.Charts = nil
+	for _, c := range charts {
+		if c.Format == charts[0].Format {
+			stats.Charts = append(stats.Charts, c)
+		}
+	}
+
+	for _, format := range d.Formats {
+		buf := bytes.Buffer{}
+		var err error
+		switch format {
+		case "md":
+			err = digestMarkdown.Execute(&buf, stats)
+		case "html":
+			err = digestHTML.Execute(&buf, stats)
+		case "csv":
+			var data []byte
+			data, err = d.writeCSV(stats)
+			buf.Write(data)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error rendering %s digest: %v", format, err)
+		}
+
+		pub.Files = append(pub.Files, &ReportFile{
+			Name: name + digestFormats[format],
+			Data: buf.Bytes(),
+		})
+	}
+
+	for _, c := range charts {
+		pub.Files = append(pub.Files, &ReportFile{
+			Name: c.File,
+			Data: c.Data,
+		})
+	}
+
+	return pub, nil
+}
+
+// onDigests publishes the digests whose last complete window wasn't published yet.
+func (r *Aggregator) onDigests() {
+	for _, digest := range r.conf.Reporter.Digests {
+		if digest.Enabled {
+			if err := r.digest(digest, time.Now()); err != nil {
+				log.Error("error publishing %s digest: %v", digest.Name, err)
+			}
+		}
+	}
+}
+
+// digestEntry returns the entry of the digest of a window, or nil if it was already published.
+func (r *Aggregator) digestEntry(name string, w window) (*models.Digest, error) {
+	entry := &models.Digest{}
+	err := r.db.Where("node_name = ? AND name = ?", r.conf.NodeName, name).Take(entry).Error
+	if err == nil && entry.Status == models.ReportPublished {
+		return nil, nil
+	} else if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	} else if err != nil {
+		entry = &models.Digest{
+			NodeName: r.conf.NodeName,
+			Name:     name,
+			Start:    w.Start,
+			End:      w.End,
+			Status:   models.ReportPending,
+		}
+		if err = r.db.Create(entry).Error; err != nil {
+			return nil, err
+		}
+	}
+	return entry, nil
+}
+
+func (r *Aggregator) digest(digest *Digest, now time.Time) error {
+	w, _ := digestWindow(digest.Period, now)
+	name := digest.fileName(w)
+
+	// dry runs are never recorded, so the last digest is generated again at every start
+	dryRun := r.conf.Reporter.DryRun
+	entry := &models.Digest{}
+	if !dryRun {
+		var err error
+		if entry, err = r.digestEntry(name, w); err != nil || entry == nil {
+			return err
+		}
+	}
+
+	log.Info("generating %s digest %s ...", digest.Name, name)
+
+	stats, err := digest.Generate(r.db, r.conf.NodeName, *r.conf.Reporter.Filter(), w)
+	if err != nil {
+		return err
+	}
+
+	charts, attached := r.digestCharts(digest, stats, w)
+
+	pub, err := digest.Render(stats, w, charts)
+	if err != nil {
+		return err
+	}
+
+	if stats.URL, err = r.conf.Reporter.Publish(pub); err != nil {
+		if !dryRun {
+			r.db.Model(entry).Updates(map[string]interface{}{
+				"status": models.ReportFailed,
+				"error":  err.Error(),
+			})
+		}
+		return err
+	}
+
+	if !dryRun {
+		now = time.Now()
+		err = r.db.Model(entry).Updates(map[string]interface{}{
+			"status":       models.ReportPublished,
+			"url":          stats.URL,
+			"events":       stats.Events,
+			"published_at": now,
+			"error":        "",
+		}).Error
+		if err != nil {
+			return fmt.Errorf("error updating digest %s: %v", name, err)
+		}
+	}
+
+	log.Info("%s digest %s published (%d events from %d addresses)", digest.Name, name, stats.Events, stats.Addresses)
+
+	if digest.Notify && !publicURL(stats.URL) {
+		log.Info("%s digest %s has no public url, not notifying it", digest.Name, name)
+	} else if digest.Notify {
+		chartURLs(attached, pub, stats.URL)
+		r.conf.Notify(&Notification{
+			ID:     fmt.Sprintf("%s-%s", r.conf.NodeName, name),
+			Node:   r.conf.NodeName,
+			URL:    stats.URL,
+			Digest: stats,
+			Charts: attached,
+			DryRun: dryRun,
+		})
+	}
+
+	return nil
+}
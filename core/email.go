@@ -0,0 +1,154 @@
+package core
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+// Email sends notifications over SMTP, using STARTTLS when the server supports it.
+type Email struct {
+	// host:port of the SMTP server
+	Address  string   `yaml:"address"`
+	Username string   `yaml:"username"`
+	Password string   `yaml:"password"`
+	From     string   `yaml:"from"`
+	To       []string `yaml:"to"`
+	// implicit TLS, usually on port 465
+	TLS         bool `yaml:"tls"`
+	TimeoutSecs int  `yaml:"timeout"`
+
+	host string
+}
+
+func (e *Email) Init() (err error) {
+	if e.Address == "" || e.From == "" || len(e.To) == 0 {
+		return fmt.Errorf("address, from and to are required")
+	}
+	if e.host, _, err = net.SplitHostPort(e.Address); err != nil {
+		return fmt.Errorf("invalid address '%s': %v", e.Address, err)
+	}
+	if e.TimeoutSecs <= 0 {
+		e.TimeoutSecs = defaultNotifierTimeoutSecs
+	}
+	return nil
+}
+
+func (e *Email) message(n *Notification) []byte {
+	buf := bytes.Buffer{}
+	fmt.Fprintf(&buf, "From: %s\r\n", e.From)
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(e.To, ", "))
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", n.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&buf, "Message-ID: <%s@%s>\r\n", unsafeFileChars.ReplaceAllString(n.ID, "-"), e.host)
+	buf.WriteString("MIME-Version: 1.0\r\n")
+
+	text := strings.Replace(n.Text, "\n", "\r\n", -1) + "\r\n"
+	if len(n.Charts) == 0 {
+		buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+		buf.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+		buf.WriteString(text)
+		return buf.Bytes()
+	}
+
+	// the text followed by the charts as attachments
+	w := multipart.NewWriter(&buf)
+	fmt.Fprintf(&buf, "Content-Type: multipart/mixed; boundary=%s\r\n\r\n", w.Boundary())
+
+	part, _ := w.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {"text/plain; charset=utf-8"},
+		"Content-Transfer-Encoding": {"8bit"},
+	})
+	part.Write([]byte(text))
+
+	for _, chart := range n.Charts {
+		part, _ = w.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {chart.ContentType},
+			"Content-Transfer-Encoding": {"base64"},
+			"Content-Disposition":       {fmt.Sprintf(`attachment; filename="%s"`, chart.FileName())},
+		})
+		part.Write(base64Lines(chart.Data))
+	}
+	w.Close()
+
+	return buf.Bytes()
+}
+
+// base64 wrapped at 76 characters, as required by MIME
+func base64Lines(data []byte) []byte {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	buf := bytes.Buffer{}
+	for len(encoded) > 76 {
+		buf.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	buf.WriteString(encoded + "\r\n")
+	return buf.Bytes()
+}
+
+func (e *Email) Notify(n *Notification) error {
+	dialer := &net.Dialer{Timeout: time.Duration(e.TimeoutSecs) * time.Second}
+	tlsConfig := &tls.Config{ServerName: e.host}
+
+	var conn net.Conn
+	var err error
+	if e.TLS {
+		conn, err = tls.DialWithDialer(dialer, "tcp", e.Address, tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", e.Address)
+	}
+	if err != nil {
+		return err
+	}
+	conn.SetDeadline(time.Now().Add(time.Duration(e.TimeoutSecs) * time.Second))
+
+	client, err := smtp.NewClient(conn, e.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok && !e.TLS {
+		if err = client.StartTLS(tlsConfig); err != nil {
+			return fmt.Errorf("STARTTLS: %v", err)
+		}
+	}
+
+	if e.Username != "" {
+		// net/smtp refuses to send the password without TLS, unless the server is localhost
+		if err = client.Auth(smtp.PlainAuth("", e.Username, e.Password, e.host)); err != nil {
+			return fmt.Errorf("AUTH: %v", err)
+		}
+	}
+
+	if err = client.Mail(e.From); err != nil {
+		return fmt.Errorf("MAIL FROM: %v", err)
+	}
+	for _, to := range e.To {
+		if err = client.Rcpt(to); err != nil {
+			return fmt.Errorf("RCPT TO %s: %v", to, err)
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("DATA: %v", err)
+	}
+	if _, err = w.Write(e.message(n)); err != nil {
+		w.Close()
+		return err
+	} else if err = w.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
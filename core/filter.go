@@ -0,0 +1,187 @@
+package core
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type EventFilter struct {
+	Addresses []string
+	Networks  []*net.IPNet
+	Countries []string
+	Sensors   []string
+	Rules     []string
+	Nodes     []string
+	ASNs      []uint
+	// events tagged with any of Tags and none of ExcludeTags
+	Tags        []string
+	ExcludeTags []string
+	Since       time.Time
+	Until       time.Time
+}
+
+// accepts both repeated parameters and comma separated lists
+func queryList(q url.Values, name string) []string {
+	list := make([]string, 0)
+	for _, value := range q[name] {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+	}
+	return list
+}
+
+// same as time.ParseDuration but also accepts days, like 7d
+func parseDuration(value string) (time.Duration, error) {
+	if strings.HasSuffix(value, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(value, "d"))
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(value)
+}
+
+func ParseTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	} else if t, err = time.Parse("2006-01-02", value); err == nil {
+		return t, nil
+	} else if d, err := parseDuration(value); err == nil {
+		// relative to now, like since=24h
+		return time.Now().Add(-d), nil
+	}
+	return time.Time{}, fmt.Errorf("can't parse time '%s', use RFC3339, YYYY-MM-DD or a duration", value)
+}
+
+func ParseEventFilter(q url.Values) (*EventFilter, error) {
+	f := &EventFilter{
+		Addresses:   queryList(q, "address"),
+		Countries:   queryList(q, "country"),
+		Sensors:     queryList(q, "sensor"),
+		Rules:       queryList(q, "rule"),
+		Nodes:       queryList(q, "node"),
+		Tags:        queryList(q, "tag"),
+		ExcludeTags: queryList(q, "exclude_tag"),
+	}
+
+	for _, cidr := range queryList(q, "cidr") {
+		if !strings.Contains(cidr, "/") {
+			if ip := net.ParseIP(cidr); ip != nil && ip.To4() != nil {
+				cidr += "/32"
+			} else {
+				cidr += "/128"
+			}
+		}
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cidr '%s': %v", cidr, err)
+		}
+		f.Networks = append(f.Networks, network)
+	}
+
+	for _, asn := range queryList(q, "asn") {
+		num, err := strconv.ParseUint(strings.TrimPrefix(strings.ToUpper(asn), "AS"), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid asn '%s'", asn)
+		}
+		f.ASNs = append(f.ASNs, uint(num))
+	}
+
+	for i, c := range f.Countries {
+		f.Countries[i] = strings.ToUpper(c)
+	}
+
+	var err error
+	if since := q.Get("since"); since != "" {
+		if f.Since, err = ParseTime(since); err != nil {
+			return nil, err
+		}
+	}
+	if until := q.Get("until"); until != "" {
+		if f.Until, err = ParseTime(until); err != nil {
+			return nil, err
+		}
+	}
+
+	return f, nil
+}
+
+// returns the first and last address of a network
+func networkRange(network *net.IPNet) (first net.IP, last net.IP) {
+	first = network.IP.Mask(network.Mask)
+	last = make(net.IP, len(first))
+	for i := range first {
+		last[i] = first[i] | ^network.Mask[i]
+	}
+	return
+}
+
+func (f *EventFilter) Scope(db *gorm.DB) *gorm.DB {
+	if len(f.Addresses) > 0 {
+		db = db.Where("address IN ?", f.Addresses)
+	}
+
+	if len(f.Networks) > 0 {
+		// INET6_ATON returns 4 bytes for ipv4 and 16 for ipv6, make sure
+		// we never compare addresses of different families
+		clauses := make([]string, 0)
+		args := make([]interface{}, 0)
+		for _, network := range f.Networks {
+			first, last := networkRange(network)
+			size := 16
+			if first.To4() != nil {
+				first, last = first.To4(), last.To4()
+				size = 4
+			}
+			clauses = append(clauses, "(LENGTH(INET6_ATON(address)) = ? AND INET6_ATON(address) BETWEEN INET6_ATON(?) AND INET6_ATON(?))")
+			args = append(args, size, first.String(), last.String())
+		}
+		db = db.Where(strings.Join(clauses, " OR "), args...)
+	}
+
+	if len(f.Countries) > 0 {
+		db = db.Where("country_code IN ?", f.Countries)
+	}
+	if len(f.Sensors) > 0 {
+		db = db.Where("sensor IN ?", f.Sensors)
+	}
+	if len(f.Rules) > 0 {
+		db = db.Where("rule IN ?", f.Rules)
+	}
+	if len(f.Nodes) > 0 {
+		db = db.Where("node_name IN ?", f.Nodes)
+	}
+	if len(f.ASNs) > 0 {
+		db = db.Where("asn IN ?", f.ASNs)
+	}
+	if len(f.Tags) > 0 {
+		clauses := make([]string, 0)
+		args := make([]interface{}, 0)
+		for _, tag := range f.Tags {
+			clauses = append(clauses, "FIND_IN_SET(?, tags) > 0")
+			args = append(args, tag)
+		}
+		db = db.Where(strings.Join(clauses, " OR "), args...)
+	}
+	for _, tag := range f.ExcludeTags {
+		db = db.Where("(tags IS NULL OR FIND_IN_SET(?, tags) = 0)", tag)
+	}
+	if !f.Since.IsZero() {
+		db = db.Where("created_at >= ?", f.Since)
+	}
+	if !f.Until.IsZero() {
+		db = db.Where("created_at < ?", f.Until)
+	}
+
+	return db
+}
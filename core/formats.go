@@ -0,0 +1,317 @@
+package core
+
+import (
+	"crypto/sha1"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+// ReportFormat renders a report to a file named after the report plus Suffix().
+type ReportFormat interface {
+	Name() string
+	Suffix() string
+	Write(w io.Writer, report *Report) error
+}
+
+var ReportFormats = map[string]ReportFormat{
+	"csv":   csvFormat{},
+	"asn":   asnFormat{},
+	"json":  jsonFormat{},
+	"jsonl": jsonlFormat{},
+	"stix":  stixFormat{},
+	"misp":  mispFormat{},
+}
+
+var defaultReportFormats = []string{"csv", "asn"}
+
+// namespace for the deterministic uuids of stix and misp objects
+var takuanNamespace = []byte{0x5c, 0x1e, 0x8a, 0x3f, 0x62, 0x0d, 0x4b, 0x7e, 0x9a, 0x41, 0x0f, 0xd2, 0x36, 0x7b, 0xc8, 0x19}
+
+// rfc 4122 version 5 uuid, the same name always generates the same uuid
+func uuid5(name string) string {
+	h := sha1.New()
+	h.Write(takuanNamespace)
+	h.Write([]byte(name))
+	sum := h.Sum(nil)
+	sum[6] = (sum[6] & 0x0f) | 0x50
+	sum[8] = (sum[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", sum[0:4], sum[4:6], sum[6:8], sum[8:10], sum[10:16])
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+type csvFormat struct{}
+
+func (f csvFormat) Name() string   { return "csv" }
+func (f csvFormat) Suffix() string { return ".csv" }
+
+// new columns are only ever appended, so existing consumers keep working
+func (f csvFormat) Write(w io.Writer, report *Report) error {
+	writer := csv.NewWriter(w)
+	writer.Write([]string{
+		"address",
+		"country_code",
+		"country_name",
+		"total_events",
+		"counters",
+		"asn",
+		"as_org",
+		"tags",
+		"first_seen",
+		"last_seen",
+		"city",
+	})
+
+	for _, e := range report.Entries {
+		writer.Write([]string{
+			e.Address,
+			e.CountryCode,
+			e.CountryName,
+			fmt.Sprintf("%d", e.Events),
+			strings.Join(e.Counters(), "|"),
+			asnString(e.ASN),
+			e.ASOrg,
+			strings.Join(e.Tags, "|"),
+			formatTime(e.FirstSeen),
+			formatTime(e.LastSeen),
+			e.City,
+		})
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// per autonomous system totals
+type asnFormat struct{}
+
+func (f asnFormat) Name() string   { return "asn" }
+func (f asnFormat) Suffix() string { return "_asn.csv" }
+
+func (f asnFormat) Write(w io.Writer, report *Report) error {
+	writer := csv.NewWriter(w)
+	writer.Write([]string{
+		"asn",
+		"as_org",
+		"addresses",
+		"total_events",
+	})
+
+	for _, a := range report.ASNs {
+		writer.Write([]string{
+			asnString(a.ASN),
+			a.Org,
+			fmt.Sprintf("%d", a.Addresses),
+			fmt.Sprintf("%d", a.Events),
+		})
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+type jsonFormat struct{}
+
+func (f jsonFormat) Name() string   { return "json" }
+func (f jsonFormat) Suffix() string { return ".json" }
+
+func (f jsonFormat) Write(w io.Writer, report *Report) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
+
+// one entry per line
+type jsonlFormat struct{}
+
+func (f jsonlFormat) Name() string   { return "jsonl" }
+func (f jsonlFormat) Suffix() string { return ".jsonl" }
+
+func (f jsonlFormat) Write(w io.Writer, report *Report) error {
+	encoder := json.NewEncoder(w)
+	for _, e := range report.Entries {
+		if err := encoder.Encode(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// stix 2.1 bundle with an indicator and a sighting for each address
+type stixFormat struct{}
+
+func (f stixFormat) Name() string   { return "stix" }
+func (f stixFormat) Suffix() string { return ".stix.json" }
+
+type stixObject map[string]interface{}
+
+func stixTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02T15:04:05.000Z")
+}
+
+// the identity of a node is the same in all of its reports, and so must be its created time
+const stixIdentityCreated = "2020-01-01T00:00:00.000Z"
+
+func (f stixFormat) Write(w io.Writer, report *Report) error {
+	// stix requires the same created time for every version of an object, indicators and sightings
+	// belong to their report so that it is always the report time, and publishing it again gives
+	// the same ids
+	created := stixTime(report.CreatedAt)
+	identity := "identity--" + uuid5("identity/"+report.Node)
+
+	objects := []stixObject{{
+		"type":           "identity",
+		"spec_version":   "2.1",
+		"id":             identity,
+		"created":        stixIdentityCreated,
+		"modified":       stixIdentityCreated,
+		"name":           fmt.Sprintf("takuan node %s", report.Node),
+		"identity_class": "system",
+	}}
+
+	for _, e := range report.Entries {
+		addrType := "ipv4-addr"
+		if ip := net.ParseIP(e.Address); ip != nil && ip.To4() == nil {
+			addrType = "ipv6-addr"
+		}
+
+		indicator := "indicator--" + uuid5("indicator/"+report.Node+"/"+report.Name+"/"+e.Address)
+
+		objects = append(objects, stixObject{
+			"type":                  "indicator",
+			"spec_version":          "2.1",
+			"id":                    indicator,
+			"created":               created,
+			"modified":              created,
+			"created_by_ref":        identity,
+			"name":                  e.Address,
+			"description":           fmt.Sprintf("%d events: %s", e.Events, strings.Join(e.Counters(), ", ")),
+			"indicator_types":       []string{"malicious-activity"},
+			"pattern":               fmt.Sprintf("[%s:value = '%s']", addrType, e.Address),
+			"pattern_type":          "stix",
+			"valid_from":            stixTime(e.FirstSeen),
+			"labels":                append(e.RuleNames(), e.Tags...),
+			"x_takuan_country_code": e.CountryCode,
+			"x_takuan_country_name": e.CountryName,
+			"x_takuan_city":         e.City,
+			"x_takuan_asn":          e.ASN,
+			"x_takuan_as_org":       e.ASOrg,
+			"x_takuan_rules":        e.Rules,
+		})
+
+		objects = append(objects, stixObject{
+			"type":               "sighting",
+			"spec_version":       "2.1",
+			"id":                 "sighting--" + uuid5("sighting/"+report.Node+"/"+report.Name+"/"+e.Address),
+			"created":            created,
+			"modified":           created,
+			"created_by_ref":     identity,
+			"sighting_of_ref":    indicator,
+			"first_seen":         stixTime(e.FirstSeen),
+			"last_seen":          stixTime(e.LastSeen),
+			"count":              e.Events,
+			"where_sighted_refs": []string{identity},
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(stixObject{
+		"type":    "bundle",
+		"id":      "bundle--" + uuid5("bundle/"+report.Node+"/"+report.Name),
+		"objects": objects,
+	})
+}
+
+// misp event with an ip-src attribute for each address
+type mispFormat struct{}
+
+func (f mispFormat) Name() string   { return "misp" }
+func (f mispFormat) Suffix() string { return ".misp.json" }
+
+type mispTag struct {
+	Name string `json:"name"`
+}
+
+type mispAttribute struct {
+	UUID      string    `json:"uuid"`
+	Type      string    `json:"type"`
+	Category  string    `json:"category"`
+	Value     string    `json:"value"`
+	ToIDS     bool      `json:"to_ids"`
+	Timestamp string    `json:"timestamp"`
+	FirstSeen string    `json:"first_seen"`
+	LastSeen  string    `json:"last_seen"`
+	Comment   string    `json:"comment"`
+	Tags      []mispTag `json:"Tag"`
+}
+
+type mispEvent struct {
+	UUID          string          `json:"uuid"`
+	Info          string          `json:"info"`
+	Date          string          `json:"date"`
+	Timestamp     string          `json:"timestamp"`
+	Published     bool            `json:"published"`
+	Analysis      string          `json:"analysis"`
+	ThreatLevelID string          `json:"threat_level_id"`
+	Distribution  string          `json:"distribution"`
+	Tags          []mispTag       `json:"Tag"`
+	Attributes    []mispAttribute `json:"Attribute"`
+}
+
+func (f mispFormat) Write(w io.Writer, report *Report) error {
+	timestamp := fmt.Sprintf("%d", report.CreatedAt.Unix())
+	event := mispEvent{
+		UUID:          uuid5("misp/" + report.Node + "/" + report.Name),
+		Info:          fmt.Sprintf("takuan %s from node %s: %d addresses, %d events", report.Name, report.Node, len(report.Entries), report.Events),
+		Date:          report.CreatedAt.UTC().Format("2006-01-02"),
+		Timestamp:     timestamp,
+		Analysis:      "2",
+		ThreatLevelID: "3",
+		Distribution:  "3",
+		Tags:          []mispTag{{Name: "tlp:white"}, {Name: "takuan"}},
+		Attributes:    make([]mispAttribute, 0, len(report.Entries)),
+	}
+
+	for _, e := range report.Entries {
+		tags := []mispTag{{Name: fmt.Sprintf("takuan:country=\"%s\"", e.CountryCode)}}
+		for _, rule := range e.RuleNames() {
+			tags = append(tags, mispTag{Name: fmt.Sprintf("takuan:rule=\"%s\"", rule)})
+		}
+		for _, tag := range e.Tags {
+			tags = append(tags, mispTag{Name: fmt.Sprintf("takuan:list=\"%s\"", tag)})
+		}
+
+		location := e.CountryName
+		if e.City != "" {
+			location = e.City + ", " + location
+		}
+		if e.ASN > 0 {
+			location += fmt.Sprintf(" - %s %s", asnString(e.ASN), e.ASOrg)
+		}
+
+		event.Attributes = append(event.Attributes, mispAttribute{
+			UUID:      uuid5("misp/" + report.Node + "/" + report.Name + "/" + e.Address),
+			Type:      "ip-src",
+			Category:  "Network activity",
+			Value:     e.Address,
+			ToIDS:     true,
+			Timestamp: timestamp,
+			FirstSeen: formatTime(e.FirstSeen),
+			LastSeen:  formatTime(e.LastSeen),
+			Comment:   fmt.Sprintf("%s; %d events: %s", location, e.Events, strings.Join(e.Counters(), ", ")),
+			Tags:      tags,
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(map[string]interface{}{"Event": event})
+}
@@ -0,0 +1,283 @@
+package core
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/evilsocket/islazy/log"
+	"github.com/oschwald/geoip2-golang"
+	"github.com/oschwald/maxminddb-golang"
+
+	"github.com/evilsocket/takuan/models"
+)
+
+type Location struct {
+	CountryCode string
+	CountryName string
+	City        string
+	ASN         uint
+	ASOrg       string
+}
+
+func (l *Location) Apply(event *models.Event) {
+	event.CountryCode = l.CountryCode
+	event.CountryName = l.CountryName
+	event.City = l.City
+	event.ASN = l.ASN
+	event.ASOrg = l.ASOrg
+}
+
+func (l *Location) Matches(event *models.Event) bool {
+	return event.CountryCode == l.CountryCode &&
+		event.CountryName == l.CountryName &&
+		event.City == l.City &&
+		event.ASN == l.ASN &&
+		event.ASOrg == l.ASOrg
+}
+
+// both maxmind GeoLite2-ASN and ipinfo asn databases are supported
+type asnRecord struct {
+	Number       uint   `maxminddb:"autonomous_system_number"`
+	Organization string `maxminddb:"autonomous_system_organization"`
+	ASN          string `maxminddb:"asn"`
+	Name         string `maxminddb:"name"`
+}
+
+type geoReaders struct {
+	country *geoip2.Reader
+	city    *geoip2.Reader
+	asn     *maxminddb.Reader
+}
+
+func openGeoReaders(conf Database) (r *geoReaders, err error) {
+	r = &geoReaders{}
+
+	if r.country, err = geoip2.Open(conf.GeoIP); err != nil {
+		return nil, err
+	}
+
+	if conf.City != "" {
+		log.Debug("loading city database %s", conf.City)
+		if r.city, err = geoip2.Open(conf.City); err != nil {
+			r.close()
+			return nil, fmt.Errorf("error loading city database %s: %v", conf.City, err)
+		}
+	}
+
+	if conf.ASN != "" {
+		log.Debug("loading asn database %s", conf.ASN)
+		if r.asn, err = maxminddb.Open(conf.ASN); err != nil {
+			r.close()
+			return nil, fmt.Errorf("error loading asn database %s: %v", conf.ASN, err)
+		}
+	}
+
+	return r, nil
+}
+
+func (r *geoReaders) buildDates() map[string]time.Time {
+	dates := map[string]time.Time{
+		"country": time.Unix(int64(r.country.Metadata().BuildEpoch), 0),
+	}
+	if r.city != nil {
+		dates["city"] = time.Unix(int64(r.city.Metadata().BuildEpoch), 0)
+	}
+	if r.asn != nil {
+		dates["asn"] = time.Unix(int64(r.asn.Metadata.BuildEpoch), 0)
+	}
+	return dates
+}
+
+func (r *geoReaders) close() {
+	if r.country != nil {
+		r.country.Close()
+	}
+	if r.city != nil {
+		r.city.Close()
+	}
+	if r.asn != nil {
+		r.asn.Close()
+	}
+}
+
+type Geo struct {
+	sync.RWMutex
+
+	conf    Database
+	readers *geoReaders
+	stamps  map[string]time.Time
+}
+
+func OpenGeo(conf Database) (*Geo, error) {
+	readers, err := openGeoReaders(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &Geo{
+		conf:    conf,
+		readers: readers,
+		stamps:  make(map[string]time.Time),
+	}
+
+	g.changed()
+	g.checkAge()
+
+	return g, nil
+}
+
+func (g *Geo) files() []string {
+	files := []string{g.conf.GeoIP}
+	if g.conf.City != "" {
+		files = append(files, g.conf.City)
+	}
+	if g.conf.ASN != "" {
+		files = append(files, g.conf.ASN)
+	}
+	return files
+}
+
+// returns true if any of the database files changed since the last call
+func (g *Geo) changed() bool {
+	changed := false
+	for _, fileName := range g.files() {
+		if stat, err := os.Stat(fileName); err != nil {
+			// geoipupdate might be replacing it right now
+			log.Debug("can't stat %s: %v", fileName, err)
+		} else if !stat.ModTime().Equal(g.stamps[fileName]) {
+			g.stamps[fileName] = stat.ModTime()
+			changed = true
+		}
+	}
+	return changed
+}
+
+func (g *Geo) checkAge() {
+	if g.conf.GeoIPMaxAgeDays <= 0 {
+		return
+	}
+
+	g.RLock()
+	defer g.RUnlock()
+
+	maxAge := time.Duration(g.conf.GeoIPMaxAgeDays) * 24 * time.Hour
+	for name, built := range g.readers.buildDates() {
+		if age := time.Since(built); age > maxAge {
+			log.Warning("the %s database was built on %s, %d days ago, consider updating it", name,
+				built.Format("2006-01-02"), int(age.Hours()/24))
+		}
+	}
+}
+
+// opens the new databases and swaps them with the current ones, lookups in progress
+// complete on the old readers before these are closed
+func (g *Geo) reload() error {
+	readers, err := openGeoReaders(g.conf)
+	if err != nil {
+		return err
+	}
+
+	g.Lock()
+	old := g.readers
+	g.readers = readers
+	g.Unlock()
+
+	old.close()
+
+	for name, built := range readers.buildDates() {
+		log.Info("loaded %s database built on %s", name, built.Format("2006-01-02"))
+	}
+
+	return nil
+}
+
+// Watch polls the database files and reloads them when they change, onReload is called
+// after every successful reload.
+func (g *Geo) Watch(onReload func()) {
+	if g.conf.GeoIPReloadSecs <= 0 {
+		return
+	}
+
+	go func() {
+		log.Info("checking geoip databases for updates every %d seconds", g.conf.GeoIPReloadSecs)
+
+		lastAgeCheck := time.Now()
+		for {
+			time.Sleep(time.Duration(g.conf.GeoIPReloadSecs) * time.Second)
+
+			if g.changed() {
+				log.Info("geoip databases changed, reloading ...")
+				if err := g.reload(); err != nil {
+					// keep using the old ones and try again on the next change
+					log.Error("error reloading geoip databases: %v", err)
+					continue
+				}
+				g.checkAge()
+				lastAgeCheck = time.Now()
+				if onReload != nil {
+					onReload()
+				}
+			} else if time.Since(lastAgeCheck) > 24*time.Hour {
+				g.checkAge()
+				lastAgeCheck = time.Now()
+			}
+		}
+	}()
+}
+
+// an error is only returned if the country lookup fails, city and asn are best effort
+func (g *Geo) Locate(address string) (*Location, error) {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid address '%s'", address)
+	}
+
+	g.RLock()
+	defer g.RUnlock()
+
+	country, err := g.readers.country.Country(ip)
+	if err != nil {
+		return nil, err
+	}
+
+	loc := &Location{
+		CountryCode: country.Country.IsoCode,
+		CountryName: country.Country.Names["en"],
+	}
+
+	if g.readers.city != nil {
+		if city, err := g.readers.city.City(ip); err == nil {
+			loc.City = city.City.Names["en"]
+		} else {
+			log.Debug("error getting city for %s: %v", address, err)
+		}
+	}
+
+	if g.readers.asn != nil {
+		record := asnRecord{}
+		if err := g.readers.asn.Lookup(ip, &record); err == nil {
+			if record.Number > 0 {
+				loc.ASN, loc.ASOrg = record.Number, record.Organization
+			} else if record.ASN != "" {
+				if num, err := strconv.ParseUint(strings.TrimPrefix(strings.ToUpper(record.ASN), "AS"), 10, 32); err == nil {
+					loc.ASN, loc.ASOrg = uint(num), record.Name
+				}
+			}
+		} else {
+			log.Debug("error getting asn for %s: %v", address, err)
+		}
+	}
+
+	return loc, nil
+}
+
+func (g *Geo) Close() {
+	g.Lock()
+	defer g.Unlock()
+	g.readers.close()
+}
@@ -0,0 +1,239 @@
+package core
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/evilsocket/islazy/fs"
+	"github.com/evilsocket/islazy/log"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+)
+
+const (
+	defaultGitRetries     = 3
+	defaultGitBackoffSecs = 2
+)
+
+// the files of a publication, committed together
+type pendingReport struct {
+	Name    string        `json:"name"`
+	Message string        `json:"message"`
+	Files   []*ReportFile `json:"files"`
+}
+
+// GitDestination commits the reports to a local clone of a repository and pushes them.
+type GitDestination struct {
+	repository `yaml:",inline"`
+
+	// when several nodes share a repository, store the files of each node
+	// in a "dir" named after it or "prefix" them with its name
+	PerNode string `yaml:"per_node"`
+	// push attempts and initial delay between them, doubled at each attempt
+	Retries     int `yaml:"retries"`
+	BackoffSecs int `yaml:"backoff"`
+
+	auth transport.AuthMethod
+	repo *git.Repository
+	tree *git.Worktree
+}
+
+func (d *GitDestination) Init() (err error) {
+	if d.PerNode != "" && d.PerNode != "dir" && d.PerNode != "prefix" {
+		return fmt.Errorf("unknown per_node layout '%s', use dir or prefix", d.PerNode)
+	}
+	if d.Retries <= 0 {
+		d.Retries = defaultGitRetries
+	}
+	if d.BackoffSecs <= 0 {
+		d.BackoffSecs = defaultGitBackoffSecs
+	}
+
+	if d.auth, err = d.repository.auth(); err != nil {
+		return err
+	}
+
+	if fs.Exists(d.Local) {
+		if d.repo, err = git.PlainOpen(d.Local); err != nil {
+			return fmt.Errorf("error while opening git repo %s: %v", d.Local, err)
+		}
+	} else {
+		log.Info("cloning %s to %s ...", d.Remote, d.Local)
+
+		cloneOpts := git.CloneOptions{
+			URL:  d.Remote,
+			Auth: d.auth,
+			// Progress: os.Stdout,
+		}
+		if d.Branch != "" {
+			cloneOpts.ReferenceName = plumbing.NewBranchReferenceName(d.Branch)
+			cloneOpts.SingleBranch = true
+		}
+
+		if d.repo, err = git.PlainClone(d.Local, false, &cloneOpts); err != nil {
+			return fmt.Errorf("error while cloning git repo %s to %s: %v", d.Remote, d.Local, err)
+		}
+	}
+
+	d.tree, err = d.repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("error while getting working tree for git repo %s: %v", d.Local, err)
+	}
+
+	if d.Branch == "" {
+		head, err := d.repo.Head()
+		if err != nil {
+			return fmt.Errorf("error while reading HEAD of git repo %s: %v", d.Local, err)
+		}
+		d.Branch = head.Name().Short()
+	}
+
+	log.Info("updating %s from %s ...", d.Local, d.Remote)
+	if err = d.sync(); err != nil {
+		return err
+	}
+
+	log.Debug("git repo %s on branch %s, committing as %s <%s>", d.Local, d.Branch, d.Author.Name, d.Author.Email)
+
+	return nil
+}
+
+// fetches the remote and hard resets the local branch to it, local commits that were
+// never pushed are discarded as the ledger publishes their reports again
+func (d *GitDestination) sync() error {
+	err := d.repo.Fetch(&git.FetchOptions{
+		RemoteName: "origin",
+		Auth:       d.auth,
+	})
+	if err != nil && err != git.NoErrAlreadyUpToDate {
+		return fmt.Errorf("error while fetching git repo %s: %v", d.Local, err)
+	}
+
+	remote, err := d.repo.Reference(plumbing.NewRemoteReferenceName("origin", d.Branch), true)
+	if err != nil {
+		return fmt.Errorf("error while resolving origin/%s of git repo %s: %v", d.Branch, d.Local, err)
+	}
+
+	branch := plumbing.NewBranchReferenceName(d.Branch)
+	if err = d.repo.Storer.SetReference(plumbing.NewHashReference(branch, remote.Hash())); err != nil {
+		return fmt.Errorf("error while updating branch %s of git repo %s: %v", d.Branch, d.Local, err)
+	}
+
+	err = d.tree.Checkout(&git.CheckoutOptions{
+		Branch: branch,
+		Force:  true,
+	})
+	if err != nil {
+		return fmt.Errorf("error while checking out branch %s of git repo %s: %v", d.Branch, d.Local, err)
+	}
+	return nil
+}
+
+// path of a report file relative to the repository
+func (d *GitDestination) filePath(node string, name string) string {
+	switch d.PerNode {
+	case "dir":
+		return path.Join(node, name)
+	case "prefix":
+		return node + "_" + name
+	}
+	return name
+}
+
+func (d *GitDestination) commit(pending *pendingReport) error {
+	for _, file := range pending.Files {
+		fileName := path.Join(d.Local, file.Name)
+		if err := os.MkdirAll(path.Dir(fileName), 0755); err != nil {
+			return fmt.Errorf("error creating %s: %v", path.Dir(fileName), err)
+		} else if err = ioutil.WriteFile(fileName, file.Data, 0644); err != nil {
+			return fmt.Errorf("error writing %s: %v", fileName, err)
+		}
+
+		log.Info("adding %s to repository", file.Name)
+		if _, err := d.tree.Add(file.Name); err != nil {
+			return fmt.Errorf("error while adding report %s to git repo %s: %v", file.Name, d.Local, err)
+		}
+	}
+
+	// files that didn't change since the last push
+	if status, err := d.tree.Status(); err != nil {
+		return fmt.Errorf("error while getting status of git repo %s: %v", d.Local, err)
+	} else if status.IsClean() {
+		log.Debug("%s didn't change", pending.Name)
+		return nil
+	}
+
+	_, err := d.tree.Commit(pending.Message, &git.CommitOptions{
+		Author:    d.Author.signature(),
+		Committer: d.Committer.signature(),
+	})
+	if err != nil {
+		return fmt.Errorf("error while creating commit for git repo %s: %v", d.Local, err)
+	}
+	return nil
+}
+
+// push commits a report on top of the remote branch and pushes it, if another
+// node pushed in the meantime everything is fetched and redone
+func (d *GitDestination) push(pending *pendingReport) (err error) {
+	backoff := time.Duration(d.BackoffSecs) * time.Second
+	refSpec := config.RefSpec(fmt.Sprintf("refs/heads/%s:refs/heads/%s", d.Branch, d.Branch))
+
+	for attempt := 1; attempt <= d.Retries; attempt++ {
+		if attempt > 1 {
+			log.Warning("git push to %s failed (%v), retrying in %s ...", d.Remote, err, backoff)
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+
+		if err = d.sync(); err != nil {
+			continue
+		}
+
+		if err = d.commit(pending); err != nil {
+			return err
+		}
+
+		err = d.repo.Push(&git.PushOptions{
+			Auth:     d.auth,
+			RefSpecs: []config.RefSpec{refSpec},
+		})
+		if err == nil || err == git.NoErrAlreadyUpToDate {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("error while pushing git repo %s: %v", d.Local, err)
+}
+
+func (d *GitDestination) Publish(pub *Publication) (string, error) {
+	pending := &pendingReport{
+		Name:    pub.Name,
+		Message: pub.Message,
+		Files:   make([]*ReportFile, 0, len(pub.Files)),
+	}
+	if d.PerNode != "" {
+		pending.Name = d.filePath(pub.Node, pub.Name)
+		pending.Message = fmt.Sprintf("%s: %s", pub.Node, pending.Message)
+	}
+	pending.Name = strings.Replace(pending.Name, "/", "_", -1)
+
+	for _, file := range pub.Files {
+		pending.Files = append(pending.Files, &ReportFile{
+			Name: d.filePath(pub.Node, file.Name),
+			Data: file.Data,
+		})
+	}
+
+	if err := d.push(pending); err != nil {
+		return "", err
+	}
+
+	return fileURL(d.HTTP, pending.Files[0].Name), nil
+}
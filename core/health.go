@@ -0,0 +1,164 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	healthOK   = "ok"
+	healthFail = "fail"
+)
+
+type Health struct {
+	// seconds without a successful read before a sensor is considered stuck,
+	// defaults to three times the sensor period
+	MaxSensorIdleSecs int `yaml:"max_sensor_idle"`
+	// seconds without a successful flush, defaults to three times the database period
+	MaxFlushAgeSecs int `yaml:"max_flush_age"`
+	// seconds without a successful report, defaults to twice the reports period
+	MaxReportAgeSecs int `yaml:"max_report_age"`
+}
+
+type HealthCheck struct {
+	Status      string     `json:"status"`
+	Message     string     `json:"message,omitempty"`
+	LastSuccess *time.Time `json:"last_success,omitempty"`
+	AgeSecs     *float64   `json:"age_seconds,omitempty"`
+}
+
+type HealthReport struct {
+	Status string                  `json:"status"`
+	Checks map[string]*HealthCheck `json:"checks"`
+}
+
+func (h *HealthReport) add(name string, check *HealthCheck) {
+	h.Checks[name] = check
+	if check.Status != healthOK {
+		h.Status = healthFail
+	}
+}
+
+// checks that something succeeded at least once every maxAge, counting from since
+// if it never did
+func ageCheck(last time.Time, since time.Time, maxAge time.Duration, lastErr error) *HealthCheck {
+	check := &HealthCheck{Status: healthOK}
+
+	ref := since
+	if !last.IsZero() {
+		ref = last
+		check.LastSuccess = &last
+	}
+
+	age := time.Since(ref).Seconds()
+	check.AgeSecs = &age
+
+	if time.Since(ref) > maxAge {
+		check.Status = healthFail
+		if last.IsZero() {
+			check.Message = fmt.Sprintf("never succeeded in the last %s", maxAge)
+		} else {
+			check.Message = fmt.Sprintf("last success older than %s", maxAge)
+		}
+	}
+
+	if lastErr != nil {
+		if check.Message != "" {
+			check.Message += ": "
+		}
+		check.Message += lastErr.Error()
+	}
+
+	return check
+}
+
+// checks that the last publish to a destination succeeded, nil if nothing was published since start
+func destinationCheck(dest *ReportDestination) *HealthCheck {
+	publishedAt, publishErr := dest.Status()
+	if publishErr != nil {
+		return &HealthCheck{Status: healthFail, Message: publishErr.Error()}
+	} else if publishedAt.IsZero() {
+		return nil
+	}
+
+	age := time.Since(publishedAt).Seconds()
+	return &HealthCheck{Status: healthOK, LastSuccess: &publishedAt, AgeSecs: &age}
+}
+
+func durationOr(secs int, def time.Duration) time.Duration {
+	if secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+	return def
+}
+
+// liveness only includes checks that a restart could fix, readiness all of them
+func (r *Aggregator) Health(readiness bool) *HealthReport {
+	health := r.conf.API.Health
+	report := &HealthReport{
+		Status: healthOK,
+		Checks: make(map[string]*HealthCheck),
+	}
+
+	dbCheck := &HealthCheck{Status: healthOK}
+	if sqlDB, err := r.db.DB(); err != nil {
+		dbCheck.Status, dbCheck.Message = healthFail, err.Error()
+	} else {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err = sqlDB.PingContext(ctx); err != nil {
+			dbCheck.Status, dbCheck.Message = healthFail, err.Error()
+		}
+	}
+	report.add("database", dbCheck)
+
+	for _, sensor := range r.conf.Sensors {
+		if sensor.Enabled {
+			lastRead, lastErr := sensor.Status()
+			maxIdle := durationOr(health.MaxSensorIdleSecs, 3*time.Duration(sensor.PeriodSecs)*time.Second)
+			report.add("sensor:"+sensor.Name, ageCheck(lastRead, r.started, maxIdle, lastErr))
+		}
+	}
+
+	status := r.status()
+	maxFlushAge := durationOr(health.MaxFlushAgeSecs, 3*time.Duration(r.conf.Database.PeriodSecs)*time.Second)
+	report.add("flush", ageCheck(status.flushedAt, r.started, maxFlushAge, status.flushErr))
+
+	if readiness {
+		// Start fails if the geoip database can't be loaded, and reloads keep the old one on errors
+		report.add("geoip", &HealthCheck{Status: healthOK})
+
+		if r.conf.Reporter.Enabled {
+			// the first report only happens after the warm up period
+			maxReportAge := durationOr(health.MaxReportAgeSecs, 2*interval(r.conf.Reporter.schedule)+r.conf.Reporter.warmUp())
+			report.add("report", ageCheck(status.reportedAt, r.started, maxReportAge, status.reportErr))
+
+			// quiet nodes may have nothing to publish, destinations only fail on their last error
+			if !r.conf.Reporter.DryRun {
+				for _, dest := range r.conf.Reporter.Destinations {
+					if check := destinationCheck(dest); check != nil {
+						report.add("destination:"+dest.Name, check)
+					}
+				}
+			}
+		}
+	}
+
+	return report
+}
+
+func (a *API) onHealth(readiness bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		report := a.agg.Health(readiness)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-cache")
+		if report.Status != healthOK {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		json.NewEncoder(w).Encode(report)
+	}
+}
@@ -0,0 +1,207 @@
+package core
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/evilsocket/islazy/log"
+)
+
+const defaultIntelPeriodSecs = 3600
+
+type IntelList struct {
+	Name     string `yaml:"name"`
+	Filename string `yaml:"filename"`
+	// plain (one address or network per line, # comments), drop (spamhaus, ; comments)
+	// or netset (firehol)
+	Format string `yaml:"format"`
+
+	modTime time.Time
+	// networks indexed by prefix length, then by masked address
+	networks map[int]map[string]bool
+}
+
+func (l *IntelList) commentChars() string {
+	if l.Format == "drop" {
+		return ";"
+	}
+	return "#"
+}
+
+func (l *IntelList) load() error {
+	fp, err := os.Open(l.Filename)
+	if err != nil {
+		return err
+	}
+	defer fp.Close()
+
+	networks := make(map[int]map[string]bool)
+	num := 0
+	scanner := bufio.NewScanner(fp)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if idx := strings.IndexAny(line, l.commentChars()); idx >= 0 {
+			line = line[:idx]
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		entry := fields[0]
+		if !strings.Contains(entry, "/") {
+			if ip := net.ParseIP(entry); ip != nil && ip.To4() != nil {
+				entry += "/32"
+			} else {
+				entry += "/128"
+			}
+		}
+
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			log.Debug("%s: skipping invalid entry '%s'", l.Filename, fields[0])
+			continue
+		}
+
+		ones, _ := network.Mask.Size()
+		if networks[ones] == nil {
+			networks[ones] = make(map[string]bool)
+		}
+		networks[ones][string(network.IP)] = true
+		num++
+	}
+
+	if err = scanner.Err(); err != nil {
+		return err
+	}
+
+	l.networks = networks
+
+	log.Info("loaded %d entries for list %s from %s", num, l.Name, l.Filename)
+
+	return nil
+}
+
+func (l *IntelList) Contains(ip net.IP) bool {
+	if v4 := ip.To4(); v4 != nil {
+		ip = v4
+	}
+
+	bits := len(ip) * 8
+	for ones, networks := range l.networks {
+		if ones > bits {
+			continue
+		}
+		masked := ip.Mask(net.CIDRMask(ones, bits))
+		// ipv4 networks are parsed as 4 bytes and ipv6 as 16 bytes
+		if networks[string(masked)] {
+			return true
+		}
+	}
+	return false
+}
+
+type Intel struct {
+	sync.RWMutex
+
+	Enabled    bool         `yaml:"enabled"`
+	PeriodSecs int          `yaml:"period"`
+	Lists      []*IntelList `yaml:"lists"`
+}
+
+func (i *Intel) Init() error {
+	if i.PeriodSecs <= 0 {
+		i.PeriodSecs = defaultIntelPeriodSecs
+	}
+
+	for _, list := range i.Lists {
+		if list.Name == "" {
+			return fmt.Errorf("intel list %s has no name", list.Filename)
+		} else if strings.Contains(list.Name, ",") {
+			return fmt.Errorf("intel list name '%s' can't contain commas", list.Name)
+		}
+
+		switch list.Format {
+		case "":
+			list.Format = "plain"
+		case "plain", "drop", "netset":
+		default:
+			return fmt.Errorf("unknown format '%s' for intel list %s", list.Format, list.Name)
+		}
+
+		if stat, err := os.Stat(list.Filename); err != nil {
+			return fmt.Errorf("error loading intel list %s: %v", list.Name, err)
+		} else if err = list.load(); err != nil {
+			return fmt.Errorf("error loading intel list %s: %v", list.Name, err)
+		} else {
+			list.modTime = stat.ModTime()
+		}
+	}
+
+	return nil
+}
+
+func (i *Intel) reload() {
+	for _, list := range i.Lists {
+		stat, err := os.Stat(list.Filename)
+		if err != nil {
+			log.Error("error checking intel list %s: %v", list.Name, err)
+			continue
+		} else if stat.ModTime().Equal(list.modTime) {
+			continue
+		}
+
+		// parse outside of the lock, then swap
+		updated := &IntelList{
+			Name:     list.Name,
+			Filename: list.Filename,
+			Format:   list.Format,
+		}
+		if err = updated.load(); err != nil {
+			log.Error("error reloading intel list %s: %v", list.Name, err)
+			continue
+		}
+
+		i.Lock()
+		list.networks = updated.networks
+		list.modTime = stat.ModTime()
+		i.Unlock()
+	}
+}
+
+func (i *Intel) Start() {
+	go func() {
+		log.Info("checking %d intel lists for updates every %d seconds", len(i.Lists), i.PeriodSecs)
+		for {
+			time.Sleep(time.Duration(i.PeriodSecs) * time.Second)
+			i.reload()
+		}
+	}()
+}
+
+// Match returns the sorted names of the lists containing the address.
+func (i *Intel) Match(address string) []string {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return nil
+	}
+
+	i.RLock()
+	defer i.RUnlock()
+
+	tags := make([]string, 0)
+	for _, list := range i.Lists {
+		if list.Contains(ip) {
+			tags = append(tags, list.Name)
+		}
+	}
+	sort.Strings(tags)
+	return tags
+}
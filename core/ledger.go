@@ -0,0 +1,435 @@
+package core
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/evilsocket/islazy/log"
+	"gorm.io/gorm"
+
+	"github.com/evilsocket/takuan/models"
+)
+
+const maxRepublishBackoff = 24 * time.Hour
+
+func unreportedScope(db *gorm.DB) *gorm.DB {
+	return db.Where("reported_at IS NULL AND report_id IS NULL")
+}
+
+// windowScope selects the events created in the window, or before its end if it has no start.
+func windowScope(w window) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if !w.Start.IsZero() {
+			db = db.Where("created_at >= ?", w.Start)
+		}
+		return db.Where("created_at < ?", w.End)
+	}
+}
+
+// nextBatch returns the id of the last event of the next report of the window, so that it
+// doesn't include more than max events, or 0 if there are no unreported events.
+func (r *Aggregator) nextBatch(w window, max int) (uint, error) {
+	var ids []uint
+	err := r.db.Model(&models.Event{}).Scopes(unreportedScope, windowScope(w)).Order("id").Offset(max-1).Limit(1).Pluck("id", &ids).Error
+	if err != nil {
+		return 0, err
+	} else if len(ids) > 0 {
+		return ids[0], nil
+	}
+
+	// less than max events left
+	var maxID sql.NullInt64
+	if err = r.db.Model(&models.Event{}).Scopes(unreportedScope, windowScope(w)).Select("MAX(id)").Row().Scan(&maxID); err != nil {
+		return 0, err
+	}
+	return uint(maxID.Int64), nil
+}
+
+// reportWindow publishes the reports of the unreported events of a window, large backlogs are
+// split in reports of at most MaxEvents events named after name and their part number.
+func (r *Aggregator) reportWindow(w window, name string) error {
+	// parts created by a previous attempt that failed halfway
+	var existing int64
+	err := r.db.Model(&models.Report{}).
+		Where("node_name = ? AND (name = ? OR name LIKE ?)", r.conf.NodeName, name, name+"\\_%").
+		Count(&existing).Error
+	if err != nil {
+		return fmt.Errorf("error getting the reports of %s: %v", name, err)
+	}
+
+	for part := int(existing) + 1; ; part++ {
+		maxID, err := r.nextBatch(w, r.conf.Reporter.MaxEvents)
+		if err != nil {
+			return fmt.Errorf("error getting unreported events: %v", err)
+		} else if maxID == 0 {
+			return nil
+		}
+
+		ledger, err := r.newLedger(w, name, part, maxID)
+		if err != nil {
+			return err
+		} else if ledger == nil {
+			continue
+		}
+
+		log.Info("reporting %d events from %d addresses", ledger.Events, ledger.Addresses)
+
+		// failed reports stay in the ledger and are published again by republish
+		if err = r.publish(ledger); err != nil {
+			log.Error("%v", err)
+			metricReportFailures.Inc()
+		}
+	}
+}
+
+// newLedger creates the ledger entry for a part of the report of a window with the unreported
+// events up to maxID matching the reporter filter, the others are marked as reported without a
+// report. Everything happens in a single transaction and if all of the events are excluded, no
+// entry is created.
+func (r *Aggregator) newLedger(w window, name string, part int, maxID uint) (*models.Report, error) {
+	createdAt := time.Now()
+	ledger := &models.Report{
+		CreatedAt: createdAt,
+		NodeName:  r.conf.NodeName,
+		Name:      name,
+		Formats:   strings.Join(r.conf.Reporter.Formats, ","),
+		Status:    models.ReportPending,
+		WindowEnd: &w.End,
+	}
+	if part > 1 {
+		ledger.Name = fmt.Sprintf("%s_%d", ledger.Name, part)
+	}
+	if !w.Start.IsZero() {
+		ledger.WindowStart = &w.Start
+	}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(ledger).Error; err != nil {
+			return err
+		}
+
+		res := tx.Model(&models.Event{}).
+			Scopes(unreportedScope, windowScope(w), r.conf.Reporter.Filter().Scope).
+			Where("id <= ?", maxID).
+			Update("report_id", ledger.ID)
+		if res.Error != nil {
+			return res.Error
+		}
+		ledger.Events = int(res.RowsAffected)
+
+		res = tx.Model(&models.Event{}).
+			Scopes(unreportedScope, windowScope(w)).
+			Where("id <= ?", maxID).
+			Update("reported_at", createdAt)
+		if res.Error != nil {
+			return res.Error
+		} else if res.RowsAffected > 0 {
+			log.Info("%d events excluded by tags", res.RowsAffected)
+		}
+
+		if ledger.Events == 0 {
+			log.Info("no events to report after filtering by tags")
+			return tx.Delete(ledger).Error
+		}
+
+		var addresses int64
+		err := tx.Model(&models.Event{}).Where("report_id = ?", ledger.ID).Distinct("address").Count(&addresses).Error
+		if err != nil {
+			return err
+		}
+		ledger.Addresses = int(addresses)
+
+		return tx.Model(ledger).Updates(map[string]interface{}{
+			"events":    ledger.Events,
+			"addresses": ledger.Addresses,
+		}).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating report ledger: %v", err)
+	} else if ledger.Events == 0 {
+		return nil, nil
+	}
+
+	return ledger, nil
+}
+
+// GenerateReport builds the report of a ledger entry streaming the events aggregated
+// by address, sensor and rule, so that they never need to be loaded in memory.
+func GenerateReport(db *gorm.DB, ledger *models.Report) (*Report, error) {
+	return generateReport(db, ledger, func(db *gorm.DB) *gorm.DB {
+		return db.Where("report_id = ?", ledger.ID)
+	})
+}
+
+// generateReport builds a report named after the ledger entry with the events selected by scope.
+func generateReport(db *gorm.DB, ledger *models.Report, scope func(*gorm.DB) *gorm.DB) (*Report, error) {
+	report := NewReport(ledger.NodeName, ledger.CreatedAt)
+	report.Name = ledger.Name
+	report.WindowStart, report.WindowEnd = ledger.WindowStart, ledger.WindowEnd
+
+	// a single address can have different geo fields if a relocation happened meanwhile,
+	// MAX picks one of them and keeps the query valid with ONLY_FULL_GROUP_BY
+	rows, err := db.Model(&models.Event{}).
+		Select("address, sensor, rule, COUNT(*), MIN(created_at), MAX(created_at), " +
+			"MAX(country_code), MAX(country_name), MAX(city), MAX(asn), MAX(as_org), GROUP_CONCAT(DISTINCT tags)").
+		Scopes(scope).
+		Group("address, sensor, rule").
+		Rows()
+	if err != nil {
+		return nil, fmt.Errorf("error generating report %s: %v", ledger.Name, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var countryCode, countryName, city, asOrg, tags sql.NullString
+		var asn sql.NullInt64
+
+		row := &ReportRow{}
+		err = rows.Scan(&row.Address, &row.Sensor, &row.Rule, &row.Events, &row.FirstSeen, &row.LastSeen,
+			&countryCode, &countryName, &city, &asn, &asOrg, &tags)
+		if err != nil {
+			return nil, fmt.Errorf("error generating report %s: %v", ledger.Name, err)
+		}
+
+		row.CountryCode = countryCode.String
+		row.CountryName = countryName.String
+		row.City = city.String
+		row.ASN = uint(asn.Int64)
+		row.ASOrg = asOrg.String
+		row.Tags = tags.String
+
+		report.Add(row)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error generating report %s: %v", ledger.Name, err)
+	}
+
+	report.Finish()
+
+	return report, nil
+}
+
+// publish publishes the report of a ledger entry and, if successful, marks its events
+// as reported in a single transaction. On failure the entry and its events are left as
+// they are, so that the very same report is published again on the next cycle.
+func (r *Aggregator) publish(ledger *models.Report) error {
+	report, err := GenerateReport(r.db, ledger)
+	if err != nil {
+		r.failed(ledger, err)
+		return err
+	}
+
+	pub, err := r.conf.Reporter.Render(report)
+	if err != nil {
+		r.failed(ledger, err)
+		return err
+	}
+
+	scope := func(db *gorm.DB) *gorm.DB {
+		return db.Where("report_id = ?", ledger.ID)
+	}
+	charts := r.reportCharts(report, scope, pub)
+
+	// a published report is only sent again to the destinations that failed
+	var only []string
+	if ledger.Pending != "" {
+		only = strings.Split(ledger.Pending, ",")
+	}
+	reportURL, failed, err := r.conf.Reporter.PublishTo(pub, only)
+	if err != nil {
+		r.failed(ledger, err)
+		return err
+	}
+
+	// a report published again was already notified, unless it had no public url yet
+	republished := ledger.Status == models.ReportPublished
+	notified := republished && publicURL(ledger.URL)
+	if republished && reportURL == "" {
+		reportURL = ledger.URL
+	}
+
+	now := time.Now()
+	update := map[string]interface{}{
+		"status":  models.ReportPublished,
+		"url":     reportURL,
+		"files":   manifestFiles(pub.Files),
+		"error":   "",
+		"pending": strings.Join(failed, ","),
+	}
+	if len(failed) > 0 {
+		ledger.Attempts++
+		update["attempts"] = ledger.Attempts
+		update["error"] = fmt.Sprintf("not published to %s", update["pending"])
+		log.Warning("report %s %s, retrying later", ledger.Name, update["error"])
+	}
+	if !republished {
+		update["published_at"] = now
+	}
+
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(ledger).Updates(update).Error; err != nil || republished {
+			return err
+		}
+		return tx.Model(&models.Event{}).Where("report_id = ?", ledger.ID).Update("reported_at", now).Error
+	})
+	if err != nil {
+		return fmt.Errorf("error updating report ledger %s: %v", ledger.Name, err)
+	} else if republished {
+		log.Info("report %s published to %s", ledger.Name, strings.Join(only, ", "))
+	} else {
+		log.Info("report %s published (%d addresses, %d events)", ledger.Name, ledger.Addresses, ledger.Events)
+	}
+
+	if notified {
+		return nil
+	} else if !publicURL(reportURL) {
+		log.Info("report %s has no public url, not notifying it", ledger.Name)
+		return nil
+	}
+
+	repeat, err := r.repeatOffenders(scope, ledger.ID)
+	if err != nil {
+		log.Warning("error counting repeat offenders of %s: %v", ledger.Name, err)
+	}
+
+	chartURLs(charts, pub, reportURL)
+	notification := NewReportNotification(report, reportURL, repeat)
+	notification.Charts = charts
+	r.conf.Notify(notification)
+
+	return nil
+}
+
+// failed marks a ledger entry as failed and counts the attempt.
+func (r *Aggregator) failed(ledger *models.Report, err error) {
+	ledger.Attempts++
+	if dbErr := r.db.Model(ledger).Updates(map[string]interface{}{
+		"status":   models.ReportFailed,
+		"error":    err.Error(),
+		"attempts": ledger.Attempts,
+	}).Error; dbErr != nil {
+		log.Error("error updating report ledger %s: %v", ledger.Name, dbErr)
+	}
+}
+
+// repeatOffenders counts the addresses of the events selected by scope that were already in
+// previous reports, the ones before the ledger entry with id before or all of them if it's 0.
+func (r *Aggregator) repeatOffenders(scope func(*gorm.DB) *gorm.DB, before uint) (int, error) {
+	previous := r.db.Model(&models.Event{}).Select("address").Where("reported_at IS NOT NULL")
+	if before > 0 {
+		// events reported before the ledger existed have no report_id
+		previous = previous.Where("(report_id IS NULL OR report_id < ?)", before)
+	}
+
+	var repeat int64
+	err := r.db.Model(&models.Event{}).
+		Scopes(scope).
+		Where("address IN (?)", previous).
+		Distinct("address").
+		Count(&repeat).Error
+	return int(repeat), err
+}
+
+// dryRun publishes the report of the unreported events of a window without creating its ledger
+// entry and without marking them as reported, max_events is ignored.
+func (r *Aggregator) dryRun(w window) error {
+	scope := func(db *gorm.DB) *gorm.DB {
+		return db.Scopes(unreportedScope, windowScope(w), r.conf.Reporter.Filter().Scope)
+	}
+
+	ledger := &models.Report{
+		CreatedAt:   time.Now(),
+		NodeName:    r.conf.NodeName,
+		Name:        ReportName(w.End),
+		WindowEnd:   &w.End,
+		WindowStart: &w.Start,
+	}
+	if w.Start.IsZero() {
+		ledger.WindowStart = nil
+	}
+
+	report, err := generateReport(r.db, ledger, scope)
+	if err != nil {
+		return err
+	} else if len(report.Entries) == 0 {
+		log.Info("no events to report (dry run)")
+		return nil
+	}
+
+	log.Info("reporting %d events from %d addresses (dry run)", report.Events, len(report.Entries))
+
+	pub, err := r.conf.Reporter.Render(report)
+	if err != nil {
+		return err
+	}
+
+	charts := r.reportCharts(report, scope, pub)
+
+	reportURL, err := r.conf.Reporter.Publish(pub)
+	if err != nil {
+		return err
+	}
+
+	// the events in the window are unreported, every reported one is from a previous report
+	repeat, err := r.repeatOffenders(scope, 0)
+	if err != nil {
+		log.Warning("error counting repeat offenders of %s: %v", ledger.Name, err)
+	}
+
+	// nothing was really published, so nothing is really sent
+	chartURLs(charts, pub, reportURL)
+	notification := NewReportNotification(report, reportURL, repeat)
+	notification.Charts = charts
+	notification.DryRun = true
+	r.conf.Notify(notification)
+
+	return nil
+}
+
+// republishBackoff is the time to wait before publishing again a report that failed, doubled
+// at each attempt up to maxRepublishBackoff, the first retry happens at the next cycle.
+func republishBackoff(period time.Duration, attempts int) time.Duration {
+	// stops doubling at the cap, so that long periods and many attempts don't overflow
+	backoff := period
+	for i := 0; i < attempts && backoff-period/2 < maxRepublishBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff -= period / 2; backoff > maxRepublishBackoff {
+		backoff = maxRepublishBackoff
+	}
+	return backoff
+}
+
+// republish publishes again the reports of this node that failed, were interrupted or still have
+// to be published to some destinations, a report that keeps failing doesn't block the others.
+func (r *Aggregator) republish() error {
+	var ledgers []models.Report
+	err := r.db.Where("node_name = ? AND (status <> ? OR pending <> '')", r.conf.NodeName, models.ReportPublished).Order("id").Find(&ledgers).Error
+	if err != nil {
+		return fmt.Errorf("error getting unpublished reports: %v", err)
+	}
+
+	period := interval(r.conf.Reporter.schedule)
+	for i := range ledgers {
+		ledger := &ledgers[i]
+		if ledger.Attempts > 0 && time.Since(ledger.UpdatedAt) < republishBackoff(period, ledger.Attempts-1) {
+			log.Debug("report %s failed %d times, backing off", ledger.Name, ledger.Attempts)
+			continue
+		}
+
+		if ledger.Pending != "" {
+			log.Info("publishing report %s again to %s", ledger.Name, ledger.Pending)
+		} else {
+			log.Info("publishing %s report %s again (%d events)", ledger.Status, ledger.Name, ledger.Events)
+		}
+		if err = r.publish(ledger); err != nil {
+			log.Error("%v", err)
+			metricReportFailures.Inc()
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRepublishBackoff(t *testing.T) {
+	for _, test := range []struct {
+		period   time.Duration
+		attempts int
+		expected time.Duration
+	}{
+		{time.Hour, 0, 30 * time.Minute},
+		{time.Hour, 1, 90 * time.Minute},
+		{time.Hour, 3, 7*time.Hour + 30*time.Minute},
+		{time.Hour, 5, maxRepublishBackoff},
+		{time.Hour, 1000, maxRepublishBackoff},
+		{time.Minute, 16, maxRepublishBackoff},
+		{24 * time.Hour, 0, 12 * time.Hour},
+		{24 * time.Hour, 1, maxRepublishBackoff},
+		// long periods used to overflow and retry immediately
+		{30 * 24 * time.Hour, 16, maxRepublishBackoff},
+		{365 * 24 * time.Hour, 64, maxRepublishBackoff},
+		{100 * 365 * 24 * time.Hour, 1000, maxRepublishBackoff},
+	} {
+		if backoff := republishBackoff(test.period, test.attempts); backoff != test.expected {
+			t.Errorf("expected %s for %s after %d attempts, got %s", test.expected, test.period, test.attempts, backoff)
+		}
+	}
+}
@@ -0,0 +1,326 @@
+package core
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/evilsocket/islazy/log"
+
+	"github.com/evilsocket/takuan/models"
+)
+
+const listsName = "lists"
+
+var (
+	defaultListWindows     = []string{"24h", "7d", "30d"}
+	defaultListSplitWindow = "7d"
+
+	blocklistExtensions = map[string]string{
+		"plain":     ".txt",
+		"cidr":      ".txt",
+		"ipset":     ".ipset",
+		"nft":       ".nft",
+		"iptables":  ".rules",
+		"ip6tables": ".rules",
+		"json":      ".json",
+		"csv":       ".csv",
+	}
+
+	unsafeFileChars = regexp.MustCompile(`[^a-zA-Z0-9_\-.]+`)
+)
+
+// ReportLists are cumulative files regenerated from the database at every
+// reporting cycle and published to the same destinations of the reports.
+type ReportLists struct {
+	Enabled bool `yaml:"enabled"`
+	// rolling windows of the blocklist_<window> files, like 24h, 7d or 30d
+	Windows []string `yaml:"windows"`
+	// window of the per country and per rule files
+	SplitWindow string `yaml:"split_window"`
+	Countries   bool   `yaml:"countries"`
+	Rules       bool   `yaml:"rules"`
+	// one of the blocklist formats
+	Format    string `yaml:"format"`
+	MinEvents int    `yaml:"min_events"`
+
+	windows     map[string]time.Duration
+	splitWindow time.Duration
+	// hash of the last published index, it changes with any of the lists
+	published [sha256.Size]byte
+}
+
+func (l *ReportLists) Init() (err error) {
+	if len(l.Windows) == 0 {
+		l.Windows = defaultListWindows
+	}
+	if l.SplitWindow == "" {
+		l.SplitWindow = defaultListSplitWindow
+	}
+	if l.Format == "" {
+		l.Format = "csv"
+	} else if _, found := BlocklistFormats[l.Format]; !found {
+		return fmt.Errorf("unknown lists format '%s'", l.Format)
+	}
+
+	l.windows = make(map[string]time.Duration)
+	for _, window := range l.Windows {
+		if l.windows[window], err = parseDuration(window); err != nil {
+			return fmt.Errorf("invalid lists window '%s': %v", window, err)
+		}
+	}
+	if l.splitWindow, err = parseDuration(l.SplitWindow); err != nil {
+		return fmt.Errorf("invalid lists split_window '%s': %v", l.SplitWindow, err)
+	}
+
+	return nil
+}
+
+type manifestFile struct {
+	Name   string `json:"name"`
+	Size   int    `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+type manifestReport struct {
+	Name        string         `json:"name"`
+	CreatedAt   time.Time      `json:"created_at"`
+	PublishedAt *time.Time     `json:"published_at"`
+	URL         string         `json:"url"`
+	Addresses   int            `json:"addresses"`
+	Events      int            `json:"events"`
+	Files       []manifestFile `json:"files"`
+}
+
+type manifest struct {
+	Node      string           `json:"node"`
+	UpdatedAt time.Time        `json:"updated_at"`
+	Lists     []manifestFile   `json:"lists"`
+	Reports   []manifestReport `json:"reports"`
+}
+
+func hashFiles(files []*ReportFile) []manifestFile {
+	hashed := make([]manifestFile, 0, len(files))
+	for _, file := range files {
+		sum := sha256.Sum256(file.Data)
+		hashed = append(hashed, manifestFile{
+			Name:   file.Name,
+			Size:   len(file.Data),
+			SHA256: hex.EncodeToString(sum[:]),
+		})
+	}
+	return hashed
+}
+
+// json list of files with their size and hash, stored in the report ledger
+func manifestFiles(files []*ReportFile) string {
+	data, _ := json.Marshal(hashFiles(files))
+	return string(data)
+}
+
+func (r *Aggregator) blocklistFile(name string, filter *BlocklistFilter, entries []*BlocklistEntry) (*ReportFile, error) {
+	lists := r.conf.Reporter.Lists
+	if entries == nil {
+		var err error
+		if entries, err = GenerateBlocklist(r.db, r.conf.RuleScores(), filter); err != nil {
+			return nil, fmt.Errorf("error generating %s: %v", name, err)
+		}
+	}
+
+	buf := bytes.Buffer{}
+	if err := WriteBlocklist(&buf, lists.Format, entries, defaultSetName); err != nil {
+		return nil, fmt.Errorf("error writing %s: %v", name, err)
+	}
+
+	return &ReportFile{
+		Name: name + blocklistExtensions[lists.Format],
+		Data: buf.Bytes(),
+	}, nil
+}
+
+func (r *Aggregator) listFilter(window time.Duration) *BlocklistFilter {
+	return &BlocklistFilter{
+		EventFilter: EventFilter{
+			Tags:        r.conf.Reporter.Tags,
+			ExcludeTags: r.conf.Reporter.ExcludeTags,
+			Since:       time.Now().Add(-window),
+		},
+		MinEvents: r.conf.Reporter.Lists.MinEvents,
+	}
+}
+
+// splits the entries of the split window by country and rule
+func (r *Aggregator) splitFiles() ([]*ReportFile, error) {
+	lists := r.conf.Reporter.Lists
+	entries, err := GenerateBlocklist(r.db, r.conf.RuleScores(), r.listFilter(lists.splitWindow))
+	if err != nil {
+		return nil, fmt.Errorf("error generating split lists: %v", err)
+	}
+
+	byName := make(map[string][]*BlocklistEntry)
+	for _, entry := range entries {
+		if lists.Countries && entry.CountryCode != "" {
+			name := "country_" + entry.CountryCode
+			byName[name] = append(byName[name], entry)
+		}
+		if lists.Rules {
+			for _, rule := range entry.Rules {
+				name := "rule_" + unsafeFileChars.ReplaceAllString(strings.Replace(rule, "/", "_", -1), "-")
+				byName[name] = append(byName[name], entry)
+			}
+		}
+	}
+
+	names := make([]string, 0, len(byName))
+	for name := range byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	files := make([]*ReportFile, 0, len(names))
+	for _, name := range names {
+		file, err := r.blocklistFile(name, nil, byName[name])
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+func (r *Aggregator) manifestFile(lists []*ReportFile) (*ReportFile, error) {
+	var ledgers []models.Report
+	err := r.db.Where("node_name = ? AND status = ?", r.conf.NodeName, models.ReportPublished).Order("id").Find(&ledgers).Error
+	if err != nil {
+		return nil, fmt.Errorf("error getting published reports: %v", err)
+	}
+
+	// no timestamps here either, the index only changes when the lists or the reports do
+	m := manifest{
+		Node:    r.conf.NodeName,
+		Lists:   hashFiles(lists),
+		Reports: make([]manifestReport, 0, len(ledgers)),
+	}
+
+	for _, ledger := range ledgers {
+		if ledger.PublishedAt != nil && ledger.PublishedAt.After(m.UpdatedAt) {
+			m.UpdatedAt = *ledger.PublishedAt
+		}
+		report := manifestReport{
+			Name:        ledger.Name,
+			CreatedAt:   ledger.CreatedAt,
+			PublishedAt: ledger.PublishedAt,
+			URL:         ledger.URL,
+			Addresses:   ledger.Addresses,
+			Events:      ledger.Events,
+			Files:       make([]manifestFile, 0),
+		}
+		if ledger.Files != "" {
+			if err := json.Unmarshal([]byte(ledger.Files), &report.Files); err != nil {
+				log.Warning("error parsing the files of report %s: %v", ledger.Name, err)
+			}
+		}
+		m.Reports = append(m.Reports, report)
+	}
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReportFile{
+		Name: "index.json",
+		Data: data,
+	}, nil
+}
+
+// latest.csv is the last published report of this node
+func (r *Aggregator) latestFile() (*ReportFile, error) {
+	var ledgers []models.Report
+	err := r.db.Where("node_name = ? AND status = ?", r.conf.NodeName, models.ReportPublished).Order("id DESC").Limit(1).Find(&ledgers).Error
+	if err != nil {
+		return nil, fmt.Errorf("error getting the latest report: %v", err)
+	} else if len(ledgers) == 0 {
+		return nil, nil
+	}
+
+	report, err := GenerateReport(r.db, &ledgers[0])
+	if err != nil {
+		return nil, err
+	}
+
+	buf := bytes.Buffer{}
+	if err = (csvFormat{}).Write(&buf, report); err != nil {
+		return nil, err
+	}
+
+	return &ReportFile{
+		Name: "latest.csv",
+		Data: buf.Bytes(),
+	}, nil
+}
+
+// onLists regenerates the cumulative files from the database and publishes them.
+func (r *Aggregator) onLists() {
+	lists := r.conf.Reporter.Lists
+	files := make([]*ReportFile, 0)
+
+	latest, err := r.latestFile()
+	if err != nil {
+		log.Error("%v", err)
+		return
+	} else if latest != nil {
+		files = append(files, latest)
+	}
+
+	for _, window := range lists.Windows {
+		file, err := r.blocklistFile("blocklist_"+window, r.listFilter(lists.windows[window]), nil)
+		if err != nil {
+			log.Error("%v", err)
+			return
+		}
+		files = append(files, file)
+	}
+
+	if lists.Countries || lists.Rules {
+		split, err := r.splitFiles()
+		if err != nil {
+			log.Error("%v", err)
+			return
+		}
+		files = append(files, split...)
+	}
+
+	index, err := r.manifestFile(files)
+	if err != nil {
+		log.Error("%v", err)
+		return
+	}
+
+	hash := sha256.Sum256(index.Data)
+	if hash == lists.published {
+		log.Debug("lists didn't change")
+		return
+	}
+
+	// the index goes first, so that it is the file linked by the destinations
+	pub := &Publication{
+		Name:    listsName,
+		Node:    r.conf.NodeName,
+		Message: fmt.Sprintf("updating %d lists", len(files)),
+		Files:   append([]*ReportFile{index}, files...),
+	}
+
+	// published again at the next cycle until every destination has them
+	if _, failed, err := r.conf.Reporter.PublishTo(pub, nil); err != nil {
+		log.Error("%v", err)
+	} else if len(failed) == 0 {
+		lists.published = hash
+	}
+}
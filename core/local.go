@@ -0,0 +1,36 @@
+package core
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+)
+
+// LocalDestination saves the reports to a directory, without git.
+type LocalDestination struct {
+	Path string `yaml:"path"`
+	// optional public url the directory is served from
+	HTTP string `yaml:"http"`
+}
+
+func (d *LocalDestination) Init() error {
+	if d.Path == "" {
+		return fmt.Errorf("path is required")
+	}
+	return os.MkdirAll(d.Path, 0755)
+}
+
+func (d *LocalDestination) Publish(pub *Publication) (string, error) {
+	for _, file := range pub.Files {
+		fileName := path.Join(d.Path, file.Name)
+		if err := ioutil.WriteFile(fileName, file.Data, 0644); err != nil {
+			return "", fmt.Errorf("error writing %s: %v", fileName, err)
+		}
+	}
+
+	if d.HTTP != "" {
+		return fileURL(d.HTTP, pub.Files[0].Name), nil
+	}
+	return "file://" + path.Join(d.Path, pub.Files[0].Name), nil
+}
@@ -0,0 +1,135 @@
+package core
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	defaultMastodonMaxLength = 500
+	mastodonMaxMedia         = 4
+)
+
+// Mastodon posts statuses with the access token of an application.
+type Mastodon struct {
+	// base url of the instance, like https://mastodon.social
+	Server      string `yaml:"server"`
+	AccessToken string `yaml:"access_token"`
+	// public, unlisted, private or direct
+	Visibility  string `yaml:"visibility"`
+	TimeoutSecs int    `yaml:"timeout"`
+
+	client *http.Client
+	// media ids of the charts of the last notification, reused when retrying it
+	mediaOf string
+	media   []string
+}
+
+type mastodonStatus struct {
+	ID string `json:"id"`
+}
+
+type mastodonMedia struct {
+	ID string `json:"id"`
+}
+
+func (m *Mastodon) Init() error {
+	if m.Server == "" || m.AccessToken == "" {
+		return fmt.Errorf("server and access_token are required")
+	}
+	if m.Visibility == "" {
+		m.Visibility = "public"
+	}
+	m.client = newNotifierClient(m.TimeoutSecs)
+	return nil
+}
+
+func (m *Mastodon) DefaultTemplate() string {
+	return defaultReportTemplate + defaultHashtags
+}
+
+// the default of most instances, use max_length for others
+func (m *Mastodon) MaxLength() int {
+	return defaultMastodonMaxLength
+}
+
+func (m *Mastodon) Length(text string) int {
+	return mastodonLength(text)
+}
+
+// upload uploads the charts of a notification and returns their media ids, statuses have at most
+// four. Retries of the same notification only upload the charts that weren't uploaded yet.
+func (m *Mastodon) upload(n *Notification) ([]string, error) {
+	if m.mediaOf != n.ID {
+		m.mediaOf = n.ID
+		m.media = make([]string, 0)
+	}
+
+	charts := n.Charts
+	if len(charts) > mastodonMaxMedia {
+		charts = charts[:mastodonMaxMedia]
+	}
+	for _, chart := range charts[len(m.media):] {
+		media := mastodonMedia{}
+		err := sendFile(m.client, strings.TrimSuffix(m.Server, "/")+"/api/v2/media", "file", chart.FileName(), chart.ContentType, chart.Data, map[string]string{
+			"description": chart.Title,
+		}, map[string]string{
+			"Authorization": "Bearer " + m.AccessToken,
+		}, &media)
+		if err != nil {
+			return nil, fmt.Errorf("error uploading %s: %v", chart.FileName(), err)
+		}
+		m.media = append(m.media, media.ID)
+	}
+	return m.media, nil
+}
+
+func (m *Mastodon) post(status string, inReplyTo string, idempotencyKey string, mediaIDs []string) (string, error) {
+	payload := map[string]interface{}{
+		"status":     status,
+		"visibility": m.Visibility,
+	}
+	if inReplyTo != "" {
+		payload["in_reply_to_id"] = inReplyTo
+	}
+	if len(mediaIDs) > 0 {
+		payload["media_ids"] = mediaIDs
+	}
+
+	posted := mastodonStatus{}
+	err := sendJSON(m.client, http.MethodPost, strings.TrimSuffix(m.Server, "/")+"/api/v1/statuses", payload, map[string]string{
+		"Authorization": "Bearer " + m.AccessToken,
+		// mastodon ignores the same key for one hour, so retries don't post twice
+		"Idempotency-Key": idempotencyKey,
+	}, &posted)
+	return posted.ID, err
+}
+
+func (m *Mastodon) Notify(n *Notification) error {
+	mediaIDs, err := m.upload(n)
+	if err != nil {
+		return err
+	}
+	_, err = m.post(n.Text, "", n.ID, mediaIDs)
+	return err
+}
+
+// the charts are attached to the first status of the thread
+func (m *Mastodon) NotifyThread(n *Notification, t *thread) error {
+	for !t.done() {
+		var mediaIDs []string
+		if t.next() == 0 {
+			var err error
+			if mediaIDs, err = m.upload(n); err != nil {
+				return err
+			}
+		}
+		id, err := m.post(t.parts[t.next()], t.inReplyTo(), fmt.Sprintf("%s-%d", n.ID, t.next()), mediaIDs)
+		if err != nil {
+			return err
+		}
+		t.posted(id)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package core
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Matrix sends messages to a room the user of the access token already joined.
+type Matrix struct {
+	// base url of the homeserver, like https://matrix.org
+	Homeserver  string `yaml:"homeserver"`
+	AccessToken string `yaml:"access_token"`
+	// room id, like !abcdefg:matrix.org
+	Room        string `yaml:"room"`
+	TimeoutSecs int    `yaml:"timeout"`
+
+	client *http.Client
+	// content uris of the charts of the last notification, reused when retrying it
+	uploadsOf string
+	uploads   map[string]string
+}
+
+func (m *Matrix) Init() error {
+	if m.Homeserver == "" || m.AccessToken == "" || m.Room == "" {
+		return fmt.Errorf("homeserver, access_token and room are required")
+	}
+	m.client = newNotifierClient(m.TimeoutSecs)
+	return nil
+}
+
+type matrixUpload struct {
+	ContentURI string `json:"content_uri"`
+}
+
+func (m *Matrix) send(txnID string, message interface{}) error {
+	// the transaction id makes retries of the same notification idempotent
+	endpoint := fmt.Sprintf("%s/_matrix/client/r0/rooms/%s/send/m.room.message/%s",
+		strings.TrimSuffix(m.Homeserver, "/"),
+		url.PathEscape(m.Room),
+		url.PathEscape(txnID))
+
+	return sendJSON(m.client, http.MethodPut, endpoint, message, map[string]string{
+		"Authorization": "Bearer " + m.AccessToken,
+	}, nil)
+}
+
+// sendImage uploads a chart to the media repository, unless it was already uploaded by a
+// previous attempt, and sends it as an image
+func (m *Matrix) sendImage(txnID string, chart *Chart) error {
+	contentURI, found := m.uploads[txnID]
+	if !found {
+		endpoint := fmt.Sprintf("%s/_matrix/media/r0/upload?filename=%s",
+			strings.TrimSuffix(m.Homeserver, "/"),
+			url.QueryEscape(chart.FileName()))
+
+		uploaded := matrixUpload{}
+		err := sendBody(m.client, http.MethodPost, endpoint, chart.ContentType, chart.Data, map[string]string{
+			"Authorization": "Bearer " + m.AccessToken,
+		}, &uploaded)
+		if err != nil {
+			return fmt.Errorf("error uploading %s: %v", chart.FileName(), err)
+		}
+		contentURI = uploaded.ContentURI
+		m.uploads[txnID] = contentURI
+	}
+
+	return m.send(txnID, map[string]interface{}{
+		"msgtype": "m.image",
+		"body":    chart.Title,
+		"url":     contentURI,
+		"info": map[string]interface{}{
+			"mimetype": chart.ContentType,
+			"size":     len(chart.Data),
+		},
+	})
+}
+
+func (m *Matrix) Notify(n *Notification) error {
+	err := m.send(n.ID, map[string]string{
+		"msgtype": "m.text",
+		"body":    n.Text,
+	})
+	if err != nil {
+		return err
+	}
+
+	if m.uploadsOf != n.ID {
+		m.uploadsOf = n.ID
+		m.uploads = make(map[string]string)
+	}
+	for _, chart := range n.Charts {
+		if err = m.sendImage(n.ID+"-"+chart.Name, chart); err != nil {
+			return err
+		}
+	}
+	return nil
+}
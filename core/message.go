@@ -0,0 +1,304 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/enescakir/emoji"
+)
+
+const (
+	defaultReportTemplate = `{{.Events}} new event{{plural .Events}} from ` +
+		`{{range $i, $c := top 5 .Countries}}{{if $i}}, {{end}}{{flag $c.Name}}  ({{$c.Count}}){{end}}` +
+		`{{if gt (len .Countries) 5}}, ...{{end}} {{.URL}}`
+	defaultSubjectTemplate = `[takuan] {{.Node}}: {{.Events}} new event{{plural .Events}}`
+	defaultAlertTemplate   = `{{with .Severity}}{{.}} {{end}}alert, ` +
+		`{{if .Address}}{{.Address}} {{flag .CountryCode}} matched {{.Rule}}` +
+		`{{if gt .Events 1}} {{.Events}} times{{end}}{{if .Suppressed}} (+{{.Suppressed}} more){{end}}` +
+		`{{else}}{{.Suppressed}} more addresses matched {{.Rule}}{{end}}` +
+		`{{if .Description}}: {{.Description}}{{end}}`
+	defaultAlertSubjectTemplate = `[takuan] {{.Node}}: {{.Severity}} alert {{.Rule}}`
+	defaultDigestTemplate       = `{{.Name}} digest: {{.Events}} events ({{trend .Events .Previous.Events}}) from ` +
+		`{{.Addresses}} addresses, {{.New}} new. Top rules: ` +
+		`{{range $i, $c := top 3 .Rules}}{{if $i}}, {{end}}{{$c.Name}} ({{$c.Count}}){{end}} {{.URL}}`
+	defaultDigestSubjectTemplate = `[takuan] {{.Node}}: {{.Name}} digest of {{.Start.Format "2006-01-02"}}`
+	// appended to the default template of social networks
+	defaultHashtags = ` #takuan #threatreport`
+
+	ellipsis = "…"
+	// twitter and mastodon count every link as this many characters
+	shortURLLength = 23
+)
+
+var (
+	urlPattern = regexp.MustCompile(`https?://\S+`)
+	// links and hashtags at the end of a message
+	trailingPattern = regexp.MustCompile(`(\s+(https?://\S+|#\w+))+\s*$`)
+
+	templateFuncs = template.FuncMap{
+		"flag":   countryFlag,
+		"plural": plural,
+		"top":    top,
+		"trend":  trend,
+	}
+)
+
+// StatCounter is the number of events of a country, rule or ASN.
+type StatCounter struct {
+	Name        string
+	Description string
+	Count       int
+}
+
+// ReportStats are the data available to the notification templates.
+type ReportStats struct {
+	Node      string
+	Name      string
+	URL       string
+	CreatedAt time.Time
+	Addresses int
+	Events    int
+	// addresses never reported before and addresses already in previous reports
+	New    int
+	Repeat int
+	// sorted by number of events
+	Countries []StatCounter
+	Rules     []StatCounter
+	ASNs      []StatCounter
+}
+
+func sortedCounters(counts map[string]int, descriptions map[string]string) []StatCounter {
+	counters := make([]StatCounter, 0, len(counts))
+	for name, count := range counts {
+		counters = append(counters, StatCounter{
+			Name:        name,
+			Description: descriptions[name],
+			Count:       count,
+		})
+	}
+	sort.Slice(counters, func(i, j int) bool {
+		if counters[i].Count != counters[j].Count {
+			return counters[i].Count > counters[j].Count
+		}
+		return counters[i].Name < counters[j].Name
+	})
+	return counters
+}
+
+// NewReportStats summarizes a report, repeat is the number of its addresses that were already reported.
+func NewReportStats(report *Report, reportURL string, repeat int) *ReportStats {
+	countries := make(map[string]int)
+	countryNames := make(map[string]string)
+	rules := make(map[string]int)
+	for _, entry := range report.Entries {
+		countries[entry.CountryCode] += entry.Events
+		countryNames[entry.CountryCode] = entry.CountryName
+		for rule, count := range entry.Rules {
+			rules[rule] += count
+		}
+	}
+
+	asns := make(map[string]int)
+	asnOrgs := make(map[string]string)
+	for _, asn := range report.ASNs {
+		if asn.ASN != 0 {
+			asns[asnString(asn.ASN)] = asn.Events
+			asnOrgs[asnString(asn.ASN)] = asn.Org
+		}
+	}
+
+	return &ReportStats{
+		Node:      report.Node,
+		Name:      report.Name,
+		URL:       reportURL,
+		CreatedAt: report.CreatedAt,
+		Addresses: len(report.Entries),
+		Events:    report.Events,
+		New:       len(report.Entries) - repeat,
+		Repeat:    repeat,
+		Countries: sortedCounters(countries, countryNames),
+		Rules:     sortedCounters(rules, nil),
+		ASNs:      sortedCounters(asns, asnOrgs),
+	}
+}
+
+func countryFlag(code string) string {
+	if flag, err := emoji.CountryFlag(code); err == nil {
+		return string(flag)
+	}
+	return code
+}
+
+func plural(n int) string {
+	if n == 1 {
+		return ""
+	}
+	return "s"
+}
+
+func top(n int, counters []StatCounter) []StatCounter {
+	if len(counters) > n {
+		return counters[:n]
+	}
+	return counters
+}
+
+// percent change from the previous value, like +12%
+func trend(current int, previous int) string {
+	if previous == 0 {
+		return "n/a"
+	}
+	return fmt.Sprintf("%+.0f%%", float64(current-previous)*100/float64(previous))
+}
+
+func parseTemplate(name string, text string) (*template.Template, error) {
+	tmpl, err := template.New(name).Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing %s template: %v", name, err)
+	}
+	return tmpl, nil
+}
+
+func renderTemplate(tmpl *template.Template, data interface{}) (string, error) {
+	buf := bytes.Buffer{}
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// runeLength counts characters, used by the platforms without link shortening
+func runeLength(text string) int {
+	return utf8.RuneCountInString(text)
+}
+
+// mastodonLength counts links as 23 characters, whatever their length
+func mastodonLength(text string) int {
+	length := 0
+	for _, part := range splitLinks(text) {
+		if urlPattern.MatchString(part) {
+			length += shortURLLength
+		} else {
+			length += runeLength(part)
+		}
+	}
+	return length
+}
+
+// twitterLength counts links as 23 characters and most characters outside
+// of the latin ranges, like emojis and CJK, as two
+func twitterLength(text string) int {
+	length := 0
+	for _, part := range splitLinks(text) {
+		if urlPattern.MatchString(part) {
+			length += shortURLLength
+			continue
+		}
+		for _, r := range part {
+			if (r >= 0 && r <= 4351) || (r >= 8192 && r <= 8205) || (r >= 8208 && r <= 8223) || (r >= 8242 && r <= 8247) {
+				length++
+			} else {
+				length += 2
+			}
+		}
+	}
+	return length
+}
+
+// splits text in links and what's in between them
+func splitLinks(text string) []string {
+	parts := make([]string, 0)
+	last := 0
+	for _, loc := range urlPattern.FindAllStringIndex(text, -1) {
+		parts = append(parts, text[last:loc[0]], text[loc[0]:loc[1]])
+		last = loc[1]
+	}
+	return append(parts, text[last:])
+}
+
+// truncate shortens text to fit max, preserving the links and hashtags at its end
+func truncate(text string, max int, length func(string) int) string {
+	if length(text) <= max {
+		return text
+	}
+
+	head, tail := text, ""
+	if loc := trailingPattern.FindStringIndex(text); loc != nil && loc[0] > 0 {
+		head, tail = text[:loc[0]], strings.TrimSpace(text[loc[0]:])
+		if length(tail)+2 > max {
+			// there's not even room for them
+			head, tail = text, ""
+		}
+	}
+
+	fits := func(head string) (string, bool) {
+		candidate := strings.TrimRightFunc(head, unicode.IsSpace) + ellipsis
+		if tail != "" {
+			candidate += " " + tail
+		}
+		return candidate, length(candidate) <= max
+	}
+
+	// cut whole words first, so that emojis and links are never broken
+	for cut := strings.LastIndexFunc(head, unicode.IsSpace); cut > 0; cut = strings.LastIndexFunc(head, unicode.IsSpace) {
+		head = strings.TrimRightFunc(head[:cut], unicode.IsSpace)
+		if candidate, ok := fits(head); ok {
+			return candidate
+		}
+	}
+
+	runes := []rune(head)
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		if candidate, ok := fits(string(runes)); ok {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// split breaks text on word boundaries in parts that fit max once
+// numbered, each part ends with " i/n"
+func split(text string, max int, length func(string) int) []string {
+	words := strings.Fields(text)
+	// the numbering grows with the parts, retry with a longer one if needed
+	for digits := 1; digits <= 3; digits++ {
+		suffix := 2*digits + 2
+		chunks := make([]string, 0)
+		current := ""
+		for _, word := range words {
+			if length(word)+suffix > max {
+				return []string{truncate(text, max, length)}
+			}
+
+			candidate := word
+			if current != "" {
+				candidate = current + " " + word
+			}
+			if length(candidate)+suffix <= max {
+				current = candidate
+			} else {
+				chunks = append(chunks, current)
+				current = word
+			}
+		}
+		if current != "" {
+			chunks = append(chunks, current)
+		}
+
+		if len(fmt.Sprintf("%d", len(chunks))) <= digits {
+			for i := range chunks {
+				chunks[i] += fmt.Sprintf(" %d/%d", i+1, len(chunks))
+			}
+			return chunks
+		}
+	}
+	return []string{truncate(text, max, length)}
+}
@@ -0,0 +1,123 @@
+package core
+
+import (
+	"errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	metricLinesRead = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "takuan_sensor_lines_total",
+		Help: "Number of log lines read by each sensor.",
+	}, []string{"sensor"})
+
+	metricParsed = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "takuan_parser_lines_total",
+		Help: "Number of log lines matched or missed by the parser of each sensor.",
+	}, []string{"sensor", "result"})
+
+	metricRuleHits = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "takuan_rule_hits_total",
+		Help: "Number of events generated by each rule.",
+	}, []string{"sensor", "rule"})
+
+	metricSensorLag = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "takuan_sensor_lag_bytes",
+		Help: "Bytes of the log file not yet processed by each sensor, sampled before each read.",
+	}, []string{"sensor"})
+
+	metricBuffered = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "takuan_buffered_events",
+		Help: "Number of events waiting to be saved to the database.",
+	})
+
+	metricFlushDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "takuan_flush_duration_seconds",
+		Help:    "Time spent saving buffered events to the database.",
+		Buckets: prometheus.DefBuckets,
+	})
+
+	metricFlushFailures = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "takuan_flush_failures_total",
+		Help: "Number of events that could not be saved to the database.",
+	})
+
+	metricReportDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "takuan_report_duration_seconds",
+		Help:    "Time spent generating and publishing reports.",
+		Buckets: []float64{.1, .5, 1, 5, 10, 30, 60, 120, 300},
+	})
+
+	metricReportFailures = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "takuan_report_failures_total",
+		Help: "Number of reports that failed.",
+	})
+
+	metricPublishFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "takuan_report_publish_failures_total",
+		Help: "Number of reports that could not be published to each destination.",
+	}, []string{"destination"})
+
+	metricNotifyFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "takuan_notification_failures_total",
+		Help: "Number of notifications that could not be sent by each notifier.",
+	}, []string{"notifier"})
+
+	metricAlerts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "takuan_alerts_total",
+		Help: "Number of real-time alerts by rule, sent, suppressed by the rule cooldown or dropped.",
+	}, []string{"rule", "result"})
+
+	metricErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "takuan_errors_total",
+		Help: "Number of errors received on the error bus by type.",
+	}, []string{"type"})
+
+	metricGeoIPFailures = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "takuan_geoip_lookup_failures_total",
+		Help: "Number of failed GeoIP lookups.",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(
+		metricLinesRead,
+		metricParsed,
+		metricRuleHits,
+		metricSensorLag,
+		metricBuffered,
+		metricFlushDuration,
+		metricFlushFailures,
+		metricReportDuration,
+		metricReportFailures,
+		metricPublishFailures,
+		metricNotifyFailures,
+		metricAlerts,
+		metricErrors,
+		metricGeoIPFailures,
+	)
+}
+
+// errors sent on the error bus by sensors carry their type
+type SensorError struct {
+	Sensor string
+	Type   string
+	Err    error
+}
+
+func (e *SensorError) Error() string {
+	return e.Sensor + ": " + e.Err.Error()
+}
+
+func (e *SensorError) Unwrap() error {
+	return e.Err
+}
+
+func errorType(err error) string {
+	var sensorErr *SensorError
+	if errors.As(err, &sensorErr) {
+		return sensorErr.Type
+	}
+	return "other"
+}
@@ -0,0 +1,444 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	neturl "net/url"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/evilsocket/islazy/log"
+)
+
+const (
+	defaultNotifierRetries     = 3
+	defaultNotifierBackoffSecs = 2
+	defaultNotifierTimeoutSecs = 30
+	notifierQueueSize          = 100
+)
+
+// Notification is a message sent to every enabled notifier, each one renders
+// its own Subject and Text from the templates.
+type Notification struct {
+	// unique, platforms supporting idempotent requests use it to not post twice when retrying
+	ID   string
+	Node string
+	URL  string
+	// either the stats of a report, an alert or a digest
+	Stats  *ReportStats
+	Report *Report
+	Alert  *Alert
+	Digest *DigestStats
+	// png charts, attached by the notifiers with charts enabled
+	Charts []*Chart
+	// logged instead of sent by every notifier, like the ones of the reports of a dry run
+	DryRun bool
+
+	Subject string
+	Text    string
+}
+
+// NewReportNotification describes a published report, repeat is the number of its addresses that were already reported.
+func NewReportNotification(report *Report, reportURL string, repeat int) *Notification {
+	return &Notification{
+		ID:     fmt.Sprintf("%s-%s", report.Node, report.Name),
+		Node:   report.Node,
+		URL:    reportURL,
+		Stats:  NewReportStats(report, reportURL, repeat),
+		Report: report,
+	}
+}
+
+// Notifier sends a notification to a chat, social network or mailbox.
+type Notifier interface {
+	Init() error
+	Notify(n *Notification) error
+}
+
+// notifiers with a maximum message length implement this
+type limitedNotifier interface {
+	MaxLength() int
+	// how the platform counts the length of a message
+	Length(text string) int
+}
+
+// notifiers able to post a long message as a thread of replies implement this
+type threadNotifier interface {
+	NotifyThread(n *Notification, t *thread) error
+}
+
+// thread is a long message being posted as replies, retries resume it from the first
+// part that wasn't posted instead of posting the others again
+type thread struct {
+	parts []string
+	// ids of the statuses of the posted parts
+	ids []string
+}
+
+func (t *thread) done() bool {
+	return len(t.ids) == len(t.parts)
+}
+
+// next returns the index of the next part to post
+func (t *thread) next() int {
+	return len(t.ids)
+}
+
+// inReplyTo returns the id of the last posted part, or an empty string if none was posted
+func (t *thread) inReplyTo() string {
+	if len(t.ids) == 0 {
+		return ""
+	}
+	return t.ids[len(t.ids)-1]
+}
+
+func (t *thread) posted(id string) {
+	t.ids = append(t.ids, id)
+}
+
+// notifiers with a default template other than defaultReportTemplate implement this
+type templatedNotifier interface {
+	DefaultTemplate() string
+}
+
+// ReportNotifier wraps exactly one of the supported notifiers.
+type ReportNotifier struct {
+	sync.Mutex
+
+	Name    string `yaml:"name"`
+	Enabled bool   `yaml:"enabled"`
+	// log the rendered messages instead of sending them
+	DryRun bool `yaml:"dry_run"`
+	// attach the charts, if enabled, on mastodon, matrix, slack and email
+	Charts bool `yaml:"charts"`
+	// attempts and initial delay between them, doubled at each attempt
+	Retries     int `yaml:"retries"`
+	BackoffSecs int `yaml:"backoff"`
+	// go text/templates of the message and of the subject used by email and webhook,
+	// executed on ReportStats
+	Template string `yaml:"template"`
+	Subject  string `yaml:"subject"`
+	// the same for real-time alerts, executed on Alert
+	AlertTemplate string `yaml:"alert_template"`
+	AlertSubject  string `yaml:"alert_subject"`
+	// and for digests, executed on DigestStats
+	DigestTemplate string `yaml:"digest_template"`
+	DigestSubject  string `yaml:"digest_subject"`
+	// overrides the maximum length of the platform, longer messages are truncated
+	MaxLength int `yaml:"max_length"`
+	// post long messages as a thread instead of truncating them, if supported
+	Thread bool `yaml:"thread"`
+
+	Twitter  *Twitter         `yaml:"twitter"`
+	Mastodon *Mastodon        `yaml:"mastodon"`
+	Matrix   *Matrix          `yaml:"matrix"`
+	Slack    *SlackWebhook    `yaml:"slack"`
+	Discord  *DiscordWebhook  `yaml:"discord"`
+	Webhook  *WebhookNotifier `yaml:"webhook"`
+	Email    *Email           `yaml:"email"`
+
+	impl          Notifier
+	text          *template.Template
+	subject       *template.Template
+	alertText     *template.Template
+	alertSubject  *template.Template
+	digestText    *template.Template
+	digestSubject *template.Template
+	queue         chan *Notification
+}
+
+func (n *ReportNotifier) Init() error {
+	if n.Retries <= 0 {
+		n.Retries = defaultNotifierRetries
+	}
+	if n.BackoffSecs <= 0 {
+		n.BackoffSecs = defaultNotifierBackoffSecs
+	}
+
+	impls := make([]Notifier, 0)
+	if n.Twitter != nil {
+		impls = append(impls, n.Twitter)
+	}
+	if n.Mastodon != nil {
+		impls = append(impls, n.Mastodon)
+	}
+	if n.Matrix != nil {
+		impls = append(impls, n.Matrix)
+	}
+	if n.Slack != nil {
+		impls = append(impls, n.Slack)
+	}
+	if n.Discord != nil {
+		impls = append(impls, n.Discord)
+	}
+	if n.Webhook != nil {
+		impls = append(impls, n.Webhook)
+	}
+	if n.Email != nil {
+		impls = append(impls, n.Email)
+	}
+
+	if len(impls) != 1 {
+		return fmt.Errorf("notifier '%s' must configure exactly one of twitter, mastodon, matrix, slack, discord, webhook or email", n.Name)
+	}
+
+	n.impl = impls[0]
+	if err := n.impl.Init(); err != nil {
+		return fmt.Errorf("error initializing notifier '%s': %v", n.Name, err)
+	}
+
+	if n.Template == "" {
+		n.Template = defaultReportTemplate
+		if t, ok := n.impl.(templatedNotifier); ok {
+			n.Template = t.DefaultTemplate()
+		}
+	}
+	if n.Subject == "" {
+		n.Subject = defaultSubjectTemplate
+	}
+	if n.AlertTemplate == "" {
+		n.AlertTemplate = defaultAlertTemplate
+	}
+	if n.AlertSubject == "" {
+		n.AlertSubject = defaultAlertSubjectTemplate
+	}
+	if n.DigestTemplate == "" {
+		n.DigestTemplate = defaultDigestTemplate
+	}
+	if n.DigestSubject == "" {
+		n.DigestSubject = defaultDigestSubjectTemplate
+	}
+
+	var err error
+	if n.text, err = parseTemplate(n.Name, n.Template); err != nil {
+		return err
+	} else if n.subject, err = parseTemplate(n.Name+" subject", n.Subject); err != nil {
+		return err
+	} else if n.alertText, err = parseTemplate(n.Name+" alert", n.AlertTemplate); err != nil {
+		return err
+	} else if n.alertSubject, err = parseTemplate(n.Name+" alert subject", n.AlertSubject); err != nil {
+		return err
+	} else if n.digestText, err = parseTemplate(n.Name+" digest", n.DigestTemplate); err != nil {
+		return err
+	} else if n.digestSubject, err = parseTemplate(n.Name+" digest subject", n.DigestSubject); err != nil {
+		return err
+	}
+
+	n.queue = make(chan *Notification, notifierQueueSize)
+
+	// catch references to unknown fields now rather than at the first report
+	if _, _, err = n.render(&Notification{Stats: &ReportStats{}}); err != nil {
+		return fmt.Errorf("error rendering %s template: %v", n.Name, err)
+	} else if _, _, err = n.render(&Notification{Alert: &Alert{}}); err != nil {
+		return fmt.Errorf("error rendering %s alert template: %v", n.Name, err)
+	} else if _, _, err = n.render(&Notification{Digest: &DigestStats{}}); err != nil {
+		return fmt.Errorf("error rendering %s digest template: %v", n.Name, err)
+	}
+
+	return nil
+}
+
+// render executes the templates and fits the text to the length limit of the
+// platform, by truncating it or by splitting it in the parts of a thread
+func (n *ReportNotifier) render(notification *Notification) (msg *Notification, parts []string, err error) {
+	rendered := *notification
+	msg = &rendered
+	if !n.Charts {
+		msg.Charts = nil
+	}
+
+	text, subject, data := n.text, n.subject, interface{}(notification.Stats)
+	if notification.Alert != nil {
+		text, subject, data = n.alertText, n.alertSubject, notification.Alert
+	} else if notification.Digest != nil {
+		text, subject, data = n.digestText, n.digestSubject, notification.Digest
+	}
+
+	if msg.Subject, err = renderTemplate(subject, data); err != nil {
+		return nil, nil, err
+	} else if msg.Text, err = renderTemplate(text, data); err != nil {
+		return nil, nil, err
+	}
+
+	max, length := n.MaxLength, runeLength
+	if limited, ok := n.impl.(limitedNotifier); ok {
+		if max <= 0 {
+			max = limited.MaxLength()
+		}
+		length = limited.Length
+	}
+
+	if max > 0 && length(msg.Text) > max {
+		if _, ok := n.impl.(threadNotifier); ok && n.Thread {
+			parts = split(msg.Text, max, length)
+		} else {
+			log.Debug("notification %s for %s is too long, truncating it to %d characters", msg.ID, n.Name, max)
+			msg.Text = truncate(msg.Text, max, length)
+		}
+	}
+
+	return msg, parts, nil
+}
+
+func (n *ReportNotifier) Notify(notification *Notification) (err error) {
+	n.Lock()
+	defer n.Unlock()
+
+	msg, parts, err := n.render(notification)
+	if err != nil {
+		metricNotifyFailures.WithLabelValues(n.Name).Inc()
+		return fmt.Errorf("error rendering notification %s for %s: %v", notification.ID, n.Name, err)
+	}
+
+	if n.DryRun || notification.DryRun {
+		if len(parts) == 0 {
+			parts = []string{msg.Text}
+		}
+		log.Info("notifier %s (dry run): %s", n.Name, msg.Subject)
+		for _, part := range parts {
+			log.Info("notifier %s (dry run): %s", n.Name, part)
+		}
+		for _, chart := range msg.Charts {
+			log.Info("notifier %s (dry run): attaching %s (%d bytes)", n.Name, chart.Title, len(chart.Data))
+		}
+		return nil
+	}
+
+	posting := &thread{parts: parts}
+	backoff := time.Duration(n.BackoffSecs) * time.Second
+	for attempt := 1; attempt <= n.Retries; attempt++ {
+		if attempt > 1 {
+			log.Warning("notifier %s failed (%v), retrying in %s ...", n.Name, err, backoff)
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+
+		if len(parts) > 1 {
+			err = n.impl.(threadNotifier).NotifyThread(msg, posting)
+		} else {
+			err = n.impl.Notify(msg)
+		}
+		if err == nil {
+			log.Info("notification %s sent to %s", notification.ID, n.Name)
+			return nil
+		}
+	}
+
+	metricNotifyFailures.WithLabelValues(n.Name).Inc()
+	return fmt.Errorf("error sending notification %s to %s: %v", notification.ID, n.Name, err)
+}
+
+// Start sends the queued notifications in order.
+func (n *ReportNotifier) Start() {
+	go func() {
+		for notification := range n.queue {
+			if err := n.Notify(notification); err != nil {
+				log.Error("%v", err)
+			}
+		}
+	}()
+}
+
+func (n *ReportNotifier) enqueue(notification *Notification) bool {
+	select {
+	case n.queue <- notification:
+		return true
+	default:
+		log.Warning("notifier %s queue is full, dropping notification %s", n.Name, notification.ID)
+		metricNotifyFailures.WithLabelValues(n.Name).Inc()
+		return false
+	}
+}
+
+// Notify queues a notification for every enabled notifier, each one retries with a backoff
+// from its own queue so that an unreachable notifier delays neither the others nor the reports.
+func (c *Config) Notify(n *Notification) {
+	for _, notifier := range c.Notifiers {
+		if notifier.Enabled {
+			notifier.enqueue(n)
+		}
+	}
+}
+
+func newNotifierClient(timeoutSecs int) *http.Client {
+	if timeoutSecs <= 0 {
+		timeoutSecs = defaultNotifierTimeoutSecs
+	}
+	return &http.Client{Timeout: time.Duration(timeoutSecs) * time.Second}
+}
+
+// sends payload as json, or as is if already encoded, fails on any non 2xx
+// status and decodes the response in result, if not nil
+func sendJSON(client *http.Client, method string, url string, payload interface{}, headers map[string]string, result interface{}) error {
+	body, ok := payload.([]byte)
+	if !ok {
+		var err error
+		if body, err = json.Marshal(payload); err != nil {
+			return err
+		}
+	}
+	return sendBody(client, method, url, "application/json", body, headers, result)
+}
+
+// sends a file as a multipart form with the given fields
+func sendFile(client *http.Client, url string, field string, fileName string, contentType string, data []byte, fields map[string]string, headers map[string]string, result interface{}) error {
+	buf := bytes.Buffer{}
+	w := multipart.NewWriter(&buf)
+	for name, value := range fields {
+		if err := w.WriteField(name, value); err != nil {
+			return err
+		}
+	}
+
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`, field, fileName))
+	header.Set("Content-Type", contentType)
+	part, err := w.CreatePart(header)
+	if err != nil {
+		return err
+	} else if _, err = part.Write(data); err != nil {
+		return err
+	} else if err = w.Close(); err != nil {
+		return err
+	}
+
+	return sendBody(client, http.MethodPost, url, w.FormDataContentType(), buf.Bytes(), headers, result)
+}
+
+func sendBody(client *http.Client, method string, url string, contentType string, body []byte, headers map[string]string, result interface{}) error {
+	req, err := http.NewRequest(method, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", contentType)
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	// the path of incoming webhooks is a secret, don't log it
+	resp, err := client.Do(req)
+	if err != nil {
+		if urlErr, ok := err.(*neturl.Error); ok {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("%s %s: %v", method, req.URL.Host, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		data, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s %s: %s (%s)", method, req.URL.Host, resp.Status, strings.TrimSpace(string(data)))
+	} else if result != nil {
+		return json.NewDecoder(resp.Body).Decode(result)
+	}
+	return nil
+}
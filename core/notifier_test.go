@@ -0,0 +1,453 @@
+package core
+
+import (
+	"bufio"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type recordedRequest struct {
+	Method string
+	Path   string
+	Header http.Header
+	Body   []byte
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	requests := make([]recordedRequest, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		requests = append(requests, recordedRequest{r.Method, r.URL.RequestURI(), r.Header, body})
+	}))
+	defer server.Close()
+
+	n := &ReportNotifier{
+		Name:     "test",
+		Enabled:  true,
+		Template: "{{.Events}} events from {{.Addresses}} addresses {{.URL}}",
+		Subject:  "report {{.Name}}",
+		Webhook:  &WebhookNotifier{URL: server.URL + "/hook", Secret: "s3cr3t"},
+	}
+	if err := n.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	report := NewReport("node", time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC))
+	report.Add(&ReportRow{Address: "1.2.3.4", Sensor: "ssh", Rule: "auth", Events: 3, CountryCode: "IT", CountryName: "Italy"})
+	report.Add(&ReportRow{Address: "5.6.7.8", Sensor: "ssh", Rule: "auth", Events: 2})
+	report.Finish()
+	if err := n.Notify(NewReportNotification(report, "https://example.com/report.csv", 1)); err != nil {
+		t.Fatal(err)
+	} else if len(requests) != 1 {
+		t.Fatalf("expected one request, got %d", len(requests))
+	}
+	req := requests[0]
+
+	mac := hmac.New(sha256.New, []byte("s3cr3t"))
+	mac.Write(req.Body)
+	if expected := "sha256=" + hex.EncodeToString(mac.Sum(nil)); req.Header.Get("X-Takuan-Signature") != expected {
+		t.Fatalf("expected signature %s, got %s", expected, req.Header.Get("X-Takuan-Signature"))
+	} else if req.Header.Get("Content-Type") != "application/json" {
+		t.Fatalf("unexpected content type %s", req.Header.Get("Content-Type"))
+	}
+
+	payload := webhookNotification{}
+	if err := json.Unmarshal(req.Body, &payload); err != nil {
+		t.Fatal(err)
+	} else if payload.ID != "node-report_2026-10-19T10:00:00+0000" || payload.Node != "node" {
+		t.Fatalf("unexpected id or node: %+v", payload)
+	} else if payload.Subject != "report report_2026-10-19T10:00:00+0000" {
+		t.Fatalf("unexpected subject: %s", payload.Subject)
+	} else if payload.Text != "5 events from 2 addresses https://example.com/report.csv" {
+		t.Fatalf("unexpected text: %s", payload.Text)
+	} else if payload.Report == nil || payload.Report.Addresses != 2 || payload.Report.Events != 5 {
+		t.Fatalf("unexpected report: %+v", payload.Report)
+	} else if payload.Alert != nil || payload.Digest != nil {
+		t.Fatalf("unexpected alert or digest: %+v", payload)
+	}
+}
+
+func TestNotifierRetries(t *testing.T) {
+	for _, test := range []struct {
+		retries  int
+		failures []int
+		attempts int
+		fails    bool
+	}{
+		{3, []int{http.StatusInternalServerError}, 2, false},
+		{3, []int{http.StatusBadGateway, http.StatusServiceUnavailable}, 3, false},
+		// gives up after the last attempt
+		{2, []int{http.StatusBadGateway, http.StatusBadGateway}, 2, true},
+		{1, []int{http.StatusBadGateway}, 1, true},
+	} {
+		attempts := 0
+		failures := test.failures
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if attempts++; len(failures) > 0 {
+				http.Error(w, "nope", failures[0])
+				failures = failures[1:]
+			}
+		}))
+
+		n := &ReportNotifier{
+			Name:        "test",
+			Enabled:     true,
+			Retries:     test.retries,
+			BackoffSecs: 1,
+			Template:    "{{.Events}} events",
+			Webhook:     &WebhookNotifier{URL: server.URL + "/hook"},
+		}
+		if err := n.Init(); err != nil {
+			t.Fatal(err)
+		}
+
+		report := NewReport("node", time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC))
+		report.Finish()
+		err := n.Notify(NewReportNotification(report, "https://example.com/report.csv", 1))
+		server.Close()
+
+		if test.fails != (err != nil) {
+			t.Errorf("unexpected error with %d retries and failures %v: %v", test.retries, test.failures, err)
+		} else if attempts != test.attempts {
+			t.Errorf("expected %d attempts with %d retries and failures %v, got %d", test.attempts, test.retries, test.failures, attempts)
+		}
+	}
+}
+
+func TestMastodonNotifier(t *testing.T) {
+	uploads := make([]recordedRequest, 0)
+	statuses := make([]recordedRequest, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		req := recordedRequest{r.Method, r.URL.RequestURI(), r.Header, body}
+		if strings.HasPrefix(r.URL.Path, "/api/v2/media") {
+			uploads = append(uploads, req)
+		} else if statuses = append(statuses, req); len(statuses) == 1 {
+			// the status is retried with the same idempotency key
+			http.Error(w, "nope", http.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"id": fmt.Sprintf("%d", len(uploads)+len(statuses))})
+	}))
+	defer server.Close()
+
+	n := &ReportNotifier{
+		Name:        "test",
+		Enabled:     true,
+		Charts:      true,
+		BackoffSecs: 1,
+		Template:    "{{.Events}} events from {{.Addresses}} addresses {{.URL}}",
+		Mastodon:    &Mastodon{Server: server.URL + "/", AccessToken: "token"},
+	}
+	if err := n.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	report := NewReport("node", time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC))
+	report.Add(&ReportRow{Address: "1.2.3.4", Sensor: "ssh", Rule: "auth", Events: 3})
+	report.Add(&ReportRow{Address: "5.6.7.8", Sensor: "ssh", Rule: "auth", Events: 2})
+	report.Finish()
+	notification := NewReportNotification(report, "https://example.com/report.csv", 1)
+	notification.Charts = []*Chart{{Name: "timeline", Title: "events per hour", Format: "png", ContentType: "image/png", Data: []byte("png")}}
+	if err := n.Notify(notification); err != nil {
+		t.Fatal(err)
+	}
+
+	// the chart is uploaded once
+	if len(uploads) != 1 {
+		t.Fatalf("expected one upload, got %d", len(uploads))
+	} else if !strings.HasPrefix(uploads[0].Header.Get("Content-Type"), "multipart/form-data") {
+		t.Fatalf("unexpected upload content type %s", uploads[0].Header.Get("Content-Type"))
+	} else if len(statuses) != 2 {
+		t.Fatalf("expected two attempts, got %d", len(statuses))
+	}
+	for _, req := range statuses {
+		if req.Header.Get("Authorization") != "Bearer token" {
+			t.Fatalf("unexpected authorization %s", req.Header.Get("Authorization"))
+		} else if req.Header.Get("Idempotency-Key") != notification.ID {
+			t.Fatalf("unexpected idempotency key %s", req.Header.Get("Idempotency-Key"))
+		}
+	}
+
+	payload := struct {
+		Status     string   `json:"status"`
+		Visibility string   `json:"visibility"`
+		MediaIDs   []string `json:"media_ids"`
+	}{}
+	if err := json.Unmarshal(statuses[1].Body, &payload); err != nil {
+		t.Fatal(err)
+	} else if payload.Status != "5 events from 2 addresses https://example.com/report.csv" || payload.Visibility != "public" {
+		t.Fatalf("unexpected payload %+v", payload)
+	} else if len(payload.MediaIDs) != 1 || payload.MediaIDs[0] != "1" {
+		t.Fatalf("unexpected media ids %v", payload.MediaIDs)
+	}
+}
+
+func TestMastodonThread(t *testing.T) {
+	type status struct {
+		Status    string `json:"status"`
+		InReplyTo string `json:"in_reply_to_id"`
+	}
+	posted := make([]status, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s := status{}
+		json.NewDecoder(r.Body).Decode(&s)
+		// the third part fails once, the thread is resumed from it
+		if posted = append(posted, s); len(posted) == 3 {
+			http.Error(w, "nope", http.StatusBadGateway)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"id": fmt.Sprintf("status-%d", len(posted))})
+	}))
+	defer server.Close()
+
+	n := &ReportNotifier{
+		Name:        "test",
+		Enabled:     true,
+		BackoffSecs: 1,
+		Thread:      true,
+		MaxLength:   30,
+		Template:    "one two three four five six seven eight nine ten eleven twelve thirteen fourteen",
+		Mastodon:    &Mastodon{Server: server.URL, AccessToken: "token"},
+	}
+	if err := n.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	report := NewReport("node", time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC))
+	report.Finish()
+	if err := n.Notify(NewReportNotification(report, "https://example.com/report.csv", 1)); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(posted) < 4 {
+		t.Fatalf("expected at least three parts and a retry, got %+v", posted)
+	} else if posted[0].InReplyTo != "" || !strings.HasSuffix(posted[0].Status, fmt.Sprintf("1/%d", len(posted)-1)) {
+		t.Fatalf("unexpected first part %+v", posted[0])
+	}
+	// the failed attempt and the retry of the third part reply to the second one
+	for _, s := range posted[2:4] {
+		if s.InReplyTo != "status-2" || s.Status != posted[2].Status {
+			t.Fatalf("unexpected retry %+v", posted)
+		}
+	}
+	for _, s := range posted[1:] {
+		if s.Status == posted[0].Status {
+			t.Fatalf("first part posted twice: %+v", posted)
+		}
+	}
+}
+
+func TestMatrixNotifier(t *testing.T) {
+	texts := make([]recordedRequest, 0)
+	uploads := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if r.URL.RequestURI() == "/_matrix/media/r0/upload?filename=timeline.png" {
+			uploads++
+		} else if texts = append(texts, recordedRequest{r.Method, r.URL.RequestURI(), r.Header, body}); len(texts) == 1 {
+			// the text is sent again with the same transaction id
+			http.Error(w, "nope", http.StatusTooManyRequests)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"content_uri": "mxc://example.com/chart", "event_id": "$event"})
+	}))
+	defer server.Close()
+
+	n := &ReportNotifier{
+		Name:        "test",
+		Enabled:     true,
+		Charts:      true,
+		BackoffSecs: 1,
+		Template:    "{{.Events}} events from {{.Addresses}} addresses {{.URL}}",
+		Matrix:      &Matrix{Homeserver: server.URL, AccessToken: "token", Room: "!room:example.com"},
+	}
+	if err := n.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	report := NewReport("node", time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC))
+	report.Add(&ReportRow{Address: "1.2.3.4", Sensor: "ssh", Rule: "auth", Events: 3})
+	report.Add(&ReportRow{Address: "5.6.7.8", Sensor: "ssh", Rule: "auth", Events: 2})
+	report.Finish()
+	notification := NewReportNotification(report, "https://example.com/report.csv", 1)
+	notification.Charts = []*Chart{{Name: "timeline", Title: "events per hour", Format: "png", ContentType: "image/png", Data: []byte("png")}}
+	if err := n.Notify(notification); err != nil {
+		t.Fatal(err)
+	}
+
+	// the chart is uploaded once
+	if len(texts) != 3 {
+		t.Fatalf("expected two texts and an image, got %d", len(texts))
+	} else if uploads != 1 {
+		t.Fatalf("expected one upload, got %d", uploads)
+	}
+	for i, path := range []string{
+		"/_matrix/client/r0/rooms/%21room:example.com/send/m.room.message/node-report_2026-10-19T10:00:00+0000",
+		"/_matrix/client/r0/rooms/%21room:example.com/send/m.room.message/node-report_2026-10-19T10:00:00+0000",
+		"/_matrix/client/r0/rooms/%21room:example.com/send/m.room.message/node-report_2026-10-19T10:00:00+0000-timeline",
+	} {
+		if texts[i].Path != path || texts[i].Method != http.MethodPut || texts[i].Header.Get("Authorization") != "Bearer token" {
+			t.Fatalf("unexpected request %s %s %s", texts[i].Method, texts[i].Path, texts[i].Header.Get("Authorization"))
+		}
+	}
+
+	message := map[string]interface{}{}
+	if err := json.Unmarshal(texts[1].Body, &message); err != nil {
+		t.Fatal(err)
+	} else if message["msgtype"] != "m.text" || message["body"] != "5 events from 2 addresses https://example.com/report.csv" {
+		t.Fatalf("unexpected message %v", message)
+	}
+
+	image := map[string]interface{}{}
+	if err := json.Unmarshal(texts[2].Body, &image); err != nil {
+		t.Fatal(err)
+	} else if image["msgtype"] != "m.image" || image["url"] != "mxc://example.com/chart" {
+		t.Fatalf("unexpected image %v", image)
+	}
+}
+
+func TestChatWebhookNotifiers(t *testing.T) {
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = ioutil.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	report := NewReport("node", time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC))
+	report.Add(&ReportRow{Address: "1.2.3.4", Sensor: "ssh", Rule: "auth", Events: 3})
+	report.Add(&ReportRow{Address: "5.6.7.8", Sensor: "ssh", Rule: "auth", Events: 2})
+	report.Finish()
+	notification := NewReportNotification(report, "https://example.com/report.csv", 1)
+	// only the charts with a public url are linked
+	notification.Charts = []*Chart{
+		{Name: "timeline", Title: "events per hour", URL: "https://example.com/timeline.png"},
+		{Name: "countries", Title: "top countries", URL: "file:///tmp/countries.png"},
+	}
+
+	for _, test := range []struct {
+		notifier *ReportNotifier
+		expected string
+	}{
+		{
+			&ReportNotifier{Slack: &SlackWebhook{URL: server.URL, Channel: "#takuan"}},
+			`{"text":"5 events from 2 addresses https://example.com/report.csv","blocks":[` +
+				`{"type":"section","text":{"type":"mrkdwn","text":"5 events from 2 addresses https://example.com/report.csv"}},` +
+				`{"type":"image","image_url":"https://example.com/timeline.png","alt_text":"events per hour",` +
+				`"title":{"type":"plain_text","text":"events per hour"}}],"channel":"#takuan"}`,
+		},
+		{
+			&ReportNotifier{Discord: &DiscordWebhook{URL: server.URL}},
+			`{"content":"5 events from 2 addresses https://example.com/report.csv"}`,
+		},
+	} {
+		n := test.notifier
+		n.Name, n.Enabled, n.Charts = "test", true, true
+		n.Template = "{{.Events}} events from {{.Addresses}} addresses {{.URL}}"
+		if err := n.Init(); err != nil {
+			t.Fatal(err)
+		} else if err = n.Notify(notification); err != nil {
+			t.Fatal(err)
+		} else if strings.TrimSpace(string(body)) != test.expected {
+			t.Errorf("expected payload\n%s\ngot\n%s", test.expected, body)
+		}
+	}
+}
+
+func TestEmailNotifier(t *testing.T) {
+	// a local stand-in accepting a single message
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	messages := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		reader := bufio.NewReader(conn)
+		fmt.Fprintf(conn, "220 localhost ESMTP\r\n")
+		message := ""
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			switch command := strings.ToUpper(strings.TrimSpace(line)); {
+			case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+				fmt.Fprintf(conn, "250-localhost\r\n250 8BITMIME\r\n")
+			case strings.HasPrefix(command, "MAIL FROM"), strings.HasPrefix(command, "RCPT TO"):
+				message += strings.TrimSpace(line) + "\n"
+				fmt.Fprintf(conn, "250 OK\r\n")
+			case command == "DATA":
+				fmt.Fprintf(conn, "354 go ahead\r\n")
+				for line, err = reader.ReadString('\n'); err == nil && line != ".\r\n"; line, err = reader.ReadString('\n') {
+					message += line
+				}
+				messages <- message
+				fmt.Fprintf(conn, "250 OK\r\n")
+			case command == "QUIT":
+				fmt.Fprintf(conn, "221 bye\r\n")
+				return
+			default:
+				fmt.Fprintf(conn, "502 unknown command\r\n")
+			}
+		}
+	}()
+
+	n := &ReportNotifier{
+		Name:     "test",
+		Enabled:  true,
+		Template: "{{.Events}} events from {{.Addresses}} addresses {{.URL}}",
+		Subject:  "report {{.Name}}",
+		Email:    &Email{Address: listener.Addr().String(), From: "takuan@example.com", To: []string{"a@example.com", "b@example.com"}},
+	}
+	if err := n.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	report := NewReport("node", time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC))
+	report.Add(&ReportRow{Address: "1.2.3.4", Sensor: "ssh", Rule: "auth", Events: 3})
+	report.Add(&ReportRow{Address: "5.6.7.8", Sensor: "ssh", Rule: "auth", Events: 2})
+	report.Finish()
+	if err := n.Notify(NewReportNotification(report, "https://example.com/report.csv", 1)); err != nil {
+		t.Fatal(err)
+	}
+
+	var message string
+	select {
+	case message = <-messages:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no message received")
+	}
+
+	for _, expected := range []string{
+		"MAIL FROM:<takuan@example.com>",
+		"RCPT TO:<a@example.com>",
+		"RCPT TO:<b@example.com>",
+		"From: takuan@example.com\r\n",
+		"To: a@example.com, b@example.com\r\n",
+		"Subject: report report_2026-10-19T10:00:00+0000\r\n",
+		"Content-Type: text/plain; charset=utf-8\r\n",
+		"\r\n\r\n5 events from 2 addresses https://example.com/report.csv\r\n",
+	} {
+		if !strings.Contains(message, expected) {
+			t.Fatalf("%q not found in message:\n%s", expected, message)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package core
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/evilsocket/islazy/log"
+)
+
+const (
+	defaultRDNSWorkers     = 4
+	defaultRDNSQueueSize   = 1024
+	defaultRDNSTimeoutSecs = 5
+	defaultRDNSCacheTTL    = 86400
+	defaultRDNSNegativeTTL = 3600
+	defaultRDNSCacheSize   = 100000
+)
+
+// Resolver is satisfied by net.Resolver, tests can replace it with a fake one.
+type Resolver interface {
+	LookupAddr(ctx context.Context, addr string) ([]string, error)
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+type rdnsCacheEntry struct {
+	hostname string
+	expires  time.Time
+}
+
+type ReverseDNS struct {
+	sync.Mutex
+
+	Enabled     bool `yaml:"enabled"`
+	Workers     int  `yaml:"workers"`
+	QueueSize   int  `yaml:"queue"`
+	TimeoutSecs int  `yaml:"timeout"`
+	// seconds to cache resolved and unresolved addresses for
+	CacheTTL    int `yaml:"cache_ttl"`
+	NegativeTTL int `yaml:"negative_ttl"`
+	CacheSize   int `yaml:"cache_size"`
+
+	Resolver Resolver `yaml:"-"`
+
+	queue   chan string
+	pending map[string]bool
+	cache   map[string]*rdnsCacheEntry
+}
+
+func (d *ReverseDNS) Init() error {
+	if d.Workers <= 0 {
+		d.Workers = defaultRDNSWorkers
+	}
+	if d.QueueSize <= 0 {
+		d.QueueSize = defaultRDNSQueueSize
+	}
+	if d.TimeoutSecs <= 0 {
+		d.TimeoutSecs = defaultRDNSTimeoutSecs
+	}
+	if d.CacheTTL <= 0 {
+		d.CacheTTL = defaultRDNSCacheTTL
+	}
+	if d.NegativeTTL <= 0 {
+		d.NegativeTTL = defaultRDNSNegativeTTL
+	}
+	if d.CacheSize <= 0 {
+		d.CacheSize = defaultRDNSCacheSize
+	}
+	if d.Resolver == nil {
+		d.Resolver = net.DefaultResolver
+	}
+
+	d.queue = make(chan string, d.QueueSize)
+	d.pending = make(map[string]bool)
+	d.cache = make(map[string]*rdnsCacheEntry)
+
+	return nil
+}
+
+// Cached returns the hostname of an address if it's been resolved (even unsuccessfully)
+// and the cache entry is still valid.
+func (d *ReverseDNS) Cached(address string) (hostname string, found bool) {
+	d.Lock()
+	defer d.Unlock()
+
+	if entry, found := d.cache[address]; found && time.Now().Before(entry.expires) {
+		return entry.hostname, true
+	}
+	return "", false
+}
+
+// Enqueue schedules an address for resolution without ever blocking the caller,
+// if the queue is full the address is dropped and will be retried on its next event.
+func (d *ReverseDNS) Enqueue(address string) {
+	d.Lock()
+	defer d.Unlock()
+
+	if d.pending[address] {
+		return
+	}
+
+	select {
+	case d.queue <- address:
+		d.pending[address] = true
+	default:
+		log.Debug("rdns queue full, dropping %s", address)
+	}
+}
+
+func (d *ReverseDNS) store(address string, hostname string) {
+	d.Lock()
+	defer d.Unlock()
+
+	delete(d.pending, address)
+
+	now := time.Now()
+	if len(d.cache) >= d.CacheSize {
+		for addr, entry := range d.cache {
+			if now.After(entry.expires) {
+				delete(d.cache, addr)
+			}
+		}
+		// still full, make room for one
+		if len(d.cache) >= d.CacheSize {
+			for addr := range d.cache {
+				delete(d.cache, addr)
+				break
+			}
+		}
+	}
+
+	ttl := time.Duration(d.CacheTTL) * time.Second
+	if hostname == "" {
+		ttl = time.Duration(d.NegativeTTL) * time.Second
+	}
+
+	d.cache[address] = &rdnsCacheEntry{
+		hostname: hostname,
+		expires:  now.Add(ttl),
+	}
+}
+
+// forward confirmed reverse dns: a name is only returned if it resolves back to the address
+func (d *ReverseDNS) resolve(address string) string {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return ""
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(d.TimeoutSecs)*time.Second)
+	defer cancel()
+
+	names, err := d.Resolver.LookupAddr(ctx, address)
+	if err != nil {
+		log.Debug("rdns: can't resolve %s: %v", address, err)
+		return ""
+	}
+
+	for _, name := range names {
+		addrs, err := d.Resolver.LookupIPAddr(ctx, name)
+		if err != nil {
+			log.Debug("rdns: can't resolve %s for %s: %v", name, address, err)
+			continue
+		}
+		for _, addr := range addrs {
+			if addr.IP.Equal(ip) {
+				return strings.TrimSuffix(name, ".")
+			}
+		}
+		log.Debug("rdns: %s for %s is not forward confirmed", name, address)
+	}
+
+	return ""
+}
+
+// Start runs the workers, onResolved is called for every address that resolved to a hostname.
+func (d *ReverseDNS) Start(onResolved func(address string, hostname string)) {
+	log.Info("starting %d rdns workers", d.Workers)
+
+	for i := 0; i < d.Workers; i++ {
+		go func() {
+			for address := range d.queue {
+				hostname := d.resolve(address)
+				d.store(address, hostname)
+				if hostname != "" {
+					onResolved(address, hostname)
+				}
+			}
+		}()
+	}
+}
@@ -0,0 +1,214 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+)
+
+// fakeResolver answers from its maps, after delay or when the lookup times out.
+type fakeResolver struct {
+	ptr     map[string][]string
+	forward map[string][]string
+	delay   time.Duration
+}
+
+func (f *fakeResolver) wait(ctx context.Context) error {
+	select {
+	case <-time.After(f.delay):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (f *fakeResolver) LookupAddr(ctx context.Context, addr string) ([]string, error) {
+	if err := f.wait(ctx); err != nil {
+		return nil, err
+	} else if names, found := f.ptr[addr]; found {
+		return names, nil
+	}
+	return nil, fmt.Errorf("no ptr for %s", addr)
+}
+
+func (f *fakeResolver) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	if err := f.wait(ctx); err != nil {
+		return nil, err
+	}
+	addrs := make([]net.IPAddr, 0)
+	for _, addr := range f.forward[host] {
+		addrs = append(addrs, net.IPAddr{IP: net.ParseIP(addr)})
+	}
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("no addresses for %s", host)
+	}
+	return addrs, nil
+}
+
+func TestReverseDNSResolve(t *testing.T) {
+	d := &ReverseDNS{Enabled: true, Resolver: &fakeResolver{
+		ptr: map[string][]string{
+			"1.2.3.4":     {"scanner.example.com."},
+			"1.2.3.5":     {"spoofed.example.com."},
+			"1.2.3.6":     {"missing.example.com.", "second.example.com."},
+			"2001:db8::1": {"v6.example.com."},
+		},
+		forward: map[string][]string{
+			"scanner.example.com.": {"5.6.7.8", "1.2.3.4"},
+			"spoofed.example.com.": {"5.6.7.8"},
+			"second.example.com.":  {"1.2.3.6"},
+			"v6.example.com.":      {"2001:db8:0:0::1"},
+		},
+	}}
+	if err := d.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		address  string
+		hostname string
+	}{
+		{"1.2.3.4", "scanner.example.com"},
+		// the ptr doesn't resolve back to the address
+		{"1.2.3.5", ""},
+		{"1.2.3.6", "second.example.com"},
+		{"2001:db8::1", "v6.example.com"},
+		{"9.9.9.9", ""},
+		{"not an address", ""},
+	} {
+		if hostname := d.resolve(test.address); hostname != test.hostname {
+			t.Errorf("expected '%s' for %s, got '%s'", test.hostname, test.address, hostname)
+		}
+	}
+}
+
+func TestReverseDNSTimeout(t *testing.T) {
+	d := &ReverseDNS{Enabled: true, TimeoutSecs: 1, Resolver: &fakeResolver{
+		ptr:     map[string][]string{"1.2.3.4": {"slow.example.com."}},
+		forward: map[string][]string{"slow.example.com.": {"1.2.3.4"}},
+		delay:   10 * time.Second,
+	}}
+	if err := d.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	started := time.Now()
+	if hostname := d.resolve("1.2.3.4"); hostname != "" {
+		t.Fatalf("unexpected hostname %s", hostname)
+	} else if elapsed := time.Since(started); elapsed > 3*time.Second {
+		t.Fatalf("lookup took %s", elapsed)
+	}
+}
+
+func TestReverseDNSCache(t *testing.T) {
+	d := &ReverseDNS{Enabled: true, CacheTTL: 3600, NegativeTTL: 1, CacheSize: 2}
+	if err := d.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	d.store("1.2.3.4", "scanner.example.com")
+	d.store("5.6.7.8", "")
+
+	if hostname, found := d.Cached("1.2.3.4"); !found || hostname != "scanner.example.com" {
+		t.Fatalf("expected a cached hostname, got '%s' %v", hostname, found)
+	} else if hostname, found = d.Cached("5.6.7.8"); !found || hostname != "" {
+		t.Fatalf("expected a cached failure, got '%s' %v", hostname, found)
+	} else if _, found = d.Cached("9.9.9.9"); found {
+		t.Fatal("unexpected cache entry")
+	}
+
+	// unresolved addresses expire after the negative ttl
+	time.Sleep(1100 * time.Millisecond)
+	if _, found := d.Cached("5.6.7.8"); found {
+		t.Fatal("negative entry not expired")
+	} else if _, found = d.Cached("1.2.3.4"); !found {
+		t.Fatal("positive entry expired")
+	}
+
+	// expired entries are evicted first when the cache is full
+	d.store("9.9.9.9", "other.example.com")
+	if len(d.cache) != 2 {
+		t.Fatalf("expected two entries, got %d", len(d.cache))
+	} else if _, found := d.Cached("1.2.3.4"); !found {
+		t.Fatal("valid entry evicted")
+	}
+	d.store("8.8.8.8", "")
+	if len(d.cache) != 2 {
+		t.Fatalf("expected two entries, got %d", len(d.cache))
+	}
+}
+
+func TestReverseDNSQueue(t *testing.T) {
+	d := &ReverseDNS{Enabled: true, QueueSize: 2}
+	if err := d.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, address := range []string{"1.2.3.4", "1.2.3.4", "5.6.7.8", "9.9.9.9"} {
+		d.Enqueue(address)
+	}
+
+	// duplicates are queued once, addresses are dropped when the queue is full
+	if len(d.queue) != 2 {
+		t.Fatalf("expected two queued addresses, got %d", len(d.queue))
+	} else if !d.pending["1.2.3.4"] || !d.pending["5.6.7.8"] {
+		t.Fatalf("unexpected pending addresses %v", d.pending)
+	} else if d.pending["9.9.9.9"] {
+		t.Fatal("dropped address is pending")
+	}
+
+	// once resolved an address can be queued again
+	<-d.queue
+	d.store("1.2.3.4", "")
+	d.Enqueue("9.9.9.9")
+	d.Enqueue("1.2.3.4")
+	if len(d.queue) != 2 || !d.pending["9.9.9.9"] || d.pending["1.2.3.4"] {
+		t.Fatalf("unexpected queue %d %v", len(d.queue), d.pending)
+	}
+}
+
+func TestReverseDNSStart(t *testing.T) {
+	d := &ReverseDNS{Enabled: true, Workers: 2, Resolver: &fakeResolver{
+		ptr:     map[string][]string{"1.2.3.4": {"scanner.example.com."}, "5.6.7.8": {"spoofed.example.com."}},
+		forward: map[string][]string{"scanner.example.com.": {"1.2.3.4"}},
+	}}
+	if err := d.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	resolved := make(chan string, 2)
+	d.Start(func(address string, hostname string) {
+		resolved <- address + " " + hostname
+	})
+
+	d.Enqueue("5.6.7.8")
+	d.Enqueue("1.2.3.4")
+
+	select {
+	case r := <-resolved:
+		if r != "1.2.3.4 scanner.example.com" {
+			t.Fatalf("unexpected resolution %s", r)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("address not resolved")
+	}
+
+	// unresolved addresses are cached but not reported
+	for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(10 * time.Millisecond) {
+		if hostname, found := d.Cached("5.6.7.8"); found {
+			if hostname != "" {
+				t.Fatalf("unexpected hostname %s", hostname)
+			}
+			break
+		} else if time.Now().After(deadline) {
+			t.Fatal("address not cached")
+		}
+	}
+	select {
+	case r := <-resolved:
+		t.Fatalf("unexpected resolution %s", r)
+	default:
+	}
+}
@@ -0,0 +1,178 @@
+package core
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/evilsocket/islazy/fs"
+	"github.com/evilsocket/islazy/log"
+	"gorm.io/gorm"
+
+	"github.com/evilsocket/takuan/models"
+)
+
+const defaultRelocateChunkSize = 1000
+
+// Relocator updates the location of stored events one distinct address at a time,
+// walking the addresses in chunks so that memory usage doesn't depend on the table size.
+type Relocator struct {
+	// only consider events created after this time
+	Since time.Time
+	// only consider events without a country
+	EmptyOnly bool
+	// only count the events that would change
+	DryRun    bool
+	ChunkSize int
+	// if set, the last processed address is saved here after each chunk
+	// and the next run resumes from it
+	StateFile string
+	Verbose   bool
+
+	db  *gorm.DB
+	geo *Geo
+}
+
+func NewRelocator(db *gorm.DB, geo *Geo) *Relocator {
+	return &Relocator{
+		ChunkSize: defaultRelocateChunkSize,
+		db:        db,
+		geo:       geo,
+	}
+}
+
+func (r *Relocator) filter(db *gorm.DB) *gorm.DB {
+	if !r.Since.IsZero() {
+		db = db.Where("created_at >= ?", r.Since)
+	}
+	if r.EmptyOnly {
+		db = db.Where("country_code = ? OR country_code IS NULL", "")
+	}
+	return db
+}
+
+// only rows with a different location are touched, columns added by a migration
+// are NULL for older rows hence the null safe comparison
+func (r *Relocator) outdated(db *gorm.DB, address string, loc *Location) *gorm.DB {
+	return db.Model(&models.Event{}).
+		Scopes(r.filter).
+		Where("address = ?", address).
+		Where("NOT (country_code <=> ? AND country_name <=> ? AND city <=> ? AND asn <=> ? AND as_org <=> ?)",
+			loc.CountryCode, loc.CountryName, loc.City, loc.ASN, loc.ASOrg)
+}
+
+func (r *Relocator) loadState() string {
+	if r.StateFile != "" && fs.Exists(r.StateFile) {
+		if data, err := ioutil.ReadFile(r.StateFile); err != nil {
+			log.Warning("can't read %s: %v", r.StateFile, err)
+		} else {
+			return strings.TrimSpace(string(data))
+		}
+	}
+	return ""
+}
+
+func (r *Relocator) saveState(last string) {
+	if r.StateFile != "" {
+		if err := ioutil.WriteFile(r.StateFile, []byte(last), 0644); err != nil {
+			log.Warning("can't save state to %s: %v", r.StateFile, err)
+		}
+	}
+}
+
+func (r *Relocator) Run() (updated int64, err error) {
+	// the schema is migrated by the service, running a migration on a big table
+	// from here would lock it for a long time
+	for _, column := range []string{"city", "asn", "as_org"} {
+		if !r.db.Migrator().HasColumn(&models.Event{}, column) {
+			return 0, fmt.Errorf("column %s not found, start the takuan service once to update the database schema", column)
+		}
+	}
+
+	if r.ChunkSize <= 0 {
+		r.ChunkSize = defaultRelocateChunkSize
+	}
+
+	last := r.loadState()
+	if last != "" {
+		log.Info("resuming from address %s", last)
+	}
+
+	var total int64
+	if err = r.db.Model(&models.Event{}).Scopes(r.filter).Distinct("address").Count(&total).Error; err != nil {
+		return 0, err
+	}
+
+	started := time.Now()
+	processed, errors := int64(0), 0
+
+	for {
+		var addresses []string
+		err = r.db.Model(&models.Event{}).
+			Scopes(r.filter).
+			Where("address > ?", last).
+			Distinct("address").
+			Order("address").
+			Limit(r.ChunkSize).
+			Pluck("address", &addresses).Error
+		if err != nil {
+			return updated, err
+		} else if len(addresses) == 0 {
+			break
+		}
+
+		for _, address := range addresses {
+			loc, err := r.geo.Locate(address)
+			if err != nil {
+				log.Debug("error locating %s: %v", address, err)
+				errors++
+				continue
+			}
+
+			var changed int64
+			if r.DryRun {
+				err = r.outdated(r.db, address, loc).Count(&changed).Error
+			} else {
+				res := r.outdated(r.db, address, loc).Updates(locationColumns(loc))
+				changed, err = res.RowsAffected, res.Error
+			}
+			if err != nil {
+				return updated, err
+			}
+
+			if changed > 0 && r.Verbose {
+				log.Info("%s -> %s (AS%d %s): %d events", address, loc.CountryName, loc.ASN, loc.ASOrg, changed)
+			}
+			updated += changed
+		}
+
+		last = addresses[len(addresses)-1]
+		processed += int64(len(addresses))
+		if !r.DryRun {
+			r.saveState(last)
+		}
+
+		if r.Verbose {
+			log.Info("%d/%d addresses, %d events updated, %d errors (%s)", processed, total, updated, errors, time.Since(started))
+		}
+	}
+
+	// done, next run starts over
+	if r.StateFile != "" && !r.DryRun && fs.Exists(r.StateFile) {
+		os.Remove(r.StateFile)
+	}
+
+	return updated, nil
+}
+
+func locationColumns(loc *Location) map[string]interface{} {
+	return map[string]interface{}{
+		"country_code": loc.CountryCode,
+		"country_name": loc.CountryName,
+		"city":         loc.City,
+		"asn":          loc.ASN,
+		"as_org":       loc.ASOrg,
+	}
+}
@@ -0,0 +1,188 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+type ReportEntry struct {
+	Address     string         `json:"address"`
+	CountryCode string         `json:"country_code"`
+	CountryName string         `json:"country_name"`
+	City        string         `json:"city,omitempty"`
+	ASN         uint           `json:"asn,omitempty"`
+	ASOrg       string         `json:"as_org,omitempty"`
+	Events      int            `json:"total_events"`
+	FirstSeen   time.Time      `json:"first_seen"`
+	LastSeen    time.Time      `json:"last_seen"`
+	Rules       map[string]int `json:"rules"`
+	Tags        []string       `json:"tags,omitempty"`
+}
+
+// sorted sensor/rule:count strings
+func (e *ReportEntry) Counters() []string {
+	counters := make([]string, 0, len(e.Rules))
+	for typeName, count := range e.Rules {
+		counters = append(counters, fmt.Sprintf("%s:%d", typeName, count))
+	}
+	sort.Strings(counters)
+	return counters
+}
+
+// sorted sensor/rule names
+func (e *ReportEntry) RuleNames() []string {
+	names := make([]string, 0, len(e.Rules))
+	for typeName := range e.Rules {
+		names = append(names, typeName)
+	}
+	sort.Strings(names)
+	return names
+}
+
+type ASNEntry struct {
+	ASN       uint   `json:"asn"`
+	Org       string `json:"as_org"`
+	Addresses int    `json:"addresses"`
+	Events    int    `json:"total_events"`
+}
+
+// Report is what gets published, regardless of the format.
+type Report struct {
+	Name      string    `json:"name"`
+	Node      string    `json:"node"`
+	CreatedAt time.Time `json:"created_at"`
+	// the scheduled window of the events
+	WindowStart *time.Time     `json:"window_start,omitempty"`
+	WindowEnd   *time.Time     `json:"window_end,omitempty"`
+	Events      int            `json:"total_events"`
+	Entries     []*ReportEntry `json:"entries"`
+	ASNs        []*ASNEntry    `json:"asns"`
+
+	byAddress map[string]*ReportEntry
+}
+
+func splitTags(tags string) []string {
+	if tags == "" {
+		return nil
+	}
+	return strings.Split(tags, ",")
+}
+
+func asnString(asn uint) string {
+	if asn == 0 {
+		return ""
+	}
+	return fmt.Sprintf("AS%d", asn)
+}
+
+// ReportName is the base name of the files of a report created at the given time.
+func ReportName(createdAt time.Time) string {
+	return fmt.Sprintf("report_%s", createdAt.Format("2006-01-02T15:04:05-0700"))
+}
+
+// ReportRow is the aggregation of the events of an address for a single sensor and rule.
+type ReportRow struct {
+	Address     string
+	Sensor      string
+	Rule        string
+	Events      int
+	FirstSeen   time.Time
+	LastSeen    time.Time
+	CountryCode string
+	CountryName string
+	City        string
+	ASN         uint
+	ASOrg       string
+	// comma separated, possibly with duplicates
+	Tags string
+}
+
+// NewReport creates an empty report, rows are then added with Add and Finish sorts it.
+func NewReport(node string, createdAt time.Time) *Report {
+	return &Report{
+		Name:      ReportName(createdAt),
+		Node:      node,
+		CreatedAt: createdAt,
+		Entries:   make([]*ReportEntry, 0),
+		ASNs:      make([]*ASNEntry, 0),
+		byAddress: make(map[string]*ReportEntry),
+	}
+}
+
+func (r *Report) Add(row *ReportRow) {
+	entry, found := r.byAddress[row.Address]
+	if !found {
+		entry = &ReportEntry{
+			Address:     row.Address,
+			CountryCode: row.CountryCode,
+			CountryName: row.CountryName,
+			City:        row.City,
+			ASN:         row.ASN,
+			ASOrg:       row.ASOrg,
+			FirstSeen:   row.FirstSeen,
+			LastSeen:    row.LastSeen,
+			Rules:       make(map[string]int),
+		}
+		r.byAddress[row.Address] = entry
+		r.Entries = append(r.Entries, entry)
+	}
+
+	r.Events += row.Events
+	entry.Events += row.Events
+	entry.Rules[fmt.Sprintf("%s/%s", row.Sensor, row.Rule)] += row.Events
+	if row.FirstSeen.Before(entry.FirstSeen) {
+		entry.FirstSeen = row.FirstSeen
+	}
+	if row.LastSeen.After(entry.LastSeen) {
+		entry.LastSeen = row.LastSeen
+	}
+	for _, tag := range splitTags(row.Tags) {
+		if !hasTag(entry.Tags, tag) {
+			entry.Tags = append(entry.Tags, tag)
+		}
+	}
+}
+
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// Finish sorts the entries by number of events and computes the per ASN totals.
+func (r *Report) Finish() {
+	byASN := make(map[uint]*ASNEntry)
+	for _, entry := range r.Entries {
+		sort.Strings(entry.Tags)
+
+		asn, found := byASN[entry.ASN]
+		if !found {
+			asn = &ASNEntry{
+				ASN: entry.ASN,
+				Org: entry.ASOrg,
+			}
+			byASN[entry.ASN] = asn
+			r.ASNs = append(r.ASNs, asn)
+		}
+		asn.Addresses++
+		asn.Events += entry.Events
+	}
+
+	sort.SliceStable(r.Entries, func(i, j int) bool {
+		if r.Entries[i].Events != r.Entries[j].Events {
+			return r.Entries[i].Events > r.Entries[j].Events
+		}
+		return r.Entries[i].Address < r.Entries[j].Address
+	})
+
+	sort.SliceStable(r.ASNs, func(i, j int) bool {
+		return r.ASNs[i].Events > r.ASNs[j].Events
+	})
+
+	r.byAddress = nil
+}
@@ -1,211 +1,240 @@
 package core
 
 import (
-	"encoding/csv"
+	"bytes"
 	"fmt"
-	"github.com/go-git/go-git/v5/plumbing/object"
-	"io/ioutil"
 	"os"
 	"path"
-	"sort"
-	"strings"
 	"sync"
 	"time"
 
-	"github.com/evilsocket/islazy/fs"
 	"github.com/evilsocket/islazy/log"
-	"github.com/go-git/go-git/v5"
-	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
-
-	"github.com/evilsocket/takuan/models"
+	"github.com/robfig/cron/v3"
 )
 
+const defaultReportMaxEvents = 100000
+
 type Reporter struct {
 	sync.Mutex
 
-	Enabled    bool       `yaml:"enabled"`
-	PeriodSecs int        `yaml:"period"`
+	Enabled    bool `yaml:"enabled"`
+	PeriodSecs int  `yaml:"period"`
+	// cron expression of the end of the report windows, aligned to the period if empty
+	Schedule string `yaml:"schedule"`
+	// seconds after the start before the first report
+	WarmUpSecs int `yaml:"warm_up"`
+	// windows missed while the node was down are reported one by one (each) or in a single report (merge)
+	CatchUp string `yaml:"catch_up"`
+	// deprecated, same as a single primary git destination
 	Repository repository `yaml:"repository"`
-
-	publicKey *ssh.PublicKeys
-	repo      *git.Repository
-	tree      *git.Worktree
+	// only report addresses tagged with any of these intel lists
+	Tags []string `yaml:"tags"`
+	// never report addresses tagged with any of these intel lists
+	ExcludeTags []string `yaml:"exclude_tags"`
+	// csv, asn, json, jsonl, stix or misp
+	Formats []string `yaml:"formats"`
+	// maximum number of events per report, larger backlogs are split into several reports
+	MaxEvents    int                  `yaml:"max_events"`
+	Destinations []*ReportDestination `yaml:"destinations"`
+	Lists        *ReportLists         `yaml:"lists"`
+	// detached signatures of every published file
+	Signing *Signer `yaml:"signing"`
+	// periodic summaries published to the same destinations
+	Digests []*Digest `yaml:"digests"`
+	// write the files to the scratch directory instead of the destinations and don't mark events as reported
+	DryRun     bool   `yaml:"dry_run"`
+	ScratchDir string `yaml:"scratch_dir"`
+
+	node     string
+	schedule cron.Schedule
+	scratch  *LocalDestination
 }
 
-func (r *Reporter) Init() (err error) {
-	sshPath := os.Getenv("HOME") + "/.ssh/id_rsa"
-	log.Debug("using ssh key %s", sshPath)
-
-	sshKey, err := ioutil.ReadFile(sshPath)
-	if err != nil {
-		return fmt.Errorf("error reading %s: %v", sshPath, err)
+func (r *Reporter) Init(node string) (err error) {
+	r.node = node
+	if r.MaxEvents <= 0 {
+		r.MaxEvents = defaultReportMaxEvents
+	}
+	if len(r.Formats) == 0 {
+		r.Formats = defaultReportFormats
+	}
+	if r.PeriodSecs <= 0 {
+		r.PeriodSecs = defaultReportPeriod
+	}
+	if r.WarmUpSecs <= 0 {
+		r.WarmUpSecs = defaultReportWarmUp
+	}
+	if r.CatchUp == "" {
+		r.CatchUp = "each"
+	} else if r.CatchUp != "each" && r.CatchUp != "merge" {
+		return fmt.Errorf("unknown catch_up '%s', use each or merge", r.CatchUp)
+	}
+	if r.schedule, err = parseSchedule(r.Schedule, r.PeriodSecs); err != nil {
+		return err
+	}
+	for _, name := range r.Formats {
+		if _, found := ReportFormats[name]; !found {
+			return fmt.Errorf("unknown report format '%s'", name)
+		}
 	}
 
-	r.publicKey, err = ssh.NewPublicKeys("git", sshKey, "")
-	if err != nil {
-		return fmt.Errorf("error reading %s: %v", sshPath, err)
+	if r.Repository.Remote != "" {
+		r.Destinations = append([]*ReportDestination{{
+			Name:    "git",
+			Primary: true,
+			Git:     &GitDestination{repository: r.Repository},
+		}}, r.Destinations...)
 	}
 
-	if fs.Exists(r.Repository.Local) {
-		// open local copy and pull
-		if r.repo, err = git.PlainOpen(r.Repository.Local); err != nil {
-			return fmt.Errorf("error while opening git repo %s: %v", r.Repository.Local, err)
+	if r.DryRun {
+		if r.ScratchDir == "" {
+			r.ScratchDir = path.Join(os.TempDir(), "takuan-dry-run")
 		}
-
-		r.tree, err = r.repo.Worktree()
-		if err != nil {
-			return fmt.Errorf("error while getting working tree for git repo %s: %v", r.Repository.Local, err)
+		r.scratch = &LocalDestination{Path: r.ScratchDir}
+		if err = r.scratch.Init(); err != nil {
+			return fmt.Errorf("error creating scratch directory: %v", err)
 		}
+		log.Warning("reports dry run, files are written to %s", r.ScratchDir)
+	} else if len(r.Destinations) == 0 {
+		return fmt.Errorf("no report destinations configured")
+	}
 
-		log.Info("updating %s from %s ...", r.Repository.Local, r.Repository.Remote)
-
-		pullOpts := git.PullOptions{
-			Auth:       r.publicKey,
-			RemoteName: "origin",
+	primaries := 0
+	names := make(map[string]bool)
+	for i, dest := range r.Destinations {
+		if dest.Name == "" {
+			dest.Name = fmt.Sprintf("destination-%d", i)
 		}
-
-		if err = r.tree.Pull(&pullOpts); err != nil && err != git.NoErrAlreadyUpToDate {
-			return fmt.Errorf("error while updating git repo %s: %v", r.Repository.Local, err)
+		// the ledger records the destinations a report still has to be published to by name
+		if names[dest.Name] {
+			return fmt.Errorf("duplicate report destination '%s'", dest.Name)
 		}
-	} else {
-		log.Info("cloning %s to %s ...", r.Repository.Remote, r.Repository.Local)
+		names[dest.Name] = true
+		if dest.Primary {
+			primaries++
+		}
+		// destinations like git clone or connect when initialized
+		if !r.DryRun {
+			if err = dest.Init(); err != nil {
+				return err
+			}
+		}
+	}
 
-		r.repo, err = git.PlainClone(r.Repository.Local, false, &git.CloneOptions{
-			URL:  r.Repository.Remote,
-			Auth: r.publicKey,
-			// Progress: os.Stdout,
-		})
+	if r.Lists != nil && r.Lists.Enabled {
+		if err = r.Lists.Init(); err != nil {
+			return err
+		}
+	}
 
-		if err != nil {
-			return fmt.Errorf("error while cloning git repo %s to %s: %v", r.Repository.Remote, r.Repository.Local, err)
+	if r.Signing != nil && r.Signing.Enabled {
+		if err = r.Signing.Init(); err != nil {
+			return err
 		}
+	}
 
-		r.tree, err = r.repo.Worktree()
-		if err != nil {
-			return fmt.Errorf("error while getting working tree for git repo %s: %v", r.Repository.Local, err)
+	for _, digest := range r.Digests {
+		if digest.Enabled {
+			if err = digest.Init(); err != nil {
+				return err
+			}
 		}
 	}
 
+	if primaries == 0 && len(r.Destinations) > 0 {
+		r.Destinations[0].Primary = true
+	} else if primaries > 1 {
+		return fmt.Errorf("only one report destination can be primary")
+	}
+
 	return nil
 }
 
-type addrCounter struct {
-	Address string
-	Count   int
+func (r *Reporter) warmUp() time.Duration {
+	return time.Duration(r.WarmUpSecs) * time.Second
 }
 
-func (r *Reporter) OnBatch(events []models.Event) (reportURL string, err error) {
-	r.Lock()
-	defer r.Unlock()
+// Filter returns the filter of the events to report, by the tags and exclude_tags options.
+func (r *Reporter) Filter() *EventFilter {
+	return &EventFilter{
+		Tags:        r.Tags,
+		ExcludeTags: r.ExcludeTags,
+	}
+}
 
-	if r.Enabled {
-		byAddress := make(map[string][]models.Event)
-		for _, event := range events {
-			if list, found := byAddress[event.Address]; found {
-				byAddress[event.Address] = append(list, event)
-			} else {
-				byAddress[event.Address] = []models.Event{event}
-			}
-		}
+// Render renders the report in every configured format.
+func (r *Reporter) Render(report *Report) (*Publication, error) {
+	pub := &Publication{
+		Name:    report.Name,
+		Node:    report.Node,
+		Message: fmt.Sprintf("reporting %d addresses, %d total events", len(report.Entries), report.Events),
+		Files:   make([]*ReportFile, 0, len(r.Formats)),
+	}
 
-		// sort by number of events
-		addrCounters := make([]addrCounter, 0)
-		for address, addrEvents := range byAddress {
-			addrCounters = append(addrCounters, addrCounter{
-				Address: address,
-				Count:   len(addrEvents),
-			})
+	for _, name := range r.Formats {
+		format := ReportFormats[name]
+		buf := bytes.Buffer{}
+		if err := format.Write(&buf, report); err != nil {
+			return nil, fmt.Errorf("error rendering %s report: %v", name, err)
 		}
-
-		sort.Slice(addrCounters, func(i, j int) bool {
-			return addrCounters[i].Count > addrCounters[j].Count
+		pub.Files = append(pub.Files, &ReportFile{
+			Name: report.Name + format.Suffix(),
+			Data: buf.Bytes(),
 		})
+	}
+	return pub, nil
+}
 
-		fileBaseName := fmt.Sprintf("report_%s.csv", time.Now().Format("2006-01-02T15:04:05-0700"))
-		fileName := path.Join(r.Repository.Local, fileBaseName)
-
-		fp, err := os.Create(fileName)
-		if err != nil {
-			return "", fmt.Errorf("error creating %s: %v", fileName, err)
-		}
-
-		log.Info("saving report to %s", fileName)
-
-		writer := csv.NewWriter(fp)
-
-		writer.Write([]string{
-			"address",
-			"country_code",
-			"country_name",
-			"total_events",
-			"counters",
-		})
+// Publish publishes to every destination, it returns the url of the
+// primary one and fails only if no destination succeeded.
+func (r *Reporter) Publish(pub *Publication) (string, error) {
+	pubURL, _, err := r.PublishTo(pub, nil)
+	return pubURL, err
+}
 
-		for _, c := range addrCounters {
-			address := c.Address
-			addrEvents := byAddress[address]
-
-			byTypeName := make(map[string]int)
-			counters := make([]string, 0)
-			for _, event := range addrEvents {
-				typeName := fmt.Sprintf("%s/%s", event.Sensor, event.Rule)
-				if _, found := byTypeName[typeName]; found {
-					byTypeName[typeName]++
-				} else {
-					byTypeName[typeName] = 1
-				}
-			}
+// PublishTo publishes to the named destinations, or to all of them if names is empty, it returns
+// the url of the primary one, the names of the destinations that failed and fails only if no
+// destination succeeded.
+func (r *Reporter) PublishTo(pub *Publication, names []string) (pubURL string, failed []string, err error) {
+	r.Lock()
+	defer r.Unlock()
 
-			for typeName, count := range byTypeName {
-				counters = append(counters, fmt.Sprintf("%s:%d", typeName, count))
-			}
+	if r.Signing != nil && r.Signing.Enabled {
+		r.Signing.sign(pub)
+	}
 
-			writer.Write([]string{
-				address,
-				addrEvents[0].CountryCode,
-				addrEvents[0].CountryName,
-				fmt.Sprintf("%d", c.Count),
-				strings.Join(counters, "|"),
-			})
+	if r.DryRun {
+		url, err := r.scratch.Publish(pub)
+		if err == nil {
+			log.Info("%s written to %s (dry run)", pub.Name, r.ScratchDir)
 		}
+		return url, nil, err
+	}
 
-		writer.Flush()
-		fp.Close()
-
-		// add, commit and push
-		log.Info("adding %s to repository", fileName)
-
-		if _, err := r.tree.Add(fileBaseName); err != nil {
-			return "", fmt.Errorf("error while adding report %s to git repo %s: %v", fileName, r.Repository.Local, err)
+	published, targets := 0, 0
+	for _, dest := range r.Destinations {
+		if len(names) > 0 && !hasTag(names, dest.Name) {
+			continue
 		}
-
-		commitMessage := fmt.Sprintf("reporting %d addresses, %d total events", len(addrCounters), len(events))
-		_, err = r.tree.Commit(commitMessage,&git.CommitOptions{
-			Author: &object.Signature{
-				When: time.Now(),
-			},
-		})
+		targets++
+		url, err := dest.Publish(pub)
 		if err != nil {
-			return "", fmt.Errorf("error while creating commit for git repo %s: %v", r.Repository.Local, err)
-		}
-
-		pushOptions := git.PushOptions{
-			Auth: r.publicKey,
+			log.Error("%v", err)
+			failed = append(failed, dest.Name)
+			continue
 		}
-		if err = r.repo.Push(&pushOptions); err != nil {
-			return "", fmt.Errorf("error while pushing git repo %s: %v", r.Repository.Local, err)
+		published++
+		if dest.Primary {
+			pubURL = url
 		}
+	}
 
-		reportURL := r.Repository.HTTP
-		if !strings.HasSuffix(reportURL, "/") {
-			reportURL += "/"
-		}
-		reportURL = fmt.Sprintf("%s%s", reportURL, fileBaseName)
-
-		log.Info("new report saved to %s", reportURL)
-
-		return reportURL, nil
+	// destinations removed from the configuration meanwhile
+	if targets == 0 {
+		return "", nil, nil
+	} else if published == 0 {
+		return "", failed, fmt.Errorf("%s could not be published to any destination", pub.Name)
 	}
 
-	return "", nil
+	return pubURL, failed, nil
 }
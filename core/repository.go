@@ -1,7 +1,167 @@
 package core
 
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/evilsocket/islazy/fs"
+	"github.com/evilsocket/islazy/log"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
+)
+
+const defaultGitAuthor = "takuan"
+
+type identity struct {
+	Name  string `yaml:"name"`
+	Email string `yaml:"email"`
+}
+
+func (i identity) signature() *object.Signature {
+	return &object.Signature{
+		Name:  i.Name,
+		Email: i.Email,
+		When:  time.Now(),
+	}
+}
+
+// ssh is used for git@ and ssh:// remotes, basic auth for http(s):// ones, local
+// remotes need none
+type gitAuth struct {
+	// ssh private key, defaults to ~/.ssh/id_rsa unless the agent is used
+	SSHKey string `yaml:"ssh_key"`
+	// the key passphrase, either inline, read from a file or from an environment variable
+	Passphrase     string `yaml:"passphrase"`
+	PassphraseFile string `yaml:"passphrase_file"`
+	PassphraseEnv  string `yaml:"passphrase_env"`
+	SSHAgent       bool   `yaml:"ssh_agent"`
+	// defaults to ~/.ssh/known_hosts or $SSH_KNOWN_HOSTS
+	KnownHosts string `yaml:"known_hosts"`
+	// https basic auth, tokens can be used as password
+	Username    string `yaml:"username"`
+	Password    string `yaml:"password"`
+	PasswordEnv string `yaml:"password_env"`
+}
+
 type repository struct {
 	HTTP   string `yaml:"http"`
 	Remote string `yaml:"remote"`
 	Local  string `yaml:"local"`
+	// defaults to the branch checked out by the local clone, or the remote HEAD
+	Branch string  `yaml:"branch"`
+	Auth   gitAuth `yaml:"auth"`
+	// the committer defaults to the author
+	Author    identity  `yaml:"author"`
+	Committer *identity `yaml:"committer"`
+}
+
+// first non empty value among inline, file and environment variable
+func secret(what string, value string, fileName string, envName string) (string, error) {
+	if value != "" {
+		return value, nil
+	} else if fileName != "" {
+		data, err := ioutil.ReadFile(fileName)
+		if err != nil {
+			return "", fmt.Errorf("error reading %s from %s: %v", what, fileName, err)
+		}
+		return strings.TrimSpace(string(data)), nil
+	} else if envName != "" {
+		if value = os.Getenv(envName); value == "" {
+			return "", fmt.Errorf("%s environment variable %s is not set", what, envName)
+		}
+		return value, nil
+	}
+	return "", nil
+}
+
+func (r *repository) isHTTP() bool {
+	return strings.HasPrefix(r.Remote, "http://") || strings.HasPrefix(r.Remote, "https://")
+}
+
+// sshUser returns the user of git@host:path and ssh://user@host/path remotes.
+func (r *repository) sshUser() string {
+	remote := strings.TrimPrefix(r.Remote, "ssh://")
+	if idx := strings.Index(remote, "@"); idx > 0 {
+		return remote[:idx]
+	}
+	return "git"
+}
+
+// auth builds and validates the authentication method for the remote.
+func (r *repository) auth() (transport.AuthMethod, error) {
+	if r.Author.Name == "" {
+		r.Author.Name = defaultGitAuthor
+	}
+	if r.Committer == nil {
+		r.Committer = &r.Author
+	}
+
+	if strings.HasPrefix(r.Remote, "file://") || strings.HasPrefix(r.Remote, "/") {
+		return nil, nil
+	} else if r.isHTTP() {
+		password, err := secret("password", r.Auth.Password, "", r.Auth.PasswordEnv)
+		if err != nil {
+			return nil, err
+		} else if password == "" {
+			log.Debug("no credentials for %s", r.Remote)
+			return nil, nil
+		}
+
+		username := r.Auth.Username
+		if username == "" {
+			// any non empty username works with tokens
+			username = defaultGitAuthor
+		}
+		log.Debug("using https basic auth as %s", username)
+		return &http.BasicAuth{Username: username, Password: password}, nil
+	}
+
+	var hostKeyCallback ssh.HostKeyCallbackHelper
+	if r.Auth.KnownHosts != "" {
+		if !fs.Exists(r.Auth.KnownHosts) {
+			return nil, fmt.Errorf("known hosts file %s does not exist", r.Auth.KnownHosts)
+		}
+		callback, err := ssh.NewKnownHostsCallback(r.Auth.KnownHosts)
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s: %v", r.Auth.KnownHosts, err)
+		}
+		hostKeyCallback.HostKeyCallback = callback
+	} else if callback, err := ssh.NewKnownHostsCallback(); err != nil {
+		return nil, fmt.Errorf("error reading known hosts: %v", err)
+	} else {
+		hostKeyCallback.HostKeyCallback = callback
+	}
+
+	if r.Auth.SSHAgent {
+		log.Debug("using ssh agent")
+		auth, err := ssh.NewSSHAgentAuth(r.sshUser())
+		if err != nil {
+			return nil, fmt.Errorf("error connecting to the ssh agent: %v", err)
+		}
+		auth.HostKeyCallbackHelper = hostKeyCallback
+		return auth, nil
+	}
+
+	keyPath := r.Auth.SSHKey
+	if keyPath == "" {
+		keyPath = os.Getenv("HOME") + "/.ssh/id_rsa"
+	}
+	log.Debug("using ssh key %s", keyPath)
+
+	passphrase, err := secret("passphrase", r.Auth.Passphrase, r.Auth.PassphraseFile, r.Auth.PassphraseEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	auth, err := ssh.NewPublicKeysFromFile(r.sshUser(), keyPath, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %v", keyPath, err)
+	}
+	auth.HostKeyCallbackHelper = hostKeyCallback
+	return auth, nil
 }
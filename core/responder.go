@@ -0,0 +1,305 @@
+package core
+
+import (
+	"fmt"
+	"net"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/evilsocket/islazy/log"
+
+	"github.com/evilsocket/takuan/models"
+)
+
+const (
+	defaultResponderBanSecs    = 3600
+	defaultResponderWindowSecs = 600
+	defaultResponderEvents     = 10
+	responderQueueSize         = 1024
+)
+
+// Executor runs the firewall commands, tests can replace it with a fake one.
+type Executor interface {
+	Run(name string, args ...string) error
+}
+
+type commandExecutor struct{}
+
+func (e commandExecutor) Run(name string, args ...string) error {
+	if out, err := exec.Command(name, args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("%s %s: %v (%s)", name, strings.Join(args, " "), err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+type dryRunExecutor struct{}
+
+func (e dryRunExecutor) Run(name string, args ...string) error {
+	log.Info("responder (dry run): %s %s", name, strings.Join(args, " "))
+	return nil
+}
+
+type Threshold struct {
+	Events     int `yaml:"events"`
+	WindowSecs int `yaml:"window"`
+}
+
+type Responder struct {
+	sync.Mutex
+
+	Enabled bool `yaml:"enabled"`
+	DryRun  bool `yaml:"dry_run"`
+	// nft or ipset
+	Backend string `yaml:"backend"`
+	// nftables table, only used by the nft backend
+	Table string `yaml:"table"`
+	Set   string `yaml:"set"`
+	// how long an address stays banned
+	BanSecs int `yaml:"ban"`
+	// default threshold and per rule overrides
+	Threshold  Threshold             `yaml:"threshold"`
+	Thresholds map[string]*Threshold `yaml:"thresholds"`
+	Allowlist  []string              `yaml:"allowlist"`
+
+	Executor Executor `yaml:"-"`
+
+	allowed []*net.IPNet
+	queue   chan net.IP
+	hits    map[string][]time.Time
+	banned  map[string]time.Time
+}
+
+func (r *Responder) Init() error {
+	if r.Backend == "" {
+		r.Backend = "nft"
+	} else if r.Backend != "nft" && r.Backend != "ipset" {
+		return fmt.Errorf("unknown responder backend '%s', use nft or ipset", r.Backend)
+	}
+
+	if r.Set == "" {
+		r.Set = defaultSetName
+	}
+	if r.Table == "" {
+		r.Table = defaultSetName
+	}
+	if r.BanSecs <= 0 {
+		r.BanSecs = defaultResponderBanSecs
+	}
+	if r.Threshold.Events <= 0 {
+		r.Threshold.Events = defaultResponderEvents
+	}
+	if r.Threshold.WindowSecs <= 0 {
+		r.Threshold.WindowSecs = defaultResponderWindowSecs
+	}
+	for _, t := range r.Thresholds {
+		if t.Events <= 0 {
+			t.Events = r.Threshold.Events
+		}
+		if t.WindowSecs <= 0 {
+			t.WindowSecs = r.Threshold.WindowSecs
+		}
+	}
+
+	// never ban ourselves
+	r.allowed = make([]*net.IPNet, 0)
+	for _, entry := range append([]string{"127.0.0.0/8", "::1/128"}, r.Allowlist...) {
+		if !strings.Contains(entry, "/") {
+			if ip := net.ParseIP(entry); ip != nil && ip.To4() != nil {
+				entry += "/32"
+			} else {
+				entry += "/128"
+			}
+		}
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			return fmt.Errorf("invalid allowlist entry '%s': %v", entry, err)
+		}
+		r.allowed = append(r.allowed, network)
+	}
+
+	if r.Executor == nil {
+		if r.DryRun {
+			r.Executor = dryRunExecutor{}
+		} else {
+			r.Executor = commandExecutor{}
+		}
+	}
+
+	r.queue = make(chan net.IP, responderQueueSize)
+	r.hits = make(map[string][]time.Time)
+	r.banned = make(map[string]time.Time)
+
+	return r.createSets()
+}
+
+func (r *Responder) setNames() (v4 string, v6 string) {
+	if r.Backend == "nft" {
+		return r.Set + "_v4", r.Set + "_v6"
+	}
+	return r.Set, r.Set + "6"
+}
+
+func (r *Responder) setFor(ip net.IP) string {
+	v4, v6 := r.setNames()
+	if ip.To4() != nil {
+		return v4
+	}
+	return v6
+}
+
+// sets are created with timeout support if they don't exist yet
+func (r *Responder) createSets() error {
+	v4, v6 := r.setNames()
+	if r.Backend == "nft" {
+		if err := r.Executor.Run("nft", "add", "table", "inet", r.Table); err != nil {
+			return err
+		}
+		for set, addrType := range map[string]string{v4: "ipv4_addr", v6: "ipv6_addr"} {
+			def := fmt.Sprintf("{ type %s; flags timeout; }", addrType)
+			if err := r.Executor.Run("nft", "add", "set", "inet", r.Table, set, def); err != nil {
+				return err
+			}
+		}
+	} else {
+		for set, family := range map[string]string{v4: "inet", v6: "inet6"} {
+			if err := r.Executor.Run("ipset", "create", set, "hash:ip", "family", family, "timeout", "0", "-exist"); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (r *Responder) isAllowed(ip net.IP) bool {
+	for _, network := range r.allowed {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Responder) thresholdFor(rule string) *Threshold {
+	if t, found := r.Thresholds[rule]; found {
+		return t
+	}
+	return &r.Threshold
+}
+
+func (r *Responder) OnEvent(event models.Event) {
+	r.Lock()
+	defer r.Unlock()
+
+	ip := net.ParseIP(event.Address)
+	if ip == nil || r.isAllowed(ip) {
+		return
+	} else if _, banned := r.banned[event.Address]; banned {
+		return
+	}
+
+	threshold := r.thresholdFor(event.Rule)
+	window := time.Duration(threshold.WindowSecs) * time.Second
+
+	// old log lines being parsed for the first time are not a reason to ban
+	if time.Since(event.CreatedAt) > window {
+		return
+	}
+
+	// hits are counted by detection time, log datetimes can be skewed or in the future
+	key := event.Address + "/" + event.Rule
+	hits := make([]time.Time, 0)
+	for _, t := range append(r.hits[key], event.DetectedAt) {
+		if event.DetectedAt.Sub(t) <= window {
+			hits = append(hits, t)
+		}
+	}
+	r.hits[key] = hits
+
+	if len(hits) >= threshold.Events {
+		delete(r.hits, key)
+		// the commands run in the worker, so that they never block the event loop
+		select {
+		case r.queue <- ip:
+			log.Info("responder: %s triggered %s %d times in %s, banning for %ds", event.Address, event.Rule, len(hits), window, r.BanSecs)
+			r.banned[event.Address] = time.Now().Add(time.Duration(r.BanSecs) * time.Second)
+		default:
+			log.Warning("responder: queue is full, not banning %s", event.Address)
+		}
+	}
+}
+
+// worker runs the queued bans, failed ones are forgotten so that the address can trigger them again.
+func (r *Responder) worker() {
+	for ip := range r.queue {
+		if err := r.ban(ip); err != nil {
+			log.Error("responder: error banning %s: %v", ip, err)
+			r.Lock()
+			delete(r.banned, ip.String())
+			r.Unlock()
+		}
+	}
+}
+
+func (r *Responder) ban(ip net.IP) error {
+	set := r.setFor(ip)
+	if r.Backend == "nft" {
+		element := fmt.Sprintf("{ %s timeout %ds }", ip, r.BanSecs)
+		return r.Executor.Run("nft", "add", "element", "inet", r.Table, set, element)
+	}
+	return r.Executor.Run("ipset", "add", set, ip.String(), "timeout", fmt.Sprintf("%d", r.BanSecs), "-exist")
+}
+
+func (r *Responder) unban(ip net.IP) error {
+	set := r.setFor(ip)
+	if r.Backend == "nft" {
+		return r.Executor.Run("nft", "delete", "element", "inet", r.Table, set, fmt.Sprintf("{ %s }", ip))
+	}
+	return r.Executor.Run("ipset", "del", set, ip.String(), "-exist")
+}
+
+// the kernel expires the set elements on its own, this makes sure they are gone
+// even if the set was created without timeout support and cleans up our state
+func (r *Responder) onExpiry() {
+	r.Lock()
+
+	now := time.Now()
+	expired := make([]string, 0)
+	for address, expiry := range r.banned {
+		if now.After(expiry) {
+			expired = append(expired, address)
+			delete(r.banned, address)
+		}
+	}
+
+	for key, hits := range r.hits {
+		rule := key[strings.Index(key, "/")+1:]
+		window := time.Duration(r.thresholdFor(rule).WindowSecs) * time.Second
+		if len(hits) > 0 && now.Sub(hits[len(hits)-1]) > window {
+			delete(r.hits, key)
+		}
+	}
+
+	r.Unlock()
+
+	// the commands don't hold the lock, events keep flowing meanwhile
+	for _, address := range expired {
+		log.Info("responder: ban for %s expired", address)
+		if err := r.unban(net.ParseIP(address)); err != nil {
+			log.Debug("responder: error unbanning %s: %v", address, err)
+		}
+	}
+}
+
+func (r *Responder) Start() {
+	log.Info("responder started (backend=%s set=%s ban=%ds dry_run=%v)", r.Backend, r.Set, r.BanSecs, r.DryRun)
+
+	go r.worker()
+	go func() {
+		ticker := time.NewTicker(time.Duration(10) * time.Second)
+		for range ticker.C {
+			r.onExpiry()
+		}
+	}()
+}
@@ -0,0 +1,194 @@
+package core
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/evilsocket/takuan/models"
+)
+
+type fakeExecutor struct {
+	sync.Mutex
+	commands []string
+}
+
+func (e *fakeExecutor) Run(name string, args ...string) error {
+	e.Lock()
+	defer e.Unlock()
+	e.commands = append(e.commands, name+" "+strings.Join(args, " "))
+	return nil
+}
+
+func (e *fakeExecutor) find(prefix string) []string {
+	e.Lock()
+	defer e.Unlock()
+	found := make([]string, 0)
+	for _, command := range e.commands {
+		if strings.HasPrefix(command, prefix) {
+			found = append(found, command)
+		}
+	}
+	return found
+}
+
+// drain runs the queued bans synchronously.
+func drain(r *Responder) {
+	for {
+		select {
+		case ip := <-r.queue:
+			r.ban(ip)
+		default:
+			return
+		}
+	}
+}
+
+func TestResponderThreshold(t *testing.T) {
+	executor := &fakeExecutor{}
+	r := &Responder{
+		Enabled:    true,
+		Backend:    "ipset",
+		Threshold:  Threshold{Events: 3, WindowSecs: 60},
+		Thresholds: map[string]*Threshold{"scan": {Events: 1}},
+		Executor:   executor,
+	}
+	if err := r.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	for _, test := range []struct {
+		address string
+		rule    string
+		hits    int
+		prefix  string
+		bans    int
+	}{
+		{"1.2.3.4", "auth", 2, "ipset add", 0},
+		{"1.2.3.4", "auth", 1, "ipset add takuan 1.2.3.4", 1},
+		// already banned addresses are not banned again
+		{"1.2.3.4", "auth", 3, "ipset add", 1},
+		// per rule thresholds and ipv6 set
+		{"2001:db8::1", "scan", 1, "ipset add takuan6 2001:db8::1", 1},
+	} {
+		for i := 0; i < test.hits; i++ {
+			r.OnEvent(models.Event{CreatedAt: now, DetectedAt: now, Address: test.address, Rule: test.rule})
+		}
+		drain(r)
+		if bans := executor.find(test.prefix); len(bans) != test.bans {
+			t.Fatalf("expected %d '%s' after %d %s hits from %s, got %v", test.bans, test.prefix, test.hits, test.rule, test.address, executor.commands)
+		}
+	}
+}
+
+func TestResponderWindow(t *testing.T) {
+	executor := &fakeExecutor{}
+	r := &Responder{Enabled: true, Backend: "ipset", Threshold: Threshold{Events: 3, WindowSecs: 60}, Executor: executor}
+	if err := r.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	for _, test := range []struct {
+		address    string
+		createdAt  []time.Duration
+		detectedAt []time.Duration
+	}{
+		// hits older than the window don't count
+		{"1.2.3.4", []time.Duration{-50 * time.Second, -40 * time.Second, 30 * time.Second}, []time.Duration{-50 * time.Second, -40 * time.Second, 30 * time.Second}},
+		// old log lines never ban
+		{"5.6.7.8", []time.Duration{-time.Hour, -time.Hour, -time.Hour, -time.Hour, -time.Hour}, []time.Duration{0, 0, 0, 0, 0}},
+		// the window uses the detection time, even if the log datetime is in the future
+		{"9.9.9.9", []time.Duration{3 * time.Hour, 3 * time.Hour, 3 * time.Hour}, []time.Duration{0, 2 * time.Minute, 4 * time.Minute}},
+	} {
+		for i := range test.createdAt {
+			r.OnEvent(models.Event{CreatedAt: now.Add(test.createdAt[i]), DetectedAt: now.Add(test.detectedAt[i]), Address: test.address, Rule: "auth"})
+		}
+		drain(r)
+		if bans := executor.find("ipset add"); len(bans) != 0 {
+			t.Fatalf("unexpected bans for %s: %v", test.address, bans)
+		}
+	}
+}
+
+func TestResponderAllowlist(t *testing.T) {
+	executor := &fakeExecutor{}
+	r := &Responder{
+		Enabled:   true,
+		Backend:   "ipset",
+		Threshold: Threshold{Events: 1},
+		Allowlist: []string{"10.0.0.0/8", "192.168.1.1"},
+		Executor:  executor,
+	}
+	if err := r.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	for _, test := range []struct {
+		address string
+		banned  bool
+	}{
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.1.2.3", false},
+		{"192.168.1.1", false},
+		{"not an address", false},
+		{"192.168.1.2", true},
+		{"11.0.0.1", true},
+	} {
+		r.OnEvent(models.Event{CreatedAt: now, DetectedAt: now, Address: test.address, Rule: "auth"})
+		drain(r)
+		if bans := executor.find("ipset add takuan " + test.address); (len(bans) == 1) != test.banned {
+			t.Errorf("unexpected bans for %s: %v", test.address, bans)
+		}
+	}
+}
+
+func TestResponderUnban(t *testing.T) {
+	executor := &fakeExecutor{}
+	r := &Responder{Enabled: true, Backend: "ipset", Threshold: Threshold{Events: 1}, Executor: executor}
+	if err := r.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	r.OnEvent(models.Event{CreatedAt: time.Now(), DetectedAt: time.Now(), Address: "1.2.3.4", Rule: "scan"})
+	drain(r)
+
+	r.onExpiry()
+	if unbans := executor.find("ipset del"); len(unbans) != 0 {
+		t.Fatalf("unbanned before the expiry: %v", unbans)
+	}
+
+	r.banned["1.2.3.4"] = time.Now().Add(-time.Second)
+	r.onExpiry()
+	if unbans := executor.find("ipset del takuan 1.2.3.4"); len(unbans) != 1 {
+		t.Fatalf("expected one unban, got %v", executor.commands)
+	}
+
+	// once unbanned the address can be banned again
+	r.OnEvent(models.Event{CreatedAt: time.Now(), DetectedAt: time.Now(), Address: "1.2.3.4", Rule: "scan"})
+	drain(r)
+	if bans := executor.find("ipset add takuan 1.2.3.4"); len(bans) != 2 {
+		t.Fatalf("expected two bans, got %v", executor.commands)
+	}
+}
+
+func TestResponderNft(t *testing.T) {
+	executor := &fakeExecutor{}
+	r := &Responder{Enabled: true, Threshold: Threshold{Events: 1}, Executor: executor}
+	if err := r.Init(); err != nil {
+		t.Fatal(err)
+	}
+	if sets := executor.find("nft add set inet takuan"); len(sets) != 2 {
+		t.Fatalf("expected two sets, got %v", executor.commands)
+	}
+
+	r.OnEvent(models.Event{CreatedAt: time.Now(), DetectedAt: time.Now(), Address: "1.2.3.4", Rule: "auth"})
+	drain(r)
+	if bans := executor.find("nft add element inet takuan takuan_v4 { 1.2.3.4 timeout 3600s }"); len(bans) != 1 {
+		t.Fatalf("expected one ban, got %v", executor.commands)
+	}
+}
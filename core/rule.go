@@ -11,7 +11,13 @@ type Rule struct {
 	Token       string `yaml:"token"`
 	Description string `yaml:"description"`
 	Expression  string `yaml:"expression"`
-	compiled    *regexp.Regexp
+	Score       int    `yaml:"score"`
+	// info, low, medium, high or critical, rules with at least the alerts
+	// min_severity are notified at the first match
+	Severity string `yaml:"severity"`
+	// alert when an address matches this many times in the window
+	Alert    *Threshold `yaml:"alert"`
+	compiled *regexp.Regexp
 }
 
 func (r *Rule) Compile() (err error) {
@@ -0,0 +1,172 @@
+package core
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+const defaultS3Region = "us-east-1"
+
+// S3Destination uploads the reports to an S3 compatible object storage, like AWS or MinIO.
+type S3Destination struct {
+	// like https://s3.amazonaws.com or http://localhost:9000
+	Endpoint string `yaml:"endpoint"`
+	Region   string `yaml:"region"`
+	Bucket   string `yaml:"bucket"`
+	Prefix   string `yaml:"prefix"`
+	// if empty, AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY are used
+	AccessKey string `yaml:"access_key"`
+	SecretKey string `yaml:"secret_key"`
+	// use http://endpoint/bucket/key instead of http://bucket.endpoint/key, required by MinIO
+	PathStyle bool `yaml:"path_style"`
+	// optional public url the objects can be downloaded from
+	HTTP string `yaml:"http"`
+
+	endpoint *url.URL
+	client   *http.Client
+}
+
+func (d *S3Destination) Init() (err error) {
+	if d.Bucket == "" {
+		return fmt.Errorf("bucket is required")
+	}
+	if d.Endpoint == "" {
+		d.Endpoint = "https://s3.amazonaws.com"
+	}
+	if d.endpoint, err = url.Parse(d.Endpoint); err != nil {
+		return fmt.Errorf("invalid endpoint '%s': %v", d.Endpoint, err)
+	}
+	if d.Region == "" {
+		d.Region = defaultS3Region
+	}
+	if d.AccessKey == "" {
+		d.AccessKey = os.Getenv("AWS_ACCESS_KEY_ID")
+	}
+	if d.SecretKey == "" {
+		d.SecretKey = os.Getenv("AWS_SECRET_ACCESS_KEY")
+	}
+	if d.AccessKey == "" || d.SecretKey == "" {
+		return fmt.Errorf("access_key and secret_key are required")
+	}
+
+	d.client = &http.Client{Timeout: 60 * time.Second}
+	return nil
+}
+
+// uri encoding as required by the aws signature, everything but unreserved characters and /
+func s3Escape(key string) string {
+	var buf strings.Builder
+	for _, b := range []byte(key) {
+		if (b >= 'A' && b <= 'Z') || (b >= 'a' && b <= 'z') || (b >= '0' && b <= '9') ||
+			b == '-' || b == '_' || b == '.' || b == '~' || b == '/' {
+			buf.WriteByte(b)
+		} else {
+			fmt.Fprintf(&buf, "%%%02X", b)
+		}
+	}
+	return buf.String()
+}
+
+func (d *S3Destination) objectURL(key string) string {
+	key = s3Escape(strings.TrimPrefix(path.Join(d.Prefix, key), "/"))
+	if d.PathStyle {
+		return fmt.Sprintf("%s://%s/%s/%s", d.endpoint.Scheme, d.endpoint.Host, d.Bucket, key)
+	}
+	return fmt.Sprintf("%s://%s.%s/%s", d.endpoint.Scheme, d.Bucket, d.endpoint.Host, key)
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// aws signature version 4
+func (d *S3Destination) sign(req *http.Request, payloadHash string, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	date := now.UTC().Format("20060102")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signedHeaders := "content-type;host;x-amz-content-sha256;x-amz-date"
+	canonicalHeaders := fmt.Sprintf("content-type:%s\nhost:%s\nx-amz-content-sha256:%s\nx-amz-date:%s\n",
+		req.Header.Get("Content-Type"), req.URL.Host, payloadHash, amzDate)
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := fmt.Sprintf("%s/%s/s3/aws4_request", date, d.Region)
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+d.SecretKey), date)
+	key = hmacSHA256(key, d.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		d.AccessKey, scope, signedHeaders, signature))
+}
+
+func (d *S3Destination) put(file *ReportFile) error {
+	objectURL := d.objectURL(file.Name)
+	req, err := http.NewRequest(http.MethodPut, objectURL, bytes.NewReader(file.Data))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", contentType(file.Name))
+	d.sign(req, sha256Hex(file.Data), time.Now())
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("PUT %s: %s (%s)", objectURL, resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+func (d *S3Destination) Publish(pub *Publication) (string, error) {
+	for _, file := range pub.Files {
+		if err := d.put(file); err != nil {
+			return "", err
+		}
+	}
+
+	if d.HTTP != "" {
+		return fileURL(d.HTTP, pub.Files[0].Name), nil
+	}
+	return d.objectURL(pub.Files[0].Name), nil
+}
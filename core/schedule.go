@@ -0,0 +1,173 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/evilsocket/islazy/log"
+	"github.com/robfig/cron/v3"
+
+	"github.com/evilsocket/takuan/models"
+)
+
+const (
+	defaultReportPeriod = 3600
+	defaultReportWarmUp = 120
+	// missed windows reported one by one after a downtime, older ones are merged in the first
+	maxCatchUpWindows = 168
+)
+
+// alignedSchedule fires at the multiples of its period since the unix epoch, so that an
+// hourly period always fires on the hour, whenever the process was started.
+type alignedSchedule struct {
+	period time.Duration
+}
+
+func (s alignedSchedule) Next(t time.Time) time.Time {
+	return t.Truncate(s.period).Add(s.period)
+}
+
+// parseSchedule parses a cron expression, like "0 * * * *", "@daily" or "@every 1h30m", or
+// returns an aligned schedule of periodSecs if it's empty.
+func parseSchedule(spec string, periodSecs int) (cron.Schedule, error) {
+	if spec == "" {
+		return alignedSchedule{period: time.Duration(periodSecs) * time.Second}, nil
+	}
+	schedule, err := cron.ParseStandard(spec)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing schedule '%s': %v", spec, err)
+	}
+	return schedule, nil
+}
+
+// interval is the time between the next two runs of a schedule.
+func interval(schedule cron.Schedule) time.Duration {
+	next := schedule.Next(time.Now())
+	return schedule.Next(next).Sub(next)
+}
+
+// windows returns the windows of the schedule that ended after last and before now.
+func (r *Reporter) windows(last time.Time, now time.Time) []window {
+	windows := make([]window, 0)
+	for end := r.schedule.Next(last); !end.After(now); end = r.schedule.Next(end) {
+		windows = append(windows, window{Start: last, End: end})
+		last = end
+	}
+
+	if len(windows) > 1 && r.CatchUp == "merge" {
+		windows = []window{{Start: windows[0].Start, End: windows[len(windows)-1].End}}
+	}
+
+	return capWindows(windows)
+}
+
+// capWindows merges the oldest windows in the first one if there are more than maxCatchUpWindows.
+func capWindows(windows []window) []window {
+	if len(windows) > maxCatchUpWindows {
+		skip := len(windows) - maxCatchUpWindows
+		windows[skip].Start = windows[0].Start
+		windows = windows[skip:]
+	}
+	return windows
+}
+
+// lastWindow returns the end of the last window processed by this node, or of the last one
+// reported for the nodes that were updated from a version without the report state.
+func (r *Aggregator) lastWindow() (time.Time, error) {
+	var state models.ReportState
+	if err := r.db.Where("node_name = ?", r.conf.NodeName).Limit(1).Find(&state).Error; err != nil {
+		return time.Time{}, err
+	}
+
+	var ledger models.Report
+	err := r.db.Where("node_name = ? AND window_end IS NOT NULL", r.conf.NodeName).
+		Order("window_end DESC").
+		Limit(1).
+		Find(&ledger).Error
+	if err != nil {
+		return time.Time{}, err
+	} else if ledger.WindowEnd != nil && ledger.WindowEnd.After(state.LastWindow) {
+		return *ledger.WindowEnd, nil
+	}
+	return state.LastWindow, nil
+}
+
+// saveLastWindow stores the end of the last window processed, so that the empty windows before
+// it aren't processed again after a restart.
+func (r *Aggregator) saveLastWindow(end time.Time) {
+	state := models.ReportState{NodeName: r.conf.NodeName}
+	err := r.db.Where(state).Assign(models.ReportState{LastWindow: end}).FirstOrCreate(&state).Error
+	if err != nil {
+		log.Error("error saving the last report window: %v", err)
+	}
+}
+
+// reportLoop reports the events of every window of the schedule once it ends, and the
+// ones of the windows missed while the node was down. Windows that fail are retried, with
+// their own bounds, at the next cycle.
+func (r *Aggregator) reportLoop() {
+	reporter := r.conf.Reporter
+	// warm up period for parsers to generate data
+	time.Sleep(reporter.warmUp())
+
+	last, err := r.lastWindow()
+	if err != nil {
+		log.Error("error getting the last reported window: %v", err)
+	}
+
+	var windows []window
+	if last.IsZero() {
+		// first run, the first window includes every unreported event
+		windows = []window{{End: time.Now()}}
+	} else if windows = reporter.windows(last, time.Now()); len(windows) > 1 {
+		log.Info("catching up with %d missed report windows since %s", len(windows), last.Format(time.RFC3339))
+	}
+
+	for {
+		if len(windows) > 0 {
+			last = windows[len(windows)-1].End
+
+			// the events of the window still in the buffer
+			r.onNewBatch()
+			reported := r.onReport(windows)
+			if reported > 0 && !reporter.DryRun {
+				r.saveLastWindow(windows[reported-1].End)
+			}
+			if lists := reporter.Lists; lists != nil && lists.Enabled {
+				r.onLists()
+			}
+
+			if windows = windows[reported:]; len(windows) > 0 {
+				log.Warning("%d report windows not reported, the first ending at %s, retrying at the next cycle", len(windows), windows[0].End.Format(time.RFC3339))
+			}
+		}
+
+		next := reporter.schedule.Next(last)
+		log.Debug("next report at %s", next.Format(time.RFC3339))
+		time.Sleep(time.Until(next))
+
+		windows = capWindows(append(windows, reporter.windows(last, time.Now())...))
+	}
+}
+
+// digestLoop publishes the digests at their schedule, and the ones of the windows
+// missed while the node was down.
+func (r *Aggregator) digestLoop() {
+	time.Sleep(r.conf.Reporter.warmUp())
+
+	r.onDigests()
+
+	for _, digest := range r.conf.Reporter.Digests {
+		if digest.Enabled {
+			go func(digest *Digest) {
+				for {
+					time.Sleep(time.Until(digest.schedule.Next(time.Now())))
+					r.onNewBatch()
+					if err := r.digest(digest, time.Now()); err != nil {
+						log.Error("error publishing %s digest: %v", digest.Name, err)
+					}
+				}
+			}(digest)
+		}
+	}
+}
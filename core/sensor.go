@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/evilsocket/islazy/log"
@@ -22,6 +23,10 @@ type Sensor struct {
 
 	fp      *os.File
 	lastPos int64
+
+	statusLock sync.Mutex
+	lastRead   time.Time
+	lastErr    error
 }
 
 func (s *Sensor) Compile() error {
@@ -39,83 +44,130 @@ func (s *Sensor) Compile() error {
 	return nil
 }
 
-func (s *Sensor) Start(events chan models.Event, errors chan error, states chan models.SensorState, state int64) {
-	if !s.Enabled {
-		return
+func (s *Sensor) error(errType string, err error) error {
+	return &SensorError{
+		Sensor: s.Name,
+		Type:   errType,
+		Err:    err,
 	}
+}
 
-	go func() {
-		log.Info("sensor %s started for file %s (from offset %d)...", s.Name, s.Filename, state)
-		s.lastPos = state
+func (s *Sensor) setStatus(err error) {
+	s.statusLock.Lock()
+	defer s.statusLock.Unlock()
+	s.lastErr = err
+	if err == nil {
+		s.lastRead = time.Now()
+	}
+}
 
-		for {
-			var err error
+// Status returns the time of the last successful read and the last error, if any.
+func (s *Sensor) Status() (lastRead time.Time, lastErr error) {
+	s.statusLock.Lock()
+	defer s.statusLock.Unlock()
+	return s.lastRead, s.lastErr
+}
 
-			s.fp, err = os.Open(s.Filename)
-			if err != nil {
-				errors <- err
-				continue
-			}
+// log datetimes without a zone are in the local time of the host, like the report windows
+func parseDatetime(format string, value string) (time.Time, error) {
+	return time.ParseInLocation(format, value, time.Local)
+}
 
-			// if file size < last pos, reset last pos
-			if stat, err := s.fp.Stat(); err != nil {
-				s.fp.Close()
-				errors <- err
-				continue
-			} else if stat.Size() < s.lastPos {
-				log.Debug("resetting last offset for %s", s.Filename)
-				s.lastPos = 0
-			}
+func (s *Sensor) read(events chan models.Event, errors chan error, states chan models.SensorState) (err error) {
+	s.fp, err = os.Open(s.Filename)
+	if err != nil {
+		return s.error("open", err)
+	}
+	defer s.fp.Close()
+
+	// if file size < last pos, reset last pos
+	stat, err := s.fp.Stat()
+	if err != nil {
+		return s.error("stat", err)
+	} else if stat.Size() < s.lastPos {
+		log.Debug("resetting last offset for %s", s.Filename)
+		s.lastPos = 0
+	}
 
-			// continue from the last position
-			_, err = s.fp.Seek(s.lastPos, os.SEEK_SET)
-			if err != nil {
-				s.fp.Close()
-				errors <- err
-				continue
-			}
+	// sampled before reading, the bytes written since the last read
+	metricSensorLag.WithLabelValues(s.Name).Set(float64(stat.Size() - s.lastPos))
+
+	// continue from the last position
+	_, err = s.fp.Seek(s.lastPos, os.SEEK_SET)
+	if err != nil {
+		return s.error("seek", err)
+	}
 
-			scanner := bufio.NewScanner(s.fp)
-			scanner.Split(bufio.ScanLines)
-
-			// for each new line
-			for scanner.Scan() {
-				line := scanner.Text()
-				if matched, tokens := s.Parser.Parse(line); matched {
-					// TODO: use work queue
-
-					// for each rule
-					for _, r := range s.Rules {
-						if matched, _ := r.Match(tokens); matched {
-							event := models.Event{
-								DetectedAt: time.Now(),
-								Address:    tokens["address"],
-								Payload:    line,
-								Rule:       r.Name,
-								Sensor:     s.Name,
-							}
-
-							event.CreatedAt, err = time.Parse(s.Parser.DatetimeFormat, tokens["datetime"])
-							if err != nil {
-								errors <- fmt.Errorf("could not parse datetime '%s' with format '%s': %v", tokens["datetime"], s.Parser.DatetimeFormat, err)
-							}
-
-							events <- event
-							break
-						}
+	scanner := bufio.NewScanner(s.fp)
+	scanner.Split(bufio.ScanLines)
+
+	// for each new line
+	for scanner.Scan() {
+		line := scanner.Text()
+		metricLinesRead.WithLabelValues(s.Name).Inc()
+		if matched, tokens := s.Parser.Parse(line); matched {
+			metricParsed.WithLabelValues(s.Name, "match").Inc()
+
+			// TODO: use work queue
+
+			// for each rule
+			for _, r := range s.Rules {
+				if matched, _ := r.Match(tokens); matched {
+					event := models.Event{
+						DetectedAt: time.Now(),
+						Address:    tokens["address"],
+						Payload:    line,
+						Rule:       r.Name,
+						Sensor:     s.Name,
 					}
-				} else {
-					// log.Info("nope: %s", line)
+
+					event.CreatedAt, err = parseDatetime(s.Parser.DatetimeFormat, tokens["datetime"])
+					if err != nil {
+						errors <- s.error("datetime", fmt.Errorf("could not parse datetime '%s' with format '%s': %v", tokens["datetime"], s.Parser.DatetimeFormat, err))
+						// keep the event inside the report windows
+						event.CreatedAt = event.DetectedAt
+					}
+
+					metricRuleHits.WithLabelValues(s.Name, r.Name).Inc()
+					events <- event
+					break
 				}
 			}
+		} else {
+			metricParsed.WithLabelValues(s.Name, "miss").Inc()
+		}
+	}
+
+	if err = scanner.Err(); err != nil {
+		return s.error("read", err)
+	}
+
+	s.lastPos, _ = s.fp.Seek(0, io.SeekCurrent)
+
+	states <- models.SensorState{
+		SensorName:   s.Name,
+		LastPosition: s.lastPos,
+	}
+
+	return nil
+}
+
+func (s *Sensor) Start(events chan models.Event, errors chan error, states chan models.SensorState, state int64) {
+	if !s.Enabled {
+		return
+	}
 
-			s.lastPos, _ = s.fp.Seek(0, io.SeekCurrent)
-			s.fp.Close()
+	go func() {
+		log.Info("sensor %s started for file %s (from offset %d)...", s.Name, s.Filename, state)
+		s.lastPos = state
 
-			states <- models.SensorState{
-				SensorName:   s.Name,
-				LastPosition: s.lastPos,
+		for {
+			// on errors wait for the next period instead of retrying right away
+			err := s.read(events, errors, states)
+			if err != nil {
+				errors <- err
 			}
+			s.setStatus(err)
 
 			time.Sleep(time.Duration(s.PeriodSecs) * time.Second)
 		}
@@ -0,0 +1,39 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDatetime(t *testing.T) {
+	local := time.Local
+	defer func() { time.Local = local }()
+	time.Local = time.FixedZone("test", 2*3600)
+
+	for _, test := range []struct {
+		format   string
+		value    string
+		expected time.Time
+		fails    bool
+	}{
+		// no zone, local time
+		{"2006-01-02 15:04:05", "2021-03-01 10:00:00", time.Date(2021, 3, 1, 8, 0, 0, 0, time.UTC), false},
+		{"Jan _2 15:04:05", "Mar  1 10:00:00", time.Date(0, 3, 1, 8, 0, 0, 0, time.UTC), false},
+		// explicit zones are kept
+		{"02/Jan/2006:15:04:05 -0700", "01/Mar/2021:10:00:00 +0000", time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC), false},
+		{time.RFC3339, "2021-03-01T10:00:00-05:00", time.Date(2021, 3, 1, 15, 0, 0, 0, time.UTC), false},
+		{"2006-01-02 15:04:05", "01/Mar/2021:10:00:00", time.Time{}, true},
+		{"2006-01-02 15:04:05", "", time.Time{}, true},
+	} {
+		parsed, err := parseDatetime(test.format, test.value)
+		if test.fails {
+			if err == nil {
+				t.Errorf("expected an error for '%s', got %s", test.value, parsed)
+			}
+		} else if err != nil {
+			t.Errorf("unexpected error for '%s': %v", test.value, err)
+		} else if !parsed.Equal(test.expected) {
+			t.Errorf("expected %s for '%s', got %s", test.expected, test.value, parsed)
+		}
+	}
+}
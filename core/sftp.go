@@ -0,0 +1,112 @@
+package core
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path"
+	"time"
+
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// SFTPDestination uploads the reports to a remote directory over SFTP.
+type SFTPDestination struct {
+	// host:port, port 22 if not specified
+	Address  string `yaml:"address"`
+	User     string `yaml:"user"`
+	Password string `yaml:"password"`
+	KeyFile  string `yaml:"key"`
+	// defaults to ~/.ssh/known_hosts
+	KnownHosts string `yaml:"known_hosts"`
+	Path       string `yaml:"path"`
+	// optional public url the directory is served from
+	HTTP string `yaml:"http"`
+
+	config *ssh.ClientConfig
+}
+
+func (d *SFTPDestination) Init() error {
+	if d.Address == "" || d.User == "" {
+		return fmt.Errorf("address and user are required")
+	}
+	if _, _, err := net.SplitHostPort(d.Address); err != nil {
+		d.Address = net.JoinHostPort(d.Address, "22")
+	}
+
+	auth := make([]ssh.AuthMethod, 0)
+	if d.KeyFile != "" {
+		key, err := ioutil.ReadFile(d.KeyFile)
+		if err != nil {
+			return fmt.Errorf("error reading %s: %v", d.KeyFile, err)
+		}
+		signer, err := ssh.ParsePrivateKey(key)
+		if err != nil {
+			return fmt.Errorf("error parsing %s: %v", d.KeyFile, err)
+		}
+		auth = append(auth, ssh.PublicKeys(signer))
+	}
+	if d.Password != "" {
+		auth = append(auth, ssh.Password(d.Password))
+	}
+	if len(auth) == 0 {
+		return fmt.Errorf("either key or password is required")
+	}
+
+	if d.KnownHosts == "" {
+		d.KnownHosts = os.Getenv("HOME") + "/.ssh/known_hosts"
+	}
+	hostKeyCallback, err := knownhosts.New(d.KnownHosts)
+	if err != nil {
+		return fmt.Errorf("error loading %s: %v", d.KnownHosts, err)
+	}
+
+	d.config = &ssh.ClientConfig{
+		User:            d.User,
+		Auth:            auth,
+		HostKeyCallback: hostKeyCallback,
+		Timeout:         30 * time.Second,
+	}
+	return nil
+}
+
+func (d *SFTPDestination) Publish(pub *Publication) (string, error) {
+	conn, err := ssh.Dial("tcp", d.Address, d.config)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	client, err := sftp.NewClient(conn)
+	if err != nil {
+		return "", err
+	}
+	defer client.Close()
+
+	if d.Path != "" {
+		if err = client.MkdirAll(d.Path); err != nil {
+			return "", fmt.Errorf("error creating %s: %v", d.Path, err)
+		}
+	}
+
+	for _, file := range pub.Files {
+		fileName := path.Join(d.Path, file.Name)
+		fp, err := client.Create(fileName)
+		if err != nil {
+			return "", fmt.Errorf("error creating %s: %v", fileName, err)
+		}
+		_, err = fp.Write(file.Data)
+		fp.Close()
+		if err != nil {
+			return "", fmt.Errorf("error writing %s: %v", fileName, err)
+		}
+	}
+
+	if d.HTTP != "" {
+		return fileURL(d.HTTP, pub.Files[0].Name), nil
+	}
+	return fmt.Sprintf("sftp://%s@%s/%s", d.User, d.Address, path.Join(d.Path, pub.Files[0].Name)), nil
+}
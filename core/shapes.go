@@ -0,0 +1,2153 @@
+package core
+
+// outlines of the countries by ISO 3166-1 alpha-2 code, longitude and latitude pairs in tenths of
+// degree of the outer rings of their polygons, from the Natural Earth 1:110m admin 0 countries
+// (public domain), used to draw the map
+var countryShapes = map[string][][]int16{
+	"AE": {
+		{
+			516, 242, 518, 243, 518, 240, 526, 242, 534, 242, 540, 241, 547, 248, 554, 254, 561, 261, 563, 257,
+			564, 249, 559, 249, 558, 243, 560, 241, 555, 239, 555, 235, 552, 231, 552, 227, 550, 225, 520, 230,
+			516, 240,
+		},
+	},
+	"AF": {
+		{
+			665, 374, 671, 374, 678, 371, 681, 370, 689, 373, 692, 372, 695, 376, 701, 376, 703, 377, 704, 381,
+			708, 385, 713, 383, 712, 380, 715, 379, 714, 371, 718, 367, 722, 369, 726, 370, 733, 375, 739, 374,
+			750, 374, 752, 371, 746, 370, 741, 368, 729, 367, 718, 365, 713, 361, 715, 357, 716, 352, 711, 347,
+			712, 343, 709, 340, 699, 340, 703, 334, 697, 331, 693, 325, 693, 319, 689, 316, 686, 317, 678, 316,
+			677, 313, 669, 313, 664, 307, 663, 299, 650, 295, 644, 296, 641, 293, 636, 295, 625, 293, 609, 298,
+			618, 307, 617, 314, 609, 315, 609, 322, 605, 330, 610, 335, 605, 337, 608, 344, 612, 357, 622, 353,
+			630, 354, 632, 359, 640, 360, 645, 363, 647, 371, 656, 373, 657, 377, 662, 374,
+		},
+	},
+	"AL": {
+		{
+			210, 408, 210, 406, 207, 404, 206, 401, 202, 396, 200, 397, 200, 399, 194, 403, 193, 407, 194, 414,
+			195, 417, 194, 419, 193, 422, 197, 427, 198, 425, 201, 426, 203, 423, 205, 422, 206, 419, 205, 415,
+			206, 411,
+		},
+	},
+	"AM": {
+		{
+			465, 388, 461, 387, 457, 393, 457, 395, 453, 395, 450, 397, 448, 397, 444, 400, 437, 403, 438, 407,
+			436, 411, 450, 412, 452, 410, 456, 408, 454, 406, 459, 402, 456, 399, 460, 396, 465, 395,
+		},
+	},
+	"AO": {
+		{
+			130, -48, 126, -50, 125, -52, 124, -57, 122, -58, 119, -50, 123, -46, 126, -44,
+		},
+		{
+			123, -61, 127, -60, 130, -60, 134, -59, 163, -59, 166, -66, 169, -72, 171, -75, 175, -81, 181, -80,
+			185, -78, 190, -80, 192, -77, 194, -72, 200, -71, 201, -69, 206, -69, 205, -73, 217, -73, 217, -79,
+			219, -83, 218, -89, 219, -95, 222, -99, 222, -111, 224, -110, 228, -110, 235, -109, 239, -109, 240,
+			-112, 239, -117, 241, -122, 239, -126, 240, -129, 219, -129, 219, -161, 226, -169, 232, -175, 214,
+			-179, 190, -178, 183, -173, 142, -174, 141, -174, 135, -170, 128, -169, 122, -171, 117, -173, 116,
+			-167, 118, -158, 121, -149, 122, -144, 125, -135, 127, -131, 133, -125, 136, -120, 137, -113, 137,
+			-107, 134, -104, 131, -98, 129, -92, 129, -90, 132, -86, 129, -76, 127, -69, 122, -63,
+		},
+	},
+	"AQ": {
+		{
+			-487, -780, -482, -780, -467, -778, -452, -780, -439, -785, -435, -791, -434, -795, -433, -800,
+			-449, -803, -465, -806, -484, -808, -505, -810, -529, -810, -542, -806, -540, -802, -519, -799,
+			-510, -796, -504, -792, -499, -788, -493, -785,
+		},
+		{
+			-663, -803, -640, -803, -619, -804, -611, -800, -606, -796, -596, -800, -599, -805, -602, -810,
+			-623, -809, -645, -809, -657, -806, -657, -805,
+		},
+		{
+			-739, -713, -732, -712, -721, -712, -718, -707, -717, -703, -717, -695, -712, -690, -703, -689,
+			-697, -693, -695, -696, -691, -701, -687, -705, -685, -710, -683, -714, -685, -718, -688, -722,
+			-700, -723, -711, -725, -724, -725, -719, -721, -731, -722, -742, -724, -750, -721, -750, -717,
+		},
+		{
+			-1023, -719, -1017, -717, -1004, -719, -990, -719, -979, -721, -968, -720, -962, -725, -970, -724,
+			-982, -725, -994, -724, -1008, -725, -1018, -723,
+		},
+		{
+			-1226, -737, -1224, -733, -1212, -735, -1199, -737, -1187, -735, -1193, -738, -1202, -741, -1216,
+			-740,
+		},
+		{
+			-1273, -735, -1266, -732, -1256, -735, -1240, -739, -1246, -738, -1259, -737,
+		},
+		{
+			-1637, -786, -1631, -782, -1612, -784, -1602, -787, -1595, -790, -1592, -795, -1611, -796, -1624,
+			-793, -1630, -789, -1631, -789,
+		},
+		{
+			1800, -847, 1800, -900, -1800, -900, -1800, -847, -1799, -847, -1791, -841, -1773, -845, -1771,
+			-844, -1761, -841, -1759, -841, -1758, -841, -1744, -845, -1731, -841, -1729, -841, -1700, -839,
+			-1690, -841, -1685, -842, -1670, -846, -1642, -848, -1619, -851, -1581, -854, -1552, -851, -1509,
+			-853, -1485, -856, -1459, -853, -1431, -850, -1429, -846, -1468, -845, -1501, -843, -1509, -839,
+			-1536, -837, -1534, -832, -1530, -828, -1527, -825, -1529, -820, -1545, -818, -1553, -814, -1568,
+			-811, -1544, -812, -1521, -810, -1506, -813, -1489, -810, -1472, -807, -1464, -803, -1468, -799,
+			-1481, -797, -1495, -794, -1516, -793, -1534, -792, -1553, -791, -1560, -787, -1573, -784, -1581,
+			-780, -1584, -769, -1579, -770, -1570, -773, -1553, -772, -1537, -771, -1529, -775, -1513, -774,
+			-1500, -772, -1487, -769, -1476, -766, -1461, -765, -1461, -761, -1465, -757, -1462, -754, -1449,
+			-752, -1443, -755, -1428, -753, -1416, -751, -1402, -751, -1389, -750, -1375, -747, -1364, -745,
+			-1352, -743, -1344, -744, -1337, -744, -1323, -743, -1309, -745, -1296, -745, -1282, -743, -1269,
+			-744, -1254, -745, -1240, -745, -1226, -745, -1211, -745, -1197, -745, -1187, -742, -1175, -740,
+			-1162, -742, -1150, -741, -1139, -737, -1133, -740, -1129, -744, -1123, -747, -1113, -744, -1101,
+			-748, -1087, -749, -1076, -752, -1061, -751, -1049, -749, -1034, -750, -1020, -751, -1006, -753,
+			-1001, -749, -1008, -745, -1013, -742, -1025, -741, -1031, -737, -1033, -734, -1037, -726, -1029,
+			-728, -1016, -728, -1003, -728, -991, -729, -981, -732, -977, -736, -963, -736, -950, -735, -937,
+			-733, -924, -732, -914, -734, -901, -733, -892, -726, -884, -730, -873, -732, -860, -731, -852,
+			-735, -839, -735, -827, -736, -815, -739, -807, -735, -803, -731, -793, -735, -779, -734, -769,
+			-736, -762, -740, -749, -739, -739, -737, -728, -734, -716, -733, -702, -731, -689, -730, -680,
+			-728, -674, -725, -671, -720, -673, -716, -676, -712, -679, -709, -682, -705, -685, -701, -685,
+			-697, -684, -693, -680, -690, -676, -685, -674, -681, -676, -677, -677, -673, -673, -669, -667,
+			-666, -661, -662, -654, -659, -646, -656, -642, -652, -636, -649, -630, -646, -620, -646, -614,
+			-643, -607, -641, -599, -640, -592, -637, -586, -634, -578, -633, -572, -635, -576, -639, -586,
+			-642, -590, -644, -598, -642, -606, -643, -613, -645, -620, -648, -625, -651, -626, -655, -626,
+			-659, -621, -662, -628, -664, -637, -665, -643, -668, -649, -672, -655, -676, -657, -680, -653,
+			-684, -648, -687, -640, -689, -632, -692, -628, -696, -626, -700, -623, -704, -618, -707, -615,
+			-711, -614, -720, -611, -724, -610, -728, -607, -732, -608, -737, -614, -741, -620, -744, -633,
+			-746, -637, -749, -644, -753, -659, -756, -672, -758, -684, -760, -698, -762, -706, -766, -722,
+			-767, -740, -766, -756, -767, -772, -767, -769, -771, -754, -773, -743, -776, -737, -779, -748,
+			-782, -765, -781, -779, -784, -780, -788, -780, -792, -768, -795, -766, -799, -754, -803, -732,
+			-804, -714, -807, -700, -810, -682, -813, -657, -815, -633, -817, -616, -820, -597, -824, -587,
+			-828, -582, -832, -570, -829, -554, -826, -536, -823, -515, -820, -498, -817, -473, -817, -448,
+			-818, -428, -821, -422, -817, -408, -814, -382, -813, -363, -811, -344, -809, -323, -808, -301,
+			-806, -285, -803, -293, -800, -297, -796, -297, -793, -316, -793, -337, -795, -356, -795, -359,
+			-791, -358, -783, -353, -781, -339, -779, -322, -777, -310, -774, -298, -771, -289, -767, -275,
+			-765, -262, -764, -255, -763, -239, -762, -225, -761, -212, -759, -200, -757, -189, -754, -175,
+			-751, -166, -748, -157, -745, -154, -741, -165, -739, -161, -735, -154, -731, -144, -730, -133,
+			-727, -123, -724, -115, -720, -110, -715, -103, -713, -91, -713, -86, -717, -74, -717, -74, -713,
+			-69, -709, -58, -710, -55, -714, -43, -715, -30, -713, -18, -712, -7, -712, -2, -716, 9, -713, 19,
+			-711, 30, -710, 41, -709, 52, -706, 63, -705, 71, -702, 77, -699, 85, -701, 95, -700, 102, -705,
+			108, -708, 120, -706, 124, -702, 134, -700, 147, -700, 151, -704, 159, -700, 170, -699, 182, -699,
+			193, -699, 204, -700, 215, -701, 219, -704, 226, -707, 237, -705, 248, -705, 260, -705, 271, -705,
+			281, -703, 292, -702, 300, -699, 310, -698, 320, -697, 328, -694, 333, -688, 339, -685, 349, -687,
+			353, -690, 362, -692, 372, -692, 379, -695, 386, -698, 397, -695, 400, -691, 409, -689, 420, -686,
+			429, -685, 441, -683, 449, -681, 457, -678, 465, -676, 474, -677, 483, -674, 490, -671, 499, -671,
+			508, -669, 509, -665, 518, -662, 526, -661, 536, -659, 545, -658, 554, -659, 564, -660, 572, -662,
+			573, -667, 581, -670, 587, -673, 599, -674, 606, -677, 614, -680, 624, -680, 632, -678, 641, -674,
+			650, -676, 660, -677, 669, -679, 679, -679, 689, -679, 697, -690, 697, -692, 696, -697, 686, -699,
+			678, -703, 679, -707, 691, -707, 689, -711, 684, -714, 679, -719, 687, -722, 699, -723, 710, -721,
+			716, -717, 719, -713, 725, -710, 731, -707, 733, -704, 739, -699, 745, -698, 756, -697, 766, -696,
+			776, -695, 781, -691, 784, -687, 791, -683, 801, -681, 809, -679, 815, -675, 821, -674, 828, -672,
+			838, -673, 847, -672, 857, -671, 868, -672, 875, -669, 880, -662, 884, -665, 888, -670, 897, -672,
+			906, -672, 916, -671, 926, -672, 935, -672, 942, -671, 950, -672, 958, -674, 967, -672, 978, -672,
+			987, -671, 997, -672, 1004, -669, 1009, -666, 1016, -663, 1028, -656, 1035, -657, 1042, -660, 1049,
+			-663, 1062, -669, 1072, -670, 1081, -670, 1092, -668, 1102, -667, 1111, -664, 1117, -661, 1129,
+			-661, 1136, -659, 1144, -661, 1149, -664, 1156, -667, 1167, -667, 1174, -669, 1186, -672, 1198,
+			-673, 1209, -672, 1217, -669, 1223, -666, 1232, -665, 1241, -666, 1252, -667, 1261, -666, 1270,
+			-666, 1279, -667, 1288, -668, 1297, -666, 1308, -664, 1318, -664, 1329, -664, 1339, -663, 1348,
+			-662, 1350, -657, 1351, -653, 1357, -656, 1359, -660, 1362, -664, 1366, -668, 1375, -670, 1386,
+			-669, 1399, -669, 1408, -668, 1421, -668, 1431, -668, 1444, -668, 1455, -669, 1462, -672, 1460,
+			-676, 1466, -679, 1477, -681, 1488, -684, 1501, -686, 1515, -687, 1525, -689, 1536, -689, 1543,
+			-686, 1552, -688, 1559, -691, 1568, -694, 1580, -695, 1592, -696, 1597, -700, 1608, -702, 1616,
+			-706, 1627, -707, 1638, -707, 1649, -708, 1661, -708, 1673, -708, 1684, -710, 1695, -712, 1705,
+			-714, 1712, -717, 1711, -721, 1706, -724, 1701, -729, 1698, -732, 1693, -737, 1680, -738, 1674,
+			-742, 1661, -744, 1656, -748, 1650, -751, 1642, -755, 1638, -759, 1636, -762, 1635, -767, 1635,
+			-771, 1641, -775, 1643, -778, 1647, -782, 1666, -783, 1670, -788, 1652, -789, 1637, -791, 1618,
+			-792, 1609, -797, 1607, -802, 1603, -806, 1598, -809, 1611, -813, 1616, -817, 1625, -821, 1637,
+			-824, 1651, -827, 1666, -830, 1689, -833, 1694, -838, 1723, -840, 1725, -841, 1732, -844, 1760,
+			-842, 1783, -845,
+		},
+	},
+	"AR": {
+		{
+			-686, -526, -682, -531, -678, -538, -664, -544, -650, -547, -655, -552, -664, -552, -670, -549,
+			-676, -549, -686, -549,
+		},
+		{
+			-576, -302, -579, -310, -581, -320, -581, -330, -583, -333, -584, -339, -585, -344, -572, -353,
+			-574, -360, -567, -364, -568, -369, -577, -382, -592, -387, -612, -389, -623, -388, -621, -394,
+			-623, -402, -621, -407, -627, -410, -638, -412, -647, -408, -651, -411, -650, -421, -643, -424,
+			-638, -420, -635, -426, -644, -429, -652, -435, -653, -445, -656, -450, -665, -450, -673, -456,
+			-676, -463, -666, -470, -656, -472, -660, -481, -672, -487, -678, -499, -687, -503, -691, -507,
+			-688, -518, -681, -523, -686, -523, -695, -521, -719, -520, -723, -514, -723, -507, -730, -507,
+			-733, -504, -734, -493, -726, -489, -723, -482, -724, -477, -719, -469, -716, -456, -717, -450,
+			-712, -448, -713, -444, -718, -442, -715, -438, -719, -434, -721, -423, -717, -421, -719, -408,
+			-717, -398, -714, -389, -708, -386, -711, -376, -711, -367, -704, -360, -704, -352, -698, -342,
+			-698, -333, -701, -331, -705, -314, -699, -303, -700, -294, -697, -285, -690, -275, -683, -269,
+			-686, -265, -684, -262, -684, -245, -673, -240, -670, -230, -671, -227, -663, -218, -650, -221,
+			-644, -228, -640, -220, -628, -220, -627, -222, -608, -239, -600, -240, -588, -248, -578, -252,
+			-576, -256, -586, -271, -576, -274, -565, -275, -557, -274, -548, -266, -546, -257, -541, -255,
+			-536, -261, -536, -269, -545, -275, -552, -279, -563, -289,
+		},
+	},
+	"AT": {
+		{
+			170, 481, 169, 477, 163, 477, 165, 475, 162, 469, 160, 467, 151, 467, 146, 464, 138, 465, 124, 468,
+			122, 471, 112, 469, 110, 468, 104, 469, 99, 469, 95, 471, 96, 473, 96, 475, 99, 476, 104, 473, 105,
+			476, 114, 475, 121, 477, 126, 477, 129, 475, 130, 476, 129, 483, 132, 484, 136, 489, 143, 486, 149,
+			490, 153, 490, 160, 487, 165, 488, 170, 486, 169, 485,
+		},
+	},
+	"AU": {
+		{
+			1477, -408, 1483, -409, 1484, -421, 1480, -424, 1479, -432, 1476, -429, 1469, -436, 1467, -436,
+			1460, -435, 1454, -427, 1453, -420, 1447, -412, 1447, -407, 1454, -408, 1464, -411, 1469, -410,
+		},
+		{
+			1261, -322, 1251, -327, 1242, -330, 1240, -335, 1237, -339, 1228, -339, 1222, -340, 1213, -338,
+			1206, -339, 1199, -340, 1193, -345, 1190, -345, 1185, -347, 1180, -351, 1173, -350, 1166, -350,
+			1156, -344, 1150, -342, 1150, -336, 1155, -335, 1157, -333, 1157, -329, 1158, -322, 1157, -316,
+			1152, -306, 1150, -300, 1150, -295, 1146, -288, 1146, -285, 1142, -281, 1140, -273, 1135, -265,
+			1133, -261, 1138, -265, 1134, -256, 1139, -259, 1142, -263, 1142, -258, 1137, -250, 1136, -247,
+			1134, -244, 1135, -238, 1137, -236, 1138, -231, 1137, -225, 1141, -218, 1142, -225, 1146, -218,
+			1155, -215, 1159, -211, 1167, -207, 1172, -206, 1174, -207, 1182, -204, 1188, -203, 1190, -200,
+			1193, -200, 1198, -200, 1209, -197, 1214, -192, 1217, -187, 1222, -182, 1223, -178, 1223, -173,
+			1230, -164, 1234, -173, 1239, -171, 1235, -166, 1238, -161, 1243, -163, 1244, -156, 1249, -151,
+			1252, -147, 1257, -145, 1257, -142, 1261, -143, 1261, -141, 1266, -140, 1271, -138, 1278, -143,
+			1284, -149, 1290, -149, 1296, -150, 1294, -144, 1299, -136, 1303, -134, 1302, -131, 1306, -125,
+			1312, -122, 1317, -123, 1326, -121, 1326, -116, 1318, -113, 1324, -111, 1330, -114, 1336, -118,
+			1344, -120, 1347, -119, 1353, -122, 1359, -120, 1363, -120, 1365, -119, 1370, -124, 1367, -129,
+			1363, -133, 1360, -133, 1361, -137, 1358, -142, 1354, -147, 1355, -150, 1363, -156, 1371, -159,
+			1376, -162, 1383, -168, 1386, -168, 1391, -171, 1393, -174, 1402, -177, 1409, -174, 1411, -168,
+			1413, -164, 1414, -158, 1417, -150, 1416, -146, 1416, -143, 1415, -137, 1417, -129, 1418, -127,
+			1417, -124, 1419, -119, 1421, -113, 1421, -110, 1425, -107, 1428, -112, 1429, -118, 1431, -119,
+			1432, -123, 1435, -128, 1436, -134, 1436, -138, 1439, -145, 1446, -142, 1449, -146, 1454, -150,
+			1453, -154, 1455, -163, 1456, -168, 1459, -169, 1462, -178, 1461, -183, 1464, -190, 1475, -195,
+			1482, -200, 1488, -204, 1487, -206, 1493, -213, 1497, -223, 1501, -221, 1505, -226, 1507, -224,
+			1509, -235, 1516, -241, 1521, -245, 1529, -253, 1531, -261, 1532, -266, 1531, -273, 1536, -281,
+			1535, -290, 1533, -295, 1531, -304, 1531, -309, 1529, -316, 1525, -326, 1517, -330, 1513, -338,
+			1510, -343, 1507, -352, 1503, -357, 1501, -364, 1499, -371, 1500, -374, 1494, -378, 1483, -378,
+			1474, -382, 1469, -386, 1463, -390, 1455, -386, 1449, -384, 1450, -379, 1445, -381, 1436, -388,
+			1427, -385, 1422, -384, 1416, -383, 1406, -380, 1400, -374, 1398, -366, 1396, -361, 1391, -357,
+			1381, -356, 1384, -351, 1382, -344, 1377, -351, 1368, -353, 1374, -347, 1375, -341, 1379, -336,
+			1378, -329, 1370, -338, 1364, -341, 1360, -349, 1352, -345, 1352, -339, 1346, -332, 1341, -328,
+			1343, -326, 1330, -320, 1323, -320, 1313, -315, 1295, -316, 1282, -319, 1271, -323,
+		},
+	},
+	"AZ": {
+		{
+			464, 419, 467, 418, 474, 412, 478, 412, 480, 414, 486, 418, 491, 413, 496, 406, 501, 405, 504, 403,
+			496, 402, 494, 394, 492, 390, 489, 388, 489, 383, 486, 383, 480, 388, 484, 393, 481, 396, 477, 395,
+			465, 388, 465, 395, 460, 396, 456, 399, 459, 402, 454, 406, 456, 408, 452, 410, 450, 412, 452, 414,
+			460, 411, 465, 411, 466, 412, 461, 417,
+		},
+		{
+			461, 387, 455, 389, 450, 393, 448, 397, 450, 397, 453, 395, 457, 395, 457, 393,
+		},
+	},
+	"BA": {
+		{
+			186, 426, 177, 430, 173, 434, 169, 437, 165, 440, 162, 444, 158, 448, 160, 452, 163, 450, 165, 452,
+			170, 452, 179, 451, 186, 451, 190, 449, 194, 449, 191, 444, 196, 440, 195, 436, 192, 435, 190, 434,
+			187, 432,
+		},
+	},
+	"BD": {
+		{
+			927, 220, 927, 213, 923, 215, 924, 207, 921, 212, 920, 217, 918, 222, 914, 228, 905, 228, 906, 224,
+			903, 218, 898, 220, 897, 219, 894, 220, 890, 221, 889, 229, 885, 236, 887, 242, 881, 245, 883, 249,
+			889, 252, 882, 258, 886, 264, 894, 260, 898, 260, 899, 253, 909, 251, 918, 251, 924, 250, 919, 241,
+			915, 241, 912, 235, 917, 230, 919, 236, 921, 236,
+		},
+	},
+	"BE": {
+		{
+			62, 508, 60, 501, 58, 501, 57, 495, 48, 500, 43, 499, 36, 504, 31, 508, 27, 508, 25, 511, 33, 513,
+			40, 513, 50, 515, 56, 510,
+		},
+	},
+	"BF": {
+		{
+			-54, 104, -55, 110, -52, 114, -52, 117, -44, 125, -43, 132, -40, 135, -35, 133, -31, 135, -30, 138,
+			-22, 142, -20, 146, -11, 150, -5, 151, -3, 149, 4, 149, 3, 144, 4, 140, 10, 133, 10, 129, 22, 126,
+			22, 119, 19, 116, 14, 115, 12, 111, 9, 110, 0, 110, -4, 111, -8, 109, -12, 110, -29, 110, -30, 104,
+			-28, 96, -35, 99, -40, 99, -43, 96, -48, 98, -50, 102,
+		},
+	},
+	"BG": {
+		{
+			227, 442, 229, 438, 233, 439, 241, 437, 256, 437, 261, 439, 272, 442, 280, 438, 286, 437, 280, 433,
+			277, 426, 280, 420, 271, 421, 261, 418, 261, 413, 252, 412, 245, 416, 237, 413, 230, 413, 229, 420,
+			224, 423, 225, 425, 224, 426, 226, 429, 230, 432, 225, 436, 224, 440,
+		},
+	},
+	"BI": {
+		{
+			305, -24, 305, -28, 307, -30, 308, -34, 305, -36, 301, -41, 298, -45, 293, -45, 293, -33, 290, -28,
+			296, -29, 299, -23,
+		},
+	},
+	"BJ": {
+		{
+			27, 63, 19, 61, 16, 68, 17, 91, 15, 93, 14, 98, 11, 102, 8, 105, 9, 110, 12, 111, 14, 115, 19, 116,
+			22, 119, 25, 122, 28, 122, 36, 117, 36, 113, 38, 107, 36, 103, 37, 101, 32, 94, 29, 91, 27, 85, 27,
+			79,
+		},
+	},
+	"BN": {
+		{
+			1155, 54, 1154, 50, 1153, 43, 1149, 43, 1147, 40, 1142, 45, 1146, 49,
+		},
+	},
+	"BO": {
+		{
+			-695, -110, -688, -110, -683, -110, -680, -107, -672, -103, -666, -99, -653, -98, -654, -105, -653,
+			-109, -654, -116, -643, -125, -632, -126, -628, -130, -621, -132, -617, -135, -611, -135, -605,
+			-138, -605, -144, -603, -146, -603, -151, -605, -151, -602, -163, -582, -163, -584, -169, -583,
+			-173, -577, -176, -575, -182, -577, -190, -579, -194, -579, -200, -582, -202, -582, -199, -591,
+			-194, -600, -193, -618, -196, -623, -205, -623, -211, -627, -222, -628, -220, -640, -220, -644,
+			-228, -650, -221, -663, -218, -671, -227, -678, -229, -682, -215, -688, -204, -684, -194, -690,
+			-190, -691, -183, -696, -176, -690, -165, -694, -157, -692, -153, -693, -150, -689, -145, -689,
+			-136, -689, -129, -687, -126,
+		},
+	},
+	"BR": {
+		{
+			-534, -338, -537, -332, -532, -327, -538, -320, -546, -315, -556, -309, -560, -309, -570, -301,
+			-576, -302, -563, -289, -552, -279, -545, -275, -536, -269, -536, -261, -541, -255, -546, -257,
+			-544, -252, -543, -246, -543, -240, -547, -238, -550, -240, -554, -240, -555, -236, -556, -227,
+			-558, -224, -565, -221, -569, -223, -579, -221, -579, -207, -582, -202, -579, -200, -579, -194,
+			-577, -190, -575, -182, -577, -176, -583, -173, -584, -169, -582, -163, -602, -163, -605, -151,
+			-603, -151, -603, -146, -605, -144, -605, -138, -611, -135, -617, -135, -621, -132, -628, -130,
+			-632, -126, -643, -125, -654, -116, -653, -109, -654, -105, -653, -98, -666, -99, -672, -103, -680,
+			-107, -683, -110, -688, -110, -695, -110, -701, -111, -705, -110, -705, -95, -713, -101, -722,
+			-101, -726, -95, -732, -95, -730, -90, -736, -84, -740, -75, -737, -73, -737, -69, -731, -66, -732,
+			-61, -730, -57, -729, -53, -717, -46, -709, -44, -708, -43, -699, -43, -694, -16, -694, -11, -696,
+			-5, -700, -2, -700, 5, -695, 7, -693, 6, -692, 10, -698, 11, -698, 17, -679, 17, -675, 20, -673,
+			17, -671, 11, -669, 13, -663, 7, -655, 8, -654, 11, -646, 13, -642, 15, -641, 19, -634, 22, -634,
+			24, -643, 25, -644, 31, -644, 38, -648, 41, -646, 41, -639, 40, -631, 38, -628, 40, -621, 42, -610,
+			45, -606, 49, -607, 52, -602, 52, -600, 50, -601, 46, -598, 44, -595, 40, -598, 36, -600, 28, -597,
+			22, -596, 18, -590, 13, -585, 13, -584, 15, -581, 15, -577, 17, -573, 19, -568, 19, -565, 19, -560,
+			18, -559, 20, -561, 22, -560, 25, -556, 24, -551, 25, -545, 23, -541, 21, -538, 24, -536, 23, -534,
+			21, -529, 21, -526, 25, -522, 32, -517, 42, -513, 42, -511, 37, -505, 19, -500, 17, -499, 10, -507,
+			2, -504, -1, -486, -2, -486, -12, -478, -6, -466, -9, -449, -16, -444, -21, -446, -27, -434, -24,
+			-415, -29, -400, -29, -385, -37, -372, -48, -365, -51, -356, -51, -352, -55, -349, -67, -347, -73,
+			-351, -90, -356, -96, -370, -110, -377, -122, -384, -130, -387, -131, -390, -138, -389, -157, -392,
+			-172, -393, -179, -396, -183, -398, -196, -408, -209, -409, -219, -418, -224, -420, -230, -431,
+			-230, -446, -234, -454, -238, -465, -241, -476, -249, -485, -259, -486, -266, -485, -272, -487,
+			-282, -489, -287, -496, -292, -507, -310, -516, -318, -523, -322, -527, -332,
+		},
+	},
+	"BS": {
+		{
+			-790, 268, -785, 269, -778, 268, -778, 266, -789, 264,
+		},
+		{
+			-778, 270, -770, 266, -772, 259, -774, 260, -773, 265, -778, 269,
+		},
+		{
+			-782, 252, -779, 252, -775, 243, -775, 238, -778, 237, -780, 243, -784, 246,
+		},
+	},
+	"BT": {
+		{
+			917, 278, 921, 275, 920, 268, 912, 268, 904, 269, 897, 267, 888, 271, 888, 273, 895, 280, 900, 283,
+			907, 281, 913, 280,
+		},
+	},
+	"BW": {
+		{
+			294, -221, 280, -228, 271, -236, 268, -242, 265, -246, 259, -247, 258, -252, 257, -255, 250, -257,
+			242, -257, 237, -254, 233, -253, 228, -255, 226, -260, 221, -263, 216, -267, 209, -268, 207, -265,
+			208, -259, 202, -249, 199, -248, 199, -218, 209, -218, 209, -183, 217, -182, 232, -179, 236, -183,
+			242, -179, 245, -179, 251, -177, 253, -177, 256, -185, 259, -187, 262, -193, 273, -204, 277, -205,
+			277, -209, 280, -215, 288, -216,
+		},
+	},
+	"BY": {
+		{
+			282, 562, 292, 559, 294, 557, 299, 558, 309, 556, 310, 551, 308, 548, 314, 542, 318, 540, 317, 538,
+			324, 536, 327, 534, 323, 531, 315, 532, 313, 531, 315, 527, 318, 521, 309, 520, 306, 518, 306, 513,
+			302, 514, 293, 514, 290, 516, 286, 514, 282, 516, 275, 516, 263, 518, 253, 519, 246, 519, 240, 516,
+			235, 516, 235, 520, 232, 525, 238, 527, 238, 531, 235, 535, 235, 539, 245, 539, 255, 543, 258, 548,
+			266, 552, 265, 556, 271, 558,
+		},
+	},
+	"BZ": {
+		{
+			-891, 178, -892, 180, -890, 180, -888, 179, -885, 185, -883, 185, -883, 184, -881, 183, -881, 181,
+			-883, 176, -882, 175, -883, 171, -882, 170, -884, 165, -886, 163, -887, 162, -889, 159, -892, 159,
+			-892, 170,
+		},
+	},
+	"CA": {
+		{
+			-1228, 490, -1230, 490, -1249, 500, -1256, 504, -1274, 508, -1280, 517, -1279, 523, -1291, 528,
+			-1293, 536, -1305, 543, -1305, 548, -1300, 553, -1300, 559, -1317, 566, -1327, 577, -1334, 584,
+			-1343, 589, -1349, 593, -1355, 598, -1365, 595, -1375, 589, -1383, 596, -1390, 600, -1400, 603,
+			-1410, 603, -1410, 660, -1410, 697, -1391, 695, -1375, 690, -1365, 689, -1356, 693, -1344, 696,
+			-1329, 695, -1314, 699, -1298, 702, -1291, 698, -1284, 700, -1281, 705, -1274, 704, -1258, 695,
+			-1244, 702, -1243, 694, -1231, 696, -1227, 699, -1215, 698, -1199, 694, -1176, 690, -1162, 688,
+			-1152, 689, -1139, 684, -1153, 679, -1135, 677, -1108, 678, -1099, 680, -1089, 674, -1078, 679,
+			-1088, 683, -1082, 687, -1070, 687, -1062, 688, -1053, 686, -1043, 680, -1032, 681, -1015, 676,
+			-999, 678, -984, 678, -986, 684, -977, 686, -961, 682, -961, 673, -955, 681, -947, 681, -942, 691,
+			-953, 697, -965, 701, -964, 712, -952, 719, -939, 718, -929, 713, -915, 702, -924, 697, -905, 695,
+			-906, 685, -892, 693, -880, 686, -883, 679, -874, 672, -863, 679, -856, 688, -855, 699, -841, 698,
+			-826, 697, -813, 692, -812, 687, -820, 681, -813, 676, -814, 671, -833, 664, -847, 663, -858, 666,
+			-861, 661, -870, 652, -873, 648, -885, 641, -899, 640, -907, 636, -908, 630, -919, 628, -932, 620,
+			-942, 609, -946, 601, -947, 589, -932, 588, -928, 578, -923, 571, -909, 573, -890, 569, -880, 565,
+			-873, 560, -861, 557, -850, 553, -834, 552, -823, 551, -824, 543, -821, 533, -814, 522, -799, 512,
+			-791, 515, -786, 526, -791, 541, -798, 547, -782, 551, -771, 558, -765, 565, -766, 572, -773, 581,
+			-785, 588, -773, 599, -778, 608, -781, 623, -774, 626, -757, 623, -747, 622, -738, 624, -729, 621,
+			-717, 615, -714, 611, -696, 611, -696, 602, -693, 590, -684, 588, -676, 582, -662, 588, -652, 599,
+			-646, 603, -638, 594, -625, 582, -614, 570, -618, 563, -605, 558, -596, 552, -580, 549, -573, 546,
+			-569, 538, -562, 536, -558, 533, -557, 521, -564, 518, -571, 514, -588, 511, -600, 502, -617, 501,
+			-639, 503, -654, 503, -664, 502, -672, 495, -685, 491, -700, 477, -711, 468, -703, 470, -686, 483,
+			-666, 491, -651, 492, -642, 487, -651, 481, -648, 470, -645, 462, -632, 457, -615, 459, -605, 470,
+			-604, 463, -598, 459, -610, 453, -633, 447, -642, 443, -654, 435, -661, 436, -662, 445, -644, 453,
+			-660, 453, -671, 451, -678, 457, -678, 471, -682, 474, -689, 472, -692, 474, -700, 467, -703, 459,
+			-707, 455, -711, 453, -714, 453, -715, 450, -733, 450, -749, 450, -753, 448, -764, 441, -765, 440,
+			-768, 436, -777, 436, -787, 436, -792, 435, -790, 433, -789, 430, -789, 429, -802, 424, -813, 422,
+			-824, 417, -827, 417, -830, 418, -831, 420, -831, 421, -829, 424, -824, 430, -821, 436, -823, 444,
+			-826, 453, -836, 458, -835, 460, -836, 461, -839, 461, -841, 463, -841, 465, -843, 464, -846, 464,
+			-845, 465, -848, 466, -849, 469, -857, 472, -865, 476, -874, 479, -884, 483, -893, 480, -896, 480,
+			-908, 483, -916, 481, -926, 484, -936, 486, -943, 487, -946, 488, -948, 494, -952, 494, -952, 490,
+			-972, 490, -1006, 490, -1040, 490, -1070, 490, -1100, 490, -1130, 490, -1160, 490, -1170, 490,
+			-1200, 490,
+		},
+		{
+			-840, 625, -833, 629, -819, 629, -819, 627, -831, 622, -838, 622,
+		},
+		{
+			-798, 728, -809, 733, -808, 737, -804, 738, -781, 737, -763, 731, -763, 728, -773, 729, -784, 729,
+			-795, 727,
+		},
+		{
+			-803, 621, -799, 624, -795, 624, -793, 622, -797, 616, -801, 617, -804, 620,
+		},
+		{
+			-936, 750, -942, 746, -956, 747, -968, 749, -963, 754, -949, 756, -940, 753,
+		},
+		{
+			-938, 775, -943, 775, -962, 776, -964, 778, -944, 778, -937, 776,
+		},
+		{
+			-968, 788, -956, 784, -958, 781, -973, 779, -981, 781, -986, 785, -986, 789, -973, 788,
+		},
+		{
+			-882, 744, -898, 745, -924, 748, -928, 754, -929, 759, -939, 763, -960, 764, -971, 768, -967, 772,
+			-947, 771, -936, 768, -916, 768, -907, 764, -910, 761, -898, 758, -892, 756, -878, 756, -864, 755,
+			-848, 757, -828, 758, -811, 757, -801, 753, -798, 749, -805, 747, -819, 744, -832, 746, -861, 744,
+		},
+		{
+			-1113, 782, -1099, 780, -1102, 777, -1121, 774, -1135, 777, -1127, 781,
+		},
+		{
+			-1110, 788, -1097, 786, -1109, 784, -1125, 784, -1125, 786, -1115, 788,
+		},
+		{
+			-556, 513, -561, 507, -568, 498, -561, 502, -555, 499, -558, 496, -549, 493, -545, 496, -535, 492,
+			-538, 485, -531, 487, -530, 482, -526, 475, -531, 467, -535, 466, -542, 468, -540, 476, -542, 478,
+			-554, 469, -560, 469, -553, 474, -563, 476, -573, 476, -593, 476, -594, 479, -588, 483, -592, 485,
+			-584, 491, -574, 507, -567, 513, -559, 516, -554, 516,
+		},
+		{
+			-839, 651, -828, 648, -816, 645, -816, 640, -808, 641, -801, 637, -810, 634, -825, 637, -831, 641,
+			-841, 636, -855, 631, -859, 636, -872, 635, -864, 640, -862, 648, -859, 657, -852, 657, -850, 652,
+			-845, 654,
+		},
+		{
+			-788, 724, -778, 727, -756, 722, -742, 718, -741, 713, -722, 716, -712, 709, -688, 705, -679, 701,
+			-670, 692, -688, 687, -664, 681, -649, 678, -634, 669, -619, 669, -622, 662, -639, 650, -651, 654,
+			-667, 664, -680, 663, -681, 657, -671, 651, -657, 646, -653, 644, -647, 634, -650, 627, -663, 629,
+			-688, 637, -674, 629, -663, 623, -662, 619, -689, 623, -710, 629, -722, 634, -719, 637, -734, 642,
+			-748, 647, -748, 644, -777, 642, -786, 646, -779, 653, -760, 653, -740, 655, -743, 658, -739, 663,
+			-727, 673, -729, 677, -733, 681, -748, 686, -769, 689, -762, 691, -773, 698, -782, 698, -790, 702,
+			-795, 699, -813, 697, -849, 700, -871, 703, -887, 704, -895, 708, -885, 712, -899, 712, -902, 722,
+			-894, 731, -884, 735, -858, 738, -866, 732, -858, 725, -849, 733, -823, 738, -806, 727, -807, 721,
+		},
+		{
+			-945, 741, -924, 741, -905, 739, -920, 730, -932, 728, -943, 720, -954, 721, -960, 729, -960, 734,
+			-955, 739,
+		},
+		{
+			-1229, 761, -1212, 769, -1191, 775, -1176, 775, -1162, 776, -1163, 769, -1171, 765, -1180, 765,
+			-1199, 761, -1215, 759,
+		},
+		{
+			-1327, 540, -1317, 541, -1320, 530, -1312, 522, -1316, 522, -1322, 526, -1325, 531, -1331, 534,
+			-1332, 539, -1332, 542,
+		},
+		{
+			-1055, 793, -1035, 792, -1008, 788, -1001, 783, -997, 779, -1013, 780, -1029, 783, -1052, 784,
+			-1042, 787, -1054, 789,
+		},
+		{
+			-1235, 485, -1240, 484, -1257, 488, -1260, 492, -1269, 495, -1270, 498, -1281, 500, -1284, 505,
+			-1284, 508, -1273, 506, -1267, 504, -1258, 503, -1254, 500, -1249, 495, -1239, 491,
+		},
+		{
+			-1215, 744, -1201, 742, -1176, 742, -1166, 739, -1155, 735, -1168, 732, -1192, 725, -1205, 718,
+			-1205, 714, -1231, 709, -1236, 713, -1259, 719, -1255, 723, -1248, 730, -1239, 737, -1249, 743,
+		},
+		{
+			-1078, 758, -1069, 760, -1059, 760, -1057, 755, -1063, 750, -1097, 748, -1122, 744, -1137, 744,
+			-1139, 747, -1118, 752, -1163, 750, -1177, 752, -1163, 762, -1154, 765, -1126, 761, -1108, 755,
+			-1091, 755, -1105, 764, -1096, 768, -1085, 767, -1082, 762,
+		},
+		{
+			-1065, 731, -1054, 727, -1048, 717, -1045, 710, -1028, 705, -1010, 700, -1011, 696, -1027, 695,
+			-1021, 691, -1024, 688, -1042, 689, -1060, 692, -1071, 691, -1090, 688, -1115, 686, -1133, 685,
+			-1139, 690, -1152, 693, -1161, 692, -1173, 700, -1167, 701, -1151, 702, -1137, 702, -1124, 704,
+			-1144, 706, -1165, 705, -1179, 705, -1184, 709, -1161, 713, -1177, 713, -1194, 716, -1186, 723,
+			-1179, 727, -1152, 733, -1142, 731, -1147, 727, -1124, 730, -1111, 725, -1099, 730, -1090, 726,
+			-1082, 717, -1077, 721, -1084, 731, -1075, 732,
+		},
+		{
+			-1004, 727, -1015, 734, -1004, 738, -992, 736, -974, 738, -971, 735, -981, 730, -965, 726, -967,
+			717, -984, 713, -993, 714, -1000, 717, -1025, 725, -1025, 728,
+		},
+		{
+			-1066, 736, -1053, 736, -1045, 734, -1054, 728, -1069, 735,
+		},
+		{
+			-985, 767, -977, 763, -977, 757, -982, 750, -998, 749, -1009, 751, -1009, 756, -1025, 756, -1026,
+			763, -1015, 763, -1000, 766, -986, 766,
+		},
+		{
+			-960, 806, -953, 809, -943, 810, -947, 812, -924, 813, -911, 807, -894, 805, -878, 803, -870, 797,
+			-858, 793, -872, 790, -890, 783, -908, 782, -929, 783, -940, 788, -939, 791, -931, 794, -950, 794,
+			-961, 797, -967, 802,
+		},
+		{
+			-916, 819, -901, 821, -889, 821, -870, 823, -855, 827, -843, 826, -832, 823, -824, 829, -811, 830,
+			-793, 831, -762, 832, -757, 831, -728, 832, -707, 832, -685, 831, -658, 830, -637, 829, -618, 826,
+			-619, 824, -643, 819, -668, 817, -677, 815, -655, 815, -678, 809, -695, 806, -712, 798, -732, 796,
+			-739, 794, -769, 793, -755, 792, -762, 790, -754, 785, -763, 782, -779, 779, -784, 775, -798, 772,
+			-796, 770, -779, 770, -779, 768, -806, 762, -832, 765, -861, 763, -876, 764, -895, 765, -896, 770,
+			-878, 772, -883, 779, -876, 780, -850, 775, -863, 782, -880, 784, -872, 788, -854, 790, -851, 793,
+			-865, 797, -869, 803, -842, 802, -834, 801, -818, 805, -841, 806, -876, 805, -894, 809, -902, 813,
+			-914, 816,
+		},
+		{
+			-752, 674, -759, 671, -770, 671, -772, 676, -768, 681, -759, 683, -751, 680, -751, 676,
+		},
+		{
+			-963, 695, -956, 691, -963, 688, -976, 691, -984, 690, -998, 694, -989, 697, -982, 701, -972, 699,
+			-966, 697,
+		},
+		{
+			-645, 499, -642, 500, -629, 497, -618, 493, -618, 491, -623, 491, -636, 494,
+		},
+		{
+			-640, 470, -637, 466, -629, 464, -620, 464, -625, 460, -629, 460, -641, 464, -644, 467,
+		},
+	},
+	"CD": {
+		{
+			293, -45, 295, -54, 294, -59, 296, -65, 302, -71, 307, -83, 303, -82, 290, -84, 287, -85, 284, -92,
+			287, -96, 285, -108, 284, -118, 286, -120, 293, -124, 296, -122, 297, -133, 289, -132, 285, -127,
+			282, -123, 274, -121, 272, -116, 266, -119, 258, -118, 254, -113, 248, -112, 243, -113, 243, -110,
+			239, -109, 235, -109, 228, -110, 224, -110, 222, -111, 222, -99, 219, -95, 218, -89, 219, -83, 217,
+			-79, 217, -73, 205, -73, 206, -69, 201, -69, 200, -71, 194, -72, 192, -77, 190, -80, 185, -78, 181,
+			-80, 175, -81, 171, -75, 169, -72, 166, -66, 163, -59, 134, -59, 130, -60, 127, -60, 123, -61, 122,
+			-58, 124, -57, 125, -52, 126, -50, 130, -48, 133, -49, 136, -45, 141, -45, 142, -48, 146, -50, 152,
+			-43, 158, -39, 160, -35, 160, -27, 164, -17, 169, -12, 175, -7, 176, -4, 177, -1, 178, 3, 178, 9,
+			179, 17, 181, 24, 184, 29, 185, 35, 185, 42, 189, 47, 195, 50, 203, 47, 209, 43, 217, 42, 224, 40,
+			227, 46, 228, 47, 233, 46, 244, 51, 248, 49, 251, 49, 253, 52, 257, 53, 264, 52, 270, 51, 274, 52,
+			280, 44, 284, 43, 287, 45, 292, 44, 297, 46, 300, 42, 308, 35, 308, 23, 312, 22, 309, 18, 305, 16,
+			301, 11, 299, 6, 298, -2, 296, -6, 296, -13, 293, -16, 293, -22, 291, -23, 290, -28, 293, -33,
+		},
+	},
+	"CF": {
+		{
+			274, 52, 270, 51, 264, 52, 257, 53, 253, 52, 251, 49, 248, 49, 244, 51, 233, 46, 228, 47, 227, 46,
+			224, 40, 217, 42, 209, 43, 203, 47, 195, 50, 189, 47, 185, 42, 185, 35, 178, 36, 171, 37, 165, 32,
+			160, 23, 159, 26, 159, 30, 154, 33, 150, 39, 150, 42, 145, 47, 146, 50, 145, 55, 145, 62, 148, 64,
+			153, 74, 161, 75, 163, 78, 165, 77, 167, 75, 180, 79, 184, 83, 189, 86, 188, 90, 191, 91, 201, 90,
+			210, 95, 217, 106, 222, 110, 229, 111, 230, 107, 236, 101, 236, 97, 234, 93, 235, 90, 238, 87, 246,
+			82, 251, 78, 251, 75, 258, 70, 262, 65, 265, 59, 272, 56,
+		},
+	},
+	"CG": {
+		{
+			185, 35, 184, 29, 181, 24, 179, 17, 178, 9, 178, 3, 177, -1, 176, -4, 175, -7, 169, -12, 164, -17,
+			160, -27, 160, -35, 158, -39, 152, -43, 146, -50, 142, -48, 141, -45, 136, -45, 133, -49, 130, -48,
+			126, -44, 123, -46, 119, -50, 111, -40, 119, -34, 115, -28, 118, -25, 125, -24, 126, -19, 131, -24,
+			140, -25, 143, -20, 144, -13, 143, -6, 138, 0, 143, 12, 140, 14, 133, 13, 130, 18, 131, 23, 143,
+			22, 151, 20, 159, 17, 160, 23, 165, 32, 171, 37, 178, 36,
+		},
+	},
+	"CH": {
+		{
+			96, 475, 96, 473, 95, 471, 99, 469, 104, 469, 104, 465, 99, 463, 92, 464, 90, 460, 85, 460, 83,
+			462, 78, 458, 73, 458, 68, 460, 65, 464, 60, 463, 60, 467, 68, 473, 67, 475, 72, 474, 75, 476, 83,
+			476, 85, 478,
+		},
+	},
+	"CI": {
+		{
+			-80, 102, -79, 103, -76, 101, -69, 101, -67, 104, -65, 104, -62, 105, -61, 101, -58, 102, -54, 104,
+			-50, 102, -48, 98, -43, 96, -40, 99, -35, 99, -28, 96, -26, 82, -30, 74, -32, 63, -28, 54, -29, 50,
+			-33, 50, -40, 52, -46, 52, -58, 50, -65, 47, -75, 43, -77, 44, -76, 52, -75, 53, -76, 57, -80, 61,
+			-83, 62, -86, 65, -84, 69, -85, 74, -84, 77, -83, 77, -82, 81, -83, 83, -82, 85, -78, 86, -81, 94,
+			-83, 98, -82, 101,
+		},
+	},
+	"CL": {
+		{
+			-686, -526, -686, -549, -676, -549, -670, -549, -673, -553, -681, -556, -686, -556, -692, -555,
+			-700, -552, -710, -551, -723, -545, -733, -540, -747, -528, -738, -530, -724, -537, -711, -541,
+			-706, -536, -703, -529, -693, -525,
+		},
+		{
+			-696, -176, -691, -183, -690, -190, -684, -194, -688, -204, -682, -215, -678, -229, -671, -227,
+			-670, -230, -673, -240, -684, -245, -684, -262, -686, -265, -683, -269, -690, -275, -697, -285,
+			-700, -294, -699, -303, -705, -314, -701, -331, -698, -333, -698, -342, -704, -352, -704, -360,
+			-711, -367, -711, -376, -708, -386, -714, -389, -717, -398, -719, -408, -717, -421, -721, -423,
+			-719, -434, -715, -438, -718, -442, -713, -444, -712, -448, -717, -450, -716, -456, -719, -469,
+			-724, -477, -723, -482, -726, -489, -734, -493, -733, -504, -730, -507, -723, -507, -723, -514,
+			-719, -520, -695, -521, -686, -523, -695, -523, -699, -525, -708, -529, -710, -538, -714, -539,
+			-726, -535, -737, -528, -749, -523, -753, -516, -750, -510, -755, -504, -756, -487, -752, -477,
+			-741, -469, -756, -466, -747, -458, -744, -441, -732, -445, -727, -424, -734, -421, -737, -434,
+			-743, -432, -740, -418, -737, -399, -732, -393, -735, -383, -736, -372, -732, -371, -726, -355,
+			-719, -339, -714, -324, -717, -309, -714, -301, -715, -289, -709, -276, -707, -257, -704, -236,
+			-701, -214, -702, -198, -704, -183, -699, -181,
+		},
+	},
+	"CM": {
+		{
+			145, 129, 149, 122, 150, 116, 149, 109, 155, 100, 149, 100, 146, 99, 142, 100, 140, 95, 145, 90,
+			150, 88, 151, 84, 154, 77, 153, 74, 148, 64, 145, 62, 145, 55, 146, 50, 145, 47, 150, 42, 150, 39,
+			154, 33, 159, 30, 159, 26, 160, 23, 159, 17, 151, 20, 143, 22, 131, 23, 130, 23, 124, 22, 118, 23,
+			113, 23, 96, 23, 98, 31, 94, 37, 89, 39, 87, 44, 85, 45, 85, 48, 88, 55, 92, 64, 95, 65, 101, 70,
+			105, 71, 111, 66, 117, 70, 118, 74, 121, 78, 122, 83, 128, 87, 130, 94, 132, 96, 133, 102, 136,
+			108, 144, 116, 145, 119, 146, 121, 142, 125, 142, 128,
+		},
+	},
+	"CN": {
+		{
+			1095, 182, 1087, 185, 1086, 194, 1091, 198, 1102, 201, 1108, 201, 1110, 197, 1106, 193, 1103, 187,
+		},
+		{
+			803, 423, 802, 429, 809, 432, 800, 449, 819, 453, 825, 455, 832, 473, 852, 470, 857, 475, 858, 485,
+			866, 485, 874, 492, 878, 493, 880, 486, 889, 481, 903, 477, 910, 469, 906, 457, 909, 453, 921, 451,
+			935, 450, 947, 444, 953, 442, 958, 433, 963, 427, 975, 427, 995, 425, 1008, 427, 1018, 425, 1033,
+			419, 1045, 419, 1050, 416, 1061, 421, 1077, 425, 1092, 425, 1104, 429, 1111, 434, 1118, 437, 1117,
+			441, 1113, 445, 1119, 451, 1124, 450, 1135, 448, 1145, 453, 1160, 457, 1167, 464, 1174, 467, 1189,
+			468, 1197, 467, 1198, 470, 1189, 477, 1181, 481, 1173, 477, 1163, 479, 1157, 477, 1155, 481, 1162,
+			491, 1167, 499, 1179, 495, 1193, 501, 1193, 506, 1202, 516, 1207, 520, 1207, 525, 1202, 528, 1210,
+			533, 1222, 534, 1236, 535, 1251, 532, 1259, 528, 1266, 518, 1269, 514, 1273, 507, 1277, 498, 1294,
+			494, 1306, 487, 1310, 478, 1325, 478, 1334, 482, 1350, 485, 1345, 476, 1341, 472, 1338, 461, 1331,
+			451, 1319, 453, 1310, 450, 1313, 441, 1311, 429, 1306, 429, 1306, 424, 1300, 430, 1296, 424, 1281,
+			420, 1282, 415, 1273, 415, 1269, 418, 1262, 411, 1251, 406, 1243, 399, 1229, 396, 1221, 392, 1211,
+			389, 1216, 394, 1214, 398, 1222, 404, 1216, 409, 1208, 406, 1196, 399, 1190, 393, 1180, 392, 1175,
+			387, 1181, 381, 1189, 379, 1189, 374, 1197, 372, 1208, 379, 1217, 375, 1224, 375, 1225, 369, 1211,
+			367, 1206, 361, 1197, 356, 1192, 349, 1202, 344, 1206, 334, 1212, 325, 1219, 317, 1219, 309, 1213,
+			307, 1215, 301, 1221, 298, 1219, 290, 1217, 282, 1211, 281, 1204, 271, 1196, 257, 1187, 245, 1173,
+			236, 1159, 228, 1148, 227, 1142, 222, 1138, 225, 1132, 221, 1118, 216, 1108, 214, 1104, 203, 1099,
+			203, 1096, 210, 1099, 214, 1085, 217, 1081, 216, 1070, 218, 1066, 222, 1067, 228, 1058, 230, 1053,
+			234, 1045, 228, 1035, 227, 1027, 227, 1022, 225, 1017, 223, 1018, 212, 1013, 212, 1012, 214, 1012,
+			218, 1004, 216, 1000, 217, 992, 221, 995, 229, 989, 231, 987, 241, 976, 239, 977, 251, 987, 259,
+			987, 267, 987, 275, 982, 277, 979, 283, 973, 283, 962, 284, 966, 288, 961, 295, 954, 290, 946, 293,
+			934, 286, 925, 279, 917, 278, 913, 280, 907, 281, 900, 283, 895, 280, 888, 273, 887, 281, 881, 279,
+			870, 280, 858, 282, 850, 286, 842, 288, 839, 293, 833, 295, 823, 301, 815, 304, 811, 302, 797, 309,
+			787, 315, 785, 326, 792, 325, 792, 330, 788, 335, 789, 343, 778, 355, 762, 359, 759, 367, 752, 371,
+			750, 374, 748, 380, 749, 384, 743, 386, 739, 385, 737, 394, 740, 397, 738, 399, 748, 404, 755, 406,
+			765, 404, 769, 411, 782, 412, 785, 416, 801, 421,
+		},
+	},
+	"CO": {
+		{
+			-669, 13, -671, 11, -673, 17, -675, 20, -679, 17, -698, 17, -698, 11, -692, 10, -693, 6, -695, 7,
+			-700, 5, -700, -2, -696, -5, -694, -11, -694, -16, -699, -43, -704, -38, -707, -37, -700, -27,
+			-708, -23, -714, -23, -718, -22, -723, -24, -731, -23, -737, -13, -741, -10, -744, -5, -751, -1,
+			-754, -2, -758, 1, -763, 4, -766, 3, -774, 4, -777, 8, -779, 8, -789, 14, -790, 17, -786, 18, -787,
+			23, -784, 26, -779, 27, -775, 33, -771, 38, -775, 41, -773, 47, -775, 56, -773, 58, -775, 67, -779,
+			72, -778, 77, -774, 76, -772, 79, -775, 85, -774, 87, -768, 86, -761, 93, -757, 94, -757, 98, -755,
+			106, -749, 111, -743, 111, -742, 113, -734, 112, -726, 117, -722, 120, -718, 124, -714, 124, -711,
+			121, -713, 118, -720, 116, -722, 111, -726, 108, -729, 105, -730, 97, -733, 92, -728, 91, -727, 86,
+			-724, 84, -724, 80, -725, 76, -724, 74, -722, 73, -720, 70, -707, 71, -701, 70, -694, 61, -690, 62,
+			-683, 62, -677, 63, -673, 61, -675, 56, -677, 52, -678, 45, -676, 38, -673, 35, -673, 33, -678, 28,
+			-674, 26, -672, 23,
+		},
+	},
+	"CR": {
+		{
+			-825, 96, -829, 95, -829, 91, -827, 89, -829, 88, -828, 86, -829, 84, -830, 82, -835, 84, -837, 87,
+			-836, 88, -836, 91, -839, 93, -843, 95, -846, 96, -847, 99, -850, 101, -849, 98, -851, 96, -853,
+			98, -857, 99, -858, 101, -858, 104, -857, 108, -859, 109, -857, 111, -856, 112, -849, 110, -847,
+			111, -844, 110, -842, 108, -839, 107, -837, 109, -834, 104, -830, 100,
+		},
+	},
+	"CU": {
+		{
+			-823, 232, -814, 231, -806, 231, -797, 228, -793, 224, -783, 225, -780, 223, -771, 217, -765, 212,
+			-762, 212, -756, 210, -757, 207, -749, 207, -742, 203, -743, 201, -750, 199, -756, 199, -763, 200,
+			-778, 199, -771, 204, -775, 207, -781, 207, -785, 210, -787, 216, -793, 216, -802, 218, -805, 220,
+			-818, 222, -822, 224, -818, 226, -828, 227, -835, 222, -839, 222, -841, 219, -845, 218, -850, 219,
+			-844, 222, -842, 226, -838, 228, -833, 230, -825, 231,
+		},
+	},
+	"CY": {
+		{
+			327, 351, 329, 351, 332, 352, 334, 352, 335, 351, 335, 350, 337, 350, 339, 351, 340, 351, 340, 350,
+			330, 346, 325, 347, 323, 351,
+		},
+	},
+	"CZ": {
+		{
+			150, 511, 155, 508, 162, 507, 162, 504, 167, 502, 169, 505, 176, 504, 176, 500, 184, 500, 189, 495,
+			186, 495, 184, 493, 182, 493, 181, 490, 179, 490, 179, 489, 175, 488, 171, 488, 170, 486, 165, 488,
+			160, 487, 153, 490, 149, 490, 143, 486, 136, 489, 130, 493, 125, 495, 124, 500, 122, 503, 130, 505,
+			133, 507, 141, 509, 143, 511, 146, 510,
+		},
+	},
+	"DE": {
+		{
+			141, 538, 144, 532, 141, 530, 144, 526, 147, 521, 146, 517, 150, 511, 146, 510, 143, 511, 141, 509,
+			133, 507, 130, 505, 122, 503, 124, 500, 125, 495, 130, 493, 136, 489, 132, 484, 129, 483, 130, 476,
+			129, 475, 126, 477, 121, 477, 114, 475, 105, 476, 104, 473, 99, 476, 96, 475, 85, 478, 83, 476, 75,
+			476, 76, 483, 81, 490, 67, 492, 62, 495, 62, 499, 60, 501, 62, 508, 60, 519, 66, 519, 68, 522, 71,
+			531, 69, 535, 71, 537, 79, 537, 81, 535, 88, 540, 86, 544, 85, 550, 93, 548, 99, 550, 99, 546, 110,
+			544, 109, 540, 120, 542, 125, 545, 136, 541,
+		},
+	},
+	"DJ": {
+		{
+			424, 125, 428, 125, 431, 127, 433, 124, 433, 120, 427, 117, 431, 115, 428, 109, 426, 111, 423, 110,
+			418, 111, 417, 114, 417, 116, 420, 121,
+		},
+	},
+	"DK": {
+		{
+			99, 550, 93, 548, 85, 550, 81, 555, 81, 565, 83, 568, 85, 571, 94, 572, 98, 574, 106, 577, 105,
+			572, 102, 569, 104, 566, 109, 565, 107, 561, 104, 562, 96, 555,
+		},
+		{
+			124, 561, 127, 556, 121, 548, 110, 554, 109, 558,
+		},
+	},
+	"DO": {
+		{
+			-717, 180, -717, 183, -719, 186, -717, 188, -716, 192, -717, 197, -716, 199, -708, 199, -702, 196,
+			-700, 196, -698, 193, -692, 193, -693, 190, -688, 190, -683, 186, -687, 182, -692, 184, -696, 184,
+			-700, 184, -701, 182, -705, 182, -707, 184, -710, 183, -714, 176, -717, 178,
+		},
+	},
+	"DZ": {
+		{
+			-87, 274, -87, 276, -87, 277, -87, 288, -71, 296, -61, 297, -52, 300, -49, 305, -37, 309, -36, 316,
+			-31, 317, -26, 321, -13, 323, -11, 327, -14, 329, -17, 339, -18, 345, -22, 352, -12, 357, -1, 359,
+			5, 363, 15, 366, 32, 368, 48, 369, 53, 367, 63, 371, 73, 371, 77, 369, 84, 369, 82, 364, 84, 355,
+			81, 347, 75, 341, 76, 333, 84, 327, 84, 325, 91, 321, 95, 303, 98, 294, 99, 290, 97, 281, 98, 277,
+			96, 271, 97, 265, 93, 261, 99, 254, 99, 249, 103, 244, 108, 246, 116, 241, 120, 235, 86, 216, 57,
+			196, 43, 192, 32, 191, 31, 197, 27, 199, 21, 201, 18, 206, -16, 228, -49, 250,
+		},
+	},
+	"EC": {
+		{
+			-754, -2, -752, -9, -755, -16, -766, -26, -778, -30, -785, -39, -786, -45, -792, -50, -796, -45,
+			-800, -43, -804, -44, -805, -41, -802, -38, -803, -34, -798, -27, -800, -22, -804, -27, -810, -22,
+			-808, -20, -809, -11, -806, -9, -804, -3, -800, 4, -801, 8, -795, 10, -789, 14, -779, 8, -777, 8,
+			-774, 4, -766, 3, -763, 4, -758, 1,
+		},
+	},
+	"EE": {
+		{
+			280, 595, 281, 593, 274, 587, 277, 578, 273, 575, 265, 575, 256, 578, 252, 580, 243, 578, 244, 584,
+			241, 583, 234, 586, 233, 592, 246, 595, 259, 596, 269, 594,
+		},
+	},
+	"EG": {
+		{
+			369, 220, 329, 220, 290, 220, 250, 220, 250, 257, 250, 292, 247, 300, 250, 307, 248, 311, 252, 316,
+			265, 316, 275, 313, 285, 310, 289, 309, 297, 312, 301, 315, 310, 316, 317, 314, 320, 309, 322, 313,
+			330, 310, 338, 310, 343, 312, 348, 298, 349, 295, 346, 291, 344, 283, 342, 278, 339, 276, 336, 280,
+			331, 284, 324, 299, 323, 298, 327, 287, 333, 277, 341, 261, 345, 256, 348, 250, 357, 239, 355, 238,
+			355, 231, 367, 222,
+		},
+	},
+	"EH": {
+		{
+			-87, 277, -87, 276, -87, 274, -87, 259, -120, 259, -119, 234, -129, 233, -131, 228, -129, 213,
+			-168, 213, -171, 210, -170, 214, -148, 215, -146, 219, -142, 223, -139, 237, -125, 248, -120, 260,
+			-117, 261, -114, 269, -106, 270, -102, 269, -97, 269, -94, 271, -88, 271, -88, 277,
+		},
+	},
+	"ER": {
+		{
+			364, 144, 363, 148, 368, 163, 369, 170, 372, 173, 379, 174, 384, 180, 390, 168, 393, 159, 398, 154,
+			412, 145, 417, 139, 423, 133, 426, 130, 431, 127, 428, 125, 424, 125, 420, 129, 416, 135, 412, 138,
+			409, 141, 400, 145, 393, 145, 391, 147, 385, 145, 379, 150, 376, 142,
+		},
+	},
+	"ES": {
+		{
+			-75, 371, -75, 374, -72, 378, -70, 381, -74, 384, -71, 390, -75, 396, -71, 397, -70, 402, -69, 403,
+			-69, 411, -64, 414, -67, 419, -73, 419, -74, 418, -80, 418, -83, 423, -87, 421, -90, 419, -90, 426,
+			-94, 430, -80, 437, -68, 436, -54, 436, -43, 434, -35, 435, -19, 434, -15, 430, 3, 426, 7, 428, 18,
+			423, 30, 425, 30, 419, 21, 412, 8, 410, 7, 407, 1, 401, -3, 393, 1, 387, -5, 383, -7, 376, -14,
+			374, -21, 367, -34, 367, -44, 367, -50, 363, -54, 359, -59, 360, -62, 364, -65, 369,
+		},
+	},
+	"ET": {
+		{
+			478, 80, 450, 50, 437, 50, 428, 43, 421, 42, 419, 39, 412, 39, 408, 43, 399, 38, 396, 34, 389, 35,
+			387, 36, 384, 36, 381, 36, 369, 44, 362, 44, 358, 48, 358, 53, 353, 55, 347, 66, 343, 68, 341, 72,
+			336, 77, 330, 78, 333, 84, 338, 84, 340, 87, 340, 96, 343, 106, 347, 109, 348, 113, 353, 121, 359,
+			126, 363, 136, 364, 144, 376, 142, 379, 150, 385, 145, 391, 147, 393, 145, 400, 145, 409, 141, 412,
+			138, 416, 135, 420, 129, 424, 125, 420, 121, 417, 116, 417, 114, 418, 111, 423, 110, 426, 111, 428,
+			109, 426, 106, 429, 100, 433, 95, 437, 92, 469, 80,
+		},
+	},
+	"FI": {
+		{
+			286, 691, 284, 684, 300, 677, 291, 669, 302, 658, 295, 649, 304, 642, 300, 636, 315, 629, 311, 624,
+			302, 618, 281, 605, 263, 604, 245, 601, 229, 598, 223, 604, 213, 607, 215, 617, 211, 626, 215, 632,
+			224, 638, 247, 649, 254, 651, 253, 655, 239, 660, 236, 664, 235, 679, 220, 686, 206, 691, 212, 694,
+			224, 688, 237, 689, 247, 686, 257, 691, 262, 698, 277, 702, 290, 698,
+		},
+	},
+	"FJ": {
+		{
+			1800, -161, 1800, -166, 1794, -168, 1787, -170, 1786, -166, 1791, -164, 1794, -164,
+		},
+		{
+			1781, -175, 1784, -173, 1787, -176, 1786, -182, 1779, -183, 1774, -182, 1773, -177, 1777, -174,
+		},
+		{
+			-1798, -160, -1799, -165, -1800, -166, -1800, -161,
+		},
+	},
+	"FK": {
+		{
+			-612, -518, -600, -512, -592, -515, -586, -511, -578, -516, -580, -519, -594, -522, -598, -518,
+			-607, -523,
+		},
+	},
+	"FR": {
+		{
+			-517, 42, -522, 32, -526, 25, -529, 21, -534, 21, -536, 23, -538, 24, -541, 21, -545, 23, -543, 27,
+			-542, 32, -540, 36, -544, 42, -545, 49, -540, 58, -536, 56, -529, 54, -518, 46,
+		},
+		{
+			62, 495, 67, 492, 81, 490, 76, 483, 75, 476, 72, 474, 67, 475, 68, 473, 60, 467, 60, 463, 65, 464,
+			68, 460, 68, 457, 71, 453, 67, 450, 70, 443, 75, 441, 74, 437, 65, 431, 46, 434, 31, 431, 30, 425,
+			18, 423, 7, 428, 3, 426, -15, 430, -19, 434, -14, 440, -12, 460, -22, 471, -30, 476, -45, 480, -46,
+			487, -33, 489, -16, 486, -19, 498, -10, 493, 13, 501, 16, 509, 25, 511, 27, 508, 31, 508, 36, 504,
+			43, 499, 48, 500, 57, 495, 59, 494,
+		},
+		{
+			87, 426, 94, 430, 96, 422, 92, 414, 88, 416, 85, 423,
+		},
+	},
+	"GA": {
+		{
+			113, 23, 118, 23, 124, 22, 130, 23, 131, 23, 130, 18, 133, 13, 140, 14, 143, 12, 138, 0, 143, -6,
+			144, -13, 143, -20, 140, -25, 131, -24, 126, -19, 125, -24, 118, -25, 115, -28, 119, -34, 111, -40,
+			101, -30, 94, -21, 88, -11, 88, -8, 90, -5, 93, 3, 95, 10, 98, 11, 113, 11,
+		},
+	},
+	"GB": {
+		{
+			-62, 539, -70, 541, -76, 541, -74, 546, -76, 551, -67, 552, -57, 546,
+		},
+		{
+			-31, 534, -29, 540, -36, 546, -48, 548, -51, 551, -47, 555, -50, 558, -56, 553, -56, 563, -61, 568,
+			-58, 578, -50, 586, -42, 586, -30, 586, -41, 576, -31, 577, -20, 577, -22, 569, -31, 560, -21, 559,
+			-20, 558, -11, 546, -4, 545, 2, 533, 5, 529, 17, 527, 16, 521, 11, 518, 14, 513, 6, 508, -8, 508,
+			-25, 505, -30, 507, -36, 502, -45, 503, -52, 500, -58, 502, -43, 512, -34, 514, -50, 516, -53, 520,
+			-42, 523, -48, 528, -46, 535,
+		},
+	},
+	"GE": {
+		{
+			400, 434, 401, 436, 409, 434, 424, 432, 438, 427, 439, 426, 445, 427, 455, 425, 458, 421, 464, 419,
+			461, 417, 466, 412, 465, 411, 460, 411, 452, 414, 450, 412, 436, 411, 426, 416, 416, 415, 417, 420,
+			415, 426, 409, 430, 403, 431,
+		},
+	},
+	"GH": {
+		{
+			0, 110, 0, 107, 4, 102, 4, 95, 5, 87, 7, 83, 5, 74, 6, 69, 8, 63, 11, 59, -5, 53, -11, 50, -20, 47,
+			-29, 50, -28, 54, -32, 63, -30, 74, -26, 82, -28, 96, -30, 104, -29, 110, -12, 110, -8, 109, -4,
+			111,
+		},
+	},
+	"GL": {
+		{
+			-468, 826, -434, 832, -399, 832, -386, 835, -351, 836, -271, 835, -208, 827, -227, 823, -265, 823,
+			-319, 822, -314, 820, -279, 821, -248, 818, -229, 821, -221, 817, -232, 812, -206, 815, -158, 819,
+			-128, 817, -122, 813, -163, 806, -168, 804, -200, 802, -177, 801, -189, 794, -197, 788, -197, 776,
+			-185, 770, -200, 769, -217, 766, -198, 761, -196, 752, -207, 752, -194, 743, -216, 742, -204, 738,
+			-208, 735, -222, 733, -236, 733, -223, 726, -223, 722, -243, 726, -248, 723, -234, 721, -221, 715,
+			-218, 707, -235, 705, -243, 709, -255, 714, -252, 708, -264, 702, -237, 702, -223, 701, -250, 693,
+			-277, 685, -307, 681, -318, 681, -328, 677, -342, 667, -364, 660, -370, 659, -384, 657, -398, 655,
+			-407, 648, -407, 641, -412, 635, -428, 627, -424, 619, -429, 611, -434, 601, -448, 600, -463, 609,
+			-483, 609, -492, 614, -499, 624, -516, 636, -521, 643, -523, 652, -537, 661, -533, 668, -540, 672,
+			-530, 684, -515, 687, -511, 691, -509, 699, -520, 696, -526, 694, -535, 693, -547, 696, -548, 703,
+			-544, 708, -534, 708, -514, 706, -531, 712, -540, 715, -550, 714, -558, 717, -547, 726, -553, 730,
+			-561, 736, -573, 747, -586, 751, -586, 755, -613, 761, -634, 762, -661, 761, -685, 761, -697, 764,
+			-714, 770, -688, 773, -668, 774, -710, 776, -733, 780, -732, 784, -694, 789, -657, 794, -653, 798,
+			-680, 801, -672, 805, -637, 812, -622, 813, -627, 818, -603, 820, -572, 822, -541, 822, -530, 819,
+			-504, 824, -480, 821, -466, 820, -445, 817, -469, 822,
+		},
+	},
+	"GM": {
+		{
+			-167, 136, -156, 136, -154, 139, -151, 139, -147, 136, -144, 136, -140, 138, -138, 135, -143, 133,
+			-147, 133, -151, 135, -155, 133, -157, 133, -159, 131, -168, 132,
+		},
+	},
+	"GN": {
+		{
+			-137, 126, -132, 126, -125, 123, -123, 124, -122, 125, -117, 124, -115, 124, -115, 121, -113, 121,
+			-110, 122, -109, 122, -106, 119, -102, 118, -99, 121, -96, 122, -93, 123, -91, 123, -89, 121, -88,
+			118, -84, 114, -86, 111, -86, 108, -84, 109, -83, 108, -83, 105, -80, 102, -82, 101, -83, 98, -81,
+			94, -78, 86, -82, 85, -83, 83, -82, 81, -83, 77, -84, 77, -87, 77, -89, 73, -92, 73, -94, 75, -93,
+			79, -98, 85, -100, 84, -102, 84, -105, 83, -105, 87, -107, 90, -106, 93, -108, 97, -111, 100, -119,
+			100, -122, 99, -124, 98, -126, 96, -127, 93, -132, 89, -137, 95, -141, 99, -143, 100, -146, 102,
+			-147, 107, -148, 109, -151, 110, -147, 115, -144, 115, -141, 117, -139, 117, -137, 118, -138, 121,
+			-137, 122,
+		},
+	},
+	"GQ": {
+		{
+			96, 23, 113, 23, 113, 11, 98, 11, 95, 10, 93, 12,
+		},
+	},
+	"GR": {
+		{
+			263, 353, 262, 350, 247, 349, 247, 351, 235, 353, 237, 357, 242, 354, 250, 354, 258, 354, 257, 352,
+		},
+		{
+			230, 413, 237, 413, 245, 416, 252, 412, 261, 413, 261, 418, 266, 416, 263, 409, 261, 408, 254, 409,
+			249, 409, 237, 407, 244, 401, 239, 400, 233, 400, 228, 405, 226, 403, 228, 397, 234, 392, 230, 390,
+			235, 385, 240, 382, 240, 377, 231, 379, 234, 374, 228, 373, 232, 364, 225, 364, 217, 368, 213, 376,
+			211, 383, 207, 388, 202, 393, 202, 396, 206, 401, 207, 404, 210, 406, 210, 408, 217, 409, 221, 411,
+			226, 411, 228, 413,
+		},
+	},
+	"GT": {
+		{
+			-922, 145, -922, 148, -921, 151, -922, 153, -917, 161, -905, 161, -904, 164, -906, 165, -907, 167,
+			-911, 169, -915, 173, -910, 173, -910, 178, -901, 178, -891, 178, -892, 170, -892, 159, -889, 159,
+			-886, 157, -885, 159, -882, 157, -887, 153, -892, 151, -892, 149, -891, 147, -894, 144, -896, 144,
+			-895, 142, -897, 141, -901, 139, -901, 137, -906, 139, -912, 139, -917, 141,
+		},
+	},
+	"GW": {
+		{
+			-167, 124, -161, 125, -158, 125, -155, 126, -137, 126, -137, 122, -138, 121, -137, 118, -139, 117,
+			-141, 117, -144, 115, -147, 115, -151, 110, -157, 115, -161, 115, -163, 118, -163, 120, -166, 122,
+		},
+	},
+	"GY": {
+		{
+			-565, 19, -568, 19, -573, 19, -577, 17, -581, 15, -584, 15, -585, 13, -590, 13, -596, 18, -597, 22,
+			-600, 28, -598, 36, -595, 40, -598, 44, -601, 46, -600, 50, -602, 52, -607, 52, -614, 60, -611, 62,
+			-612, 67, -605, 69, -603, 70, -606, 74, -606, 78, -598, 84, -591, 80, -585, 73, -585, 68, -581, 68,
+			-575, 63, -571, 60, -573, 51, -579, 48, -579, 46, -580, 41, -576, 33, -573, 33, -572, 28,
+		},
+	},
+	"HN": {
+		{
+			-831, 150, -835, 150, -836, 149, -840, 147, -842, 147, -844, 146, -846, 147, -848, 148, -849, 148,
+			-851, 146, -852, 144, -855, 141, -857, 140, -858, 138, -861, 140, -863, 138, -865, 138, -868, 138,
+			-867, 133, -869, 133, -870, 130, -873, 130, -875, 133, -878, 134, -877, 138, -879, 139, -881, 140,
+			-885, 138, -885, 140, -888, 141, -891, 143, -894, 144, -891, 147, -892, 149, -892, 151, -887, 153,
+			-882, 157, -881, 157, -879, 159, -876, 159, -875, 158, -874, 158, -869, 158, -864, 158, -861, 159,
+			-860, 160, -857, 160, -854, 159, -852, 159, -850, 160, -845, 159, -844, 158, -841, 156, -838, 154,
+			-834, 153,
+		},
+	},
+	"HR": {
+		{
+			166, 465, 169, 464, 176, 460, 185, 458, 188, 459, 191, 455, 194, 452, 190, 449, 186, 451, 179, 451,
+			170, 452, 165, 452, 163, 450, 160, 452, 158, 448, 162, 444, 165, 440, 169, 437, 173, 434, 177, 430,
+			186, 426, 185, 425, 175, 428, 169, 432, 160, 435, 152, 442, 154, 443, 149, 447, 149, 451, 143, 452,
+			140, 448, 137, 451, 137, 455, 144, 455, 146, 456, 149, 455, 153, 455, 153, 457, 157, 458, 158, 462,
+		},
+	},
+	"HT": {
+		{
+			-717, 197, -716, 192, -717, 188, -719, 186, -717, 183, -717, 180, -724, 182, -728, 181, -735, 182,
+			-739, 180, -745, 183, -744, 187, -734, 185, -727, 184, -723, 187, -728, 191, -728, 195, -734, 196,
+			-732, 199, -726, 199,
+		},
+	},
+	"HU": {
+		{
+			221, 484, 226, 482, 227, 479, 221, 477, 216, 470, 210, 463, 202, 461, 196, 462, 188, 459, 185, 458,
+			176, 460, 169, 464, 166, 465, 164, 468, 162, 469, 165, 475, 163, 477, 169, 477, 170, 481, 175, 479,
+			179, 478, 187, 479, 188, 481, 192, 481, 197, 483, 198, 482, 202, 483, 205, 486, 208, 486, 219, 483,
+		},
+	},
+	"ID": {
+		{
+			1410, -26, 1410, -59, 1410, -91, 1401, -83, 1391, -81, 1389, -84, 1376, -84, 1380, -76, 1387, -73,
+			1384, -62, 1379, -54, 1360, -45, 1352, -45, 1337, -35, 1334, -40, 1330, -41, 1328, -37, 1328, -33,
+			1320, -28, 1331, -25, 1338, -25, 1337, -22, 1322, -22, 1318, -16, 1309, -14, 1305, -9, 1319, -7,
+			1324, -4, 1340, -8, 1341, -12, 1344, -28, 1355, -34, 1363, -23, 1374, -17, 1383, -17, 1392, -21,
+			1399, -24,
+		},
+		{
+			1250, -89, 1251, -91, 1251, -94, 1244, -101, 1236, -104, 1235, -102, 1236, -99, 1240, -93,
+		},
+		{
+			1342, -69, 1341, -61, 1343, -58, 1345, -54, 1347, -57, 1347, -62,
+		},
+		{
+			1179, 41, 1173, 32, 1180, 23, 1179, 18, 1190, 9, 1178, 8, 1175, 1, 1175, -8, 1166, -15, 1165, -25,
+			1161, -40, 1160, -37, 1149, -41, 1145, -35, 1138, -34, 1133, -31, 1121, -35, 1117, -30, 1110, -30,
+			1102, -29, 1101, -16, 1096, -13, 1091, -5, 1090, 4, 1091, 13, 1097, 20, 1098, 13, 1105, 8, 1112,
+			10, 1118, 9, 1124, 14, 1129, 15, 1138, 12, 1146, 14, 1151, 28, 1155, 32, 1159, 43, 1170, 43,
+		},
+		{
+			1294, -28, 1305, -31, 1308, -39, 1300, -34, 1292, -34, 1286, -34, 1279, -34, 1281, -28,
+		},
+		{
+			1269, -38, 1262, -36, 1260, -32, 1270, -31, 1272, -35,
+		},
+		{
+			1279, 22, 1280, 16, 1286, 15, 1287, 11, 1286, 3, 1281, 4, 1280, -3, 1284, -8, 1281, -9, 1277, -3,
+			1274, 10, 1276, 18,
+		},
+		{
+			1229, 9, 1241, 9, 1251, 16, 1252, 14, 1244, 4, 1237, 2, 1227, 4, 1211, 4, 1202, 2, 1200, -5, 1209,
+			-14, 1215, -10, 1233, -6, 1233, -11, 1228, -9, 1224, -15, 1215, -19, 1225, -32, 1223, -35, 1232,
+			-47, 1232, -53, 1226, -56, 1222, -53, 1227, -45, 1217, -49, 1215, -46, 1216, -42, 1209, -36, 1210,
+			-26, 1203, -29, 1204, -41, 1204, -55, 1198, -57, 1194, -54, 1197, -45, 1195, -35, 1191, -35, 1188,
+			-28, 1192, -21, 1193, -14, 1198, 2, 1200, 6, 1209, 13, 1217, 10,
+		},
+		{
+			1203, -103, 1190, -96, 1199, -94, 1204, -97, 1208, -100, 1207, -102,
+		},
+		{
+			1213, -85, 1220, -85, 1229, -81, 1228, -86, 1213, -89, 1199, -88, 1199, -84, 1207, -82,
+		},
+		{
+			1183, -84, 1189, -83, 1191, -87, 1180, -89, 1173, -90, 1167, -90, 1171, -85, 1176, -84, 1179, -81,
+		},
+		{
+			1085, -64, 1086, -68, 1105, -69, 1108, -65, 1126, -69, 1130, -76, 1145, -78, 1157, -84, 1146, -88,
+			1135, -83, 1126, -84, 1115, -83, 1106, -81, 1094, -77, 1087, -76, 1083, -78, 1065, -74, 1063, -69,
+			1054, -69, 1061, -59, 1073, -60, 1081, -63,
+		},
+		{
+			1044, -11, 1045, -18, 1049, -23, 1056, -24, 1061, -31, 1059, -43, 1058, -59, 1047, -59, 1039, -50,
+			1026, -42, 1022, -36, 1014, -28, 1009, -21, 1001, -7, 993, 2, 990, 10, 986, 18, 977, 25, 972, 33,
+			964, 39, 954, 50, 953, 55, 959, 54, 975, 52, 984, 43, 991, 36, 997, 32, 1006, 21, 1017, 21, 1025,
+			14, 1031, 6, 1038, 1, 1034, -7, 1040, -11,
+		},
+	},
+	"IE": {
+		{
+			-62, 539, -60, 532, -68, 523, -86, 517, -100, 518, -92, 529, -97, 539, -83, 547, -76, 551, -74,
+			546, -76, 541, -70, 541,
+		},
+	},
+	"IL": {
+		{
+			357, 327, 355, 324, 352, 325, 350, 319, 352, 318, 350, 316, 349, 314, 354, 315, 354, 311, 349, 295,
+			348, 298, 343, 312, 346, 315, 345, 316, 348, 321, 350, 328, 351, 331, 355, 331, 356, 333, 358, 333,
+			358, 329,
+		},
+	},
+	"IN": {
+		{
+			973, 283, 974, 279, 971, 277, 971, 271, 964, 273, 951, 266, 952, 260, 946, 252, 946, 247, 941, 239,
+			933, 241, 933, 230, 931, 227, 932, 223, 927, 220, 921, 236, 919, 236, 917, 230, 912, 235, 915, 241,
+			919, 241, 924, 250, 918, 251, 909, 251, 899, 253, 898, 260, 894, 260, 886, 264, 882, 258, 889, 252,
+			883, 249, 881, 245, 887, 242, 885, 236, 889, 229, 890, 221, 889, 217, 882, 217, 870, 215, 870, 207,
+			865, 202, 851, 195, 839, 183, 832, 177, 822, 170, 822, 166, 817, 163, 808, 160, 803, 159, 800, 151,
+			802, 138, 803, 130, 799, 121, 799, 104, 793, 103, 789, 95, 792, 92, 783, 89, 779, 83, 775, 80, 766,
+			89, 761, 103, 757, 113, 754, 118, 749, 127, 746, 140, 744, 146, 735, 160, 731, 179, 728, 192, 728,
+			204, 726, 214, 712, 208, 705, 209, 692, 221, 696, 225, 693, 228, 682, 237, 688, 244, 710, 244, 708,
+			252, 703, 257, 702, 265, 695, 269, 706, 280, 718, 279, 728, 290, 735, 300, 744, 310, 744, 317, 753,
+			323, 745, 328, 741, 334, 737, 343, 742, 347, 758, 345, 769, 347, 778, 355, 789, 343, 788, 335, 792,
+			330, 792, 325, 785, 326, 787, 315, 797, 309, 811, 302, 805, 297, 801, 288, 811, 284, 820, 279, 833,
+			274, 847, 272, 853, 267, 860, 266, 872, 264, 881, 264, 882, 268, 880, 274, 881, 279, 887, 281, 888,
+			273, 888, 271, 897, 267, 904, 269, 912, 268, 920, 268, 921, 275, 917, 278, 925, 279, 934, 286, 946,
+			293, 954, 290, 961, 295, 966, 288, 962, 284,
+		},
+	},
+	"IQ": {
+		{
+			392, 322, 388, 334, 410, 344, 414, 356, 413, 364, 418, 366, 423, 372, 428, 374, 439, 373, 443, 370,
+			448, 372, 454, 360, 461, 357, 462, 351, 456, 347, 454, 340, 461, 330, 473, 325, 478, 317, 477, 310,
+			480, 310, 480, 305, 486, 299, 480, 300, 473, 301, 466, 291, 447, 292, 419, 312, 404, 319,
+		},
+	},
+	"IR": {
+		{
+			486, 299, 480, 305, 480, 310, 477, 310, 478, 317, 473, 325, 461, 330, 454, 340, 456, 347, 462, 351,
+			461, 357, 454, 360, 448, 372, 442, 380, 444, 383, 441, 394, 448, 397, 450, 393, 455, 389, 461, 387,
+			465, 388, 477, 395, 481, 396, 484, 393, 480, 388, 486, 383, 489, 383, 492, 376, 501, 374, 508, 369,
+			523, 367, 538, 370, 539, 372, 548, 374, 555, 380, 562, 379, 566, 381, 573, 380, 584, 375, 592, 374,
+			604, 365, 611, 365, 612, 357, 608, 344, 605, 337, 610, 335, 605, 330, 609, 322, 609, 315, 617, 314,
+			618, 307, 609, 298, 614, 293, 618, 287, 627, 283, 628, 274, 632, 272, 633, 268, 619, 262, 615, 251,
+			596, 254, 585, 256, 574, 257, 570, 270, 565, 271, 557, 270, 547, 265, 535, 268, 525, 276, 515, 279,
+			509, 288, 501, 301, 496, 300, 489, 303,
+		},
+	},
+	"IS": {
+		{
+			-145, 665, -147, 658, -136, 651, -149, 644, -178, 637, -187, 635, -200, 636, -228, 640, -218, 644,
+			-240, 649, -222, 651, -222, 654, -243, 656, -237, 663, -221, 664, -206, 657, -191, 663, -178, 660,
+			-162, 665,
+		},
+	},
+	"IT": {
+		{
+			104, 469, 110, 468, 112, 469, 122, 471, 124, 468, 138, 465, 137, 460, 139, 456, 131, 457, 123, 454,
+			124, 449, 123, 446, 126, 441, 135, 436, 140, 428, 151, 420, 159, 420, 162, 417, 159, 415, 168, 412,
+			175, 409, 184, 404, 185, 402, 183, 398, 177, 403, 169, 404, 164, 398, 172, 394, 171, 389, 166, 388,
+			161, 380, 157, 379, 157, 382, 159, 388, 161, 390, 157, 395, 154, 400, 150, 402, 147, 406, 141, 408,
+			136, 412, 129, 413, 121, 417, 112, 424, 105, 429, 102, 439, 97, 440, 89, 444, 84, 442, 79, 438, 74,
+			437, 75, 441, 70, 443, 67, 450, 71, 453, 68, 457, 68, 460, 73, 458, 78, 458, 83, 462, 85, 460, 90,
+			460, 92, 464, 99, 463, 104, 465,
+		},
+		{
+			148, 381, 155, 382, 152, 374, 153, 371, 151, 366, 143, 370, 138, 371, 124, 376, 126, 381, 137, 380,
+		},
+		{
+			87, 409, 92, 412, 98, 405, 97, 392, 92, 392, 88, 389, 84, 392, 84, 404, 82, 410,
+		},
+	},
+	"JM": {
+		{
+			-776, 185, -769, 184, -764, 182, -762, 179, -769, 179, -772, 177, -778, 179, -783, 182, -782, 185,
+			-778, 185,
+		},
+	},
+	"JO": {
+		{
+			355, 324, 357, 327, 368, 323, 388, 334, 392, 322, 390, 320, 370, 315, 380, 305, 377, 303, 375, 300,
+			367, 299, 365, 295, 361, 292, 350, 294, 349, 295, 354, 311, 354, 315, 355, 318,
+		},
+	},
+	"JP": {
+		{
+			1419, 392, 1410, 382, 1410, 371, 1406, 363, 1408, 358, 1403, 351, 1390, 347, 1372, 346, 1358, 335,
+			1351, 338, 1351, 346, 1333, 344, 1322, 339, 1310, 339, 1320, 331, 1313, 315, 1307, 310, 1302, 314,
+			1304, 323, 1298, 326, 1294, 333, 1304, 336, 1309, 342, 1319, 347, 1326, 354, 1346, 357, 1357, 355,
+			1367, 373, 1374, 368, 1389, 378, 1394, 382, 1401, 394, 1399, 406, 1403, 412, 1414, 414, 1419, 400,
+		},
+		{
+			1446, 440, 1453, 444, 1455, 433, 1441, 430, 1432, 420, 1416, 427, 1411, 416, 1400, 416, 1398, 426,
+			1403, 433, 1414, 434, 1417, 448, 1420, 456, 1431, 445, 1439, 442,
+		},
+		{
+			1324, 335, 1329, 341, 1335, 339, 1339, 344, 1346, 341, 1348, 338, 1342, 332, 1338, 335, 1333, 333,
+			1330, 327, 1324, 330,
+		},
+	},
+	"KE": {
+		{
+			392, -47, 378, -37, 377, -31, 341, -11, 339, -10, 339, 1, 342, 5, 347, 12, 350, 19, 346, 31, 345,
+			36, 340, 42, 346, 48, 353, 55, 358, 53, 358, 48, 362, 44, 369, 44, 381, 36, 384, 36, 387, 36, 389,
+			35, 396, 34, 399, 38, 408, 43, 412, 39, 419, 39, 410, 28, 410, -9, 416, -17, 409, -21, 406, -25,
+			403, -26, 401, -33, 398, -37, 396, -43,
+		},
+	},
+	"KG": {
+		{
+			710, 423, 712, 427, 718, 428, 735, 425, 736, 431, 742, 433, 756, 429, 760, 430, 777, 430, 791, 429,
+			796, 425, 803, 423, 801, 421, 785, 416, 782, 412, 769, 411, 765, 404, 755, 406, 748, 404, 738, 399,
+			740, 397, 737, 394, 718, 393, 705, 396, 695, 395, 696, 401, 706, 399, 710, 402, 718, 401, 731, 409,
+			719, 414, 712, 411, 704, 415, 713, 422,
+		},
+	},
+	"KH": {
+		{
+			1026, 122, 1023, 134, 1030, 142, 1043, 144, 1052, 143, 1060, 139, 1065, 146, 1074, 142, 1076, 135,
+			1075, 123, 1058, 116, 1062, 110, 1052, 109, 1043, 105, 1035, 106, 1031, 112,
+		},
+	},
+	"KP": {
+		{
+			1306, 424, 1308, 422, 1304, 423, 1300, 419, 1297, 416, 1297, 409, 1292, 407, 1290, 405, 1286, 402,
+			1280, 400, 1275, 398, 1275, 393, 1274, 392, 1278, 391, 1283, 386, 1282, 384, 1278, 383, 1271, 383,
+			1267, 378, 1262, 378, 1262, 377, 1257, 379, 1256, 378, 1253, 377, 1252, 379, 1250, 379, 1247, 381,
+			1250, 385, 1252, 387, 1251, 388, 1254, 394, 1253, 396, 1247, 397, 1243, 399, 1251, 406, 1262, 411,
+			1269, 418, 1273, 415, 1282, 415, 1281, 420, 1296, 424, 1300, 430,
+		},
+	},
+	"KR": {
+		{
+			1262, 377, 1262, 378, 1267, 378, 1271, 383, 1278, 383, 1282, 384, 1283, 386, 1292, 374, 1295, 368,
+			1295, 356, 1291, 351, 1282, 349, 1274, 345, 1265, 344, 1264, 349, 1266, 357, 1261, 367, 1269, 369,
+		},
+	},
+	"KW": {
+		{
+			480, 300, 482, 295, 481, 293, 484, 286, 477, 285, 475, 290, 466, 291, 473, 301,
+		},
+	},
+	"KZ": {
+		{
+			874, 492, 866, 485, 858, 485, 857, 475, 852, 470, 832, 473, 825, 455, 819, 453, 800, 449, 809, 432,
+			802, 429, 803, 423, 796, 425, 791, 429, 777, 430, 760, 430, 756, 429, 742, 433, 736, 431, 735, 425,
+			718, 428, 712, 427, 710, 423, 704, 421, 691, 414, 686, 407, 683, 407, 680, 411, 667, 412, 665, 420,
+			660, 420, 661, 430, 649, 437, 632, 437, 620, 435, 611, 444, 602, 448, 587, 455, 585, 456, 559, 450,
+			560, 413, 555, 413, 548, 420, 541, 423, 529, 421, 525, 418, 524, 420, 527, 424, 525, 428, 513, 431,
+			509, 440, 503, 443, 503, 446, 513, 445, 513, 452, 522, 454, 530, 453, 532, 462, 530, 469, 520, 468,
+			512, 470, 500, 466, 491, 464, 486, 466, 487, 471, 481, 477, 473, 477, 465, 484, 470, 492, 468, 494,
+			475, 505, 486, 499, 487, 506, 508, 517, 523, 517, 545, 510, 557, 506, 568, 510, 584, 511, 596, 505,
+			599, 508, 613, 508, 616, 513, 600, 520, 609, 524, 607, 527, 617, 530, 610, 537, 614, 540, 652, 544,
+			657, 546, 682, 550, 691, 554, 709, 552, 712, 541, 722, 544, 735, 540, 734, 535, 744, 535, 769, 545,
+			765, 542, 778, 534, 800, 509, 806, 514, 819, 508, 834, 511, 839, 509, 844, 503, 851, 501, 855, 497,
+			868, 498,
+		},
+	},
+	"LA": {
+		{
+			1074, 142, 1065, 146, 1060, 139, 1052, 143, 1055, 147, 1056, 156, 1048, 164, 1047, 174, 1040, 182,
+			1032, 183, 1030, 180, 1024, 179, 1021, 181, 1011, 175, 1010, 184, 1013, 195, 1006, 195, 1005, 201,
+			1001, 204, 1003, 208, 1012, 214, 1013, 212, 1018, 212, 1017, 223, 1022, 225, 1028, 217, 1032, 208,
+			1044, 208, 1048, 199, 1042, 196, 1039, 193, 1051, 187, 1059, 175, 1066, 166, 1073, 159, 1076, 152,
+		},
+	},
+	"LB": {
+		{
+			358, 333, 356, 333, 355, 331, 351, 331, 355, 339, 360, 346, 364, 346, 366, 342, 361, 338,
+		},
+	},
+	"LK": {
+		{
+			818, 75, 816, 65, 812, 62, 803, 60, 799, 68, 797, 82, 801, 98, 808, 93, 813, 86,
+		},
+	},
+	"LR": {
+		{
+			-84, 77, -85, 74, -84, 69, -86, 65, -83, 62, -80, 61, -76, 57, -75, 53, -76, 52, -77, 44, -80, 44,
+			-90, 48, -99, 56, -108, 61, -114, 68, -112, 71, -111, 74, -107, 79, -102, 84, -100, 84, -98, 85,
+			-93, 79, -94, 75, -92, 73, -89, 73, -87, 77,
+		},
+	},
+	"LS": {
+		{
+			290, -290, 293, -293, 290, -297, 288, -301, 283, -302, 281, -305, 277, -306, 270, -299, 275, -292,
+			281, -289, 285, -286,
+		},
+	},
+	"LT": {
+		{
+			265, 556, 266, 552, 258, 548, 255, 543, 245, 539, 235, 539, 232, 542, 227, 543, 227, 546, 228, 549,
+			223, 550, 213, 552, 211, 560, 222, 563, 239, 563, 249, 564, 250, 562, 255, 561,
+		},
+	},
+	"LU": {
+		{
+			60, 501, 62, 499, 62, 495, 59, 494, 57, 495, 58, 501,
+		},
+	},
+	"LV": {
+		{
+			273, 575, 278, 572, 279, 568, 282, 562, 271, 558, 265, 556, 255, 561, 250, 562, 249, 564, 239, 563,
+			222, 563, 211, 560, 211, 568, 216, 574, 225, 578, 233, 570, 241, 570, 243, 578, 252, 580, 256, 578,
+			265, 575,
+		},
+	},
+	"LY": {
+		{
+			250, 220, 250, 200, 238, 200, 238, 196, 198, 215, 159, 234, 149, 229, 141, 225, 136, 230, 120, 235,
+			116, 241, 108, 246, 103, 244, 99, 249, 99, 254, 93, 261, 97, 265, 96, 271, 98, 277, 97, 281, 99,
+			290, 98, 294, 95, 303, 100, 305, 101, 310, 100, 314, 106, 318, 109, 321, 114, 324, 115, 331, 127,
+			328, 131, 329, 139, 327, 152, 323, 157, 314, 166, 312, 180, 308, 191, 303, 196, 305, 201, 310, 198,
+			318, 201, 322, 209, 327, 215, 328, 229, 326, 232, 322, 236, 322, 239, 320, 249, 319, 252, 316, 248,
+			311, 250, 307, 247, 300, 250, 292, 250, 257,
+		},
+	},
+	"MA": {
+		{
+			-22, 352, -18, 345, -17, 339, -14, 329, -11, 327, -13, 323, -26, 321, -31, 317, -36, 316, -37, 309,
+			-49, 305, -52, 300, -61, 297, -71, 296, -87, 288, -87, 277, -88, 277, -88, 271, -94, 271, -97, 269,
+			-102, 269, -106, 270, -114, 269, -117, 261, -120, 260, -125, 248, -139, 237, -142, 223, -146, 219,
+			-148, 215, -170, 214, -170, 219, -166, 222, -163, 227, -163, 230, -160, 237, -154, 244, -151, 245,
+			-148, 251, -148, 256, -144, 263, -138, 266, -131, 276, -131, 277, -126, 280, -117, 281, -109, 288,
+			-104, 291, -96, 299, -98, 312, -94, 320, -93, 326, -87, 332, -77, 337, -69, 341, -62, 351, -59,
+			358, -52, 358, -46, 353, -36, 354, -26, 352,
+		},
+	},
+	"MD": {
+		{
+			266, 482, 269, 484, 275, 485, 283, 482, 287, 481, 291, 478, 291, 475, 294, 473, 296, 469, 299, 467,
+			298, 465, 300, 464, 298, 463, 292, 464, 291, 465, 289, 464, 289, 463, 287, 459, 285, 456, 282, 455,
+			281, 459, 282, 464, 281, 468, 276, 474, 272, 478, 269, 481,
+		},
+	},
+	"ME": {
+		{
+			201, 426, 198, 425, 197, 427, 193, 422, 194, 419, 192, 420, 189, 423, 185, 425, 186, 426, 187, 432,
+			190, 434, 192, 435, 195, 434, 196, 432, 200, 431, 203, 429, 203, 428,
+		},
+	},
+	"MG": {
+		{
+			495, -125, 498, -129, 501, -136, 502, -148, 505, -152, 504, -157, 502, -160, 499, -154, 497, -157,
+			499, -165, 498, -169, 495, -171, 494, -180, 490, -191, 485, -205, 479, -224, 475, -238, 471, -249,
+			463, -252, 454, -256, 448, -253, 440, -250, 438, -245, 437, -236, 433, -228, 433, -221, 434, -213,
+			439, -212, 439, -208, 444, -201, 445, -194, 442, -190, 440, -183, 440, -174, 443, -169, 444, -162,
+			449, -162, 455, -160, 459, -158, 463, -158, 469, -152, 477, -146, 480, -141, 479, -137, 483, -138,
+			488, -131, 489, -125, 492, -120,
+		},
+	},
+	"MK": {
+		{
+			224, 423, 229, 420, 230, 413, 228, 413, 226, 411, 221, 411, 217, 409, 210, 408, 206, 411, 205, 415,
+			206, 419, 207, 418, 208, 421, 214, 422, 216, 422, 219, 423,
+		},
+	},
+	"ML": {
+		{
+			-115, 124, -115, 128, -116, 131, -119, 134, -121, 140, -122, 146, -118, 148, -117, 154, -113, 154,
+			-107, 151, -101, 153, -97, 153, -96, 155, -55, 155, -53, 162, -55, 163, -60, 206, -65, 250, -49,
+			250, -16, 228, 18, 206, 21, 201, 27, 199, 31, 197, 32, 191, 43, 192, 43, 169, 37, 162, 36, 156, 27,
+			154, 14, 153, 10, 150, 4, 149, -3, 149, -5, 151, -11, 150, -20, 146, -22, 142, -30, 138, -31, 135,
+			-35, 133, -40, 135, -43, 132, -44, 125, -52, 117, -52, 114, -55, 110, -54, 104, -58, 102, -61, 101,
+			-62, 105, -65, 104, -67, 104, -69, 101, -76, 101, -79, 103, -80, 102, -83, 105, -83, 108, -84, 109,
+			-86, 108, -86, 111, -84, 114, -88, 118, -89, 121, -91, 123, -93, 123, -96, 122, -99, 121, -102,
+			118, -106, 119, -109, 122, -110, 122, -113, 121, -115, 121,
+		},
+	},
+	"MM": {
+		{
+			1001, 204, 995, 202, 990, 198, 983, 197, 978, 186, 974, 184, 979, 176, 985, 168, 989, 162, 985,
+			153, 982, 151, 984, 146, 991, 138, 992, 133, 992, 128, 996, 119, 990, 110, 986, 99, 985, 107, 988,
+			114, 984, 120, 985, 131, 981, 136, 978, 148, 976, 161, 972, 169, 965, 164, 954, 157, 948, 158, 942,
+			160, 945, 173, 943, 182, 935, 194, 937, 197, 931, 199, 924, 207, 923, 215, 927, 213, 927, 220, 932,
+			223, 931, 227, 933, 230, 933, 241, 941, 239, 946, 247, 946, 252, 952, 260, 951, 266, 964, 273, 971,
+			271, 971, 277, 974, 279, 973, 283, 979, 283, 982, 277, 987, 275, 987, 267, 987, 259, 977, 251, 976,
+			239, 987, 241, 989, 231, 995, 229, 992, 221, 1000, 217, 1004, 216, 1012, 218, 1012, 214, 1003, 208,
+		},
+	},
+	"MN": {
+		{
+			878, 493, 888, 495, 907, 503, 922, 508, 931, 505, 941, 505, 948, 500, 958, 500, 973, 497, 982, 504,
+			978, 510, 989, 520, 1000, 516, 1009, 515, 1021, 513, 1023, 505, 1037, 501, 1046, 503, 1059, 504,
+			1069, 503, 1079, 498, 1085, 493, 1094, 493, 1107, 491, 1116, 494, 1129, 495, 1144, 502, 1150, 501,
+			1155, 498, 1167, 499, 1162, 491, 1155, 481, 1157, 477, 1163, 479, 1173, 477, 1181, 481, 1189, 477,
+			1198, 470, 1197, 467, 1189, 468, 1174, 467, 1167, 464, 1160, 457, 1145, 453, 1135, 448, 1124, 450,
+			1119, 451, 1113, 445, 1117, 441, 1118, 437, 1111, 434, 1104, 429, 1092, 425, 1077, 425, 1061, 421,
+			1050, 416, 1045, 419, 1033, 419, 1018, 425, 1008, 427, 995, 425, 975, 427, 963, 427, 958, 433, 953,
+			442, 947, 444, 935, 450, 921, 451, 909, 453, 906, 457, 910, 469, 903, 477, 889, 481, 880, 486,
+		},
+	},
+	"MR": {
+		{
+			-171, 210, -168, 213, -129, 213, -131, 228, -129, 233, -119, 234, -120, 259, -87, 259, -87, 274,
+			-49, 250, -65, 250, -60, 206, -55, 163, -53, 162, -55, 155, -96, 155, -97, 153, -101, 153, -107,
+			151, -113, 154, -117, 154, -118, 148, -122, 146, -128, 153, -134, 160, -141, 163, -146, 166, -151,
+			166, -156, 164, -161, 165, -165, 161, -165, 167, -163, 172, -161, 181, -163, 191, -164, 196, -163,
+			201, -165, 206,
+		},
+	},
+	"MW": {
+		{
+			328, -92, 337, -94, 339, -97, 343, -102, 346, -115, 343, -123, 346, -136, 349, -136, 353, -139,
+			357, -146, 358, -159, 353, -161, 350, -168, 344, -162, 343, -155, 345, -150, 345, -146, 341, -144,
+			338, -145, 332, -140, 327, -137, 330, -128, 333, -124, 331, -116, 333, -108, 335, -105, 332, -97,
+		},
+	},
+	"MX": {
+		{
+			-1171, 325, -1160, 326, -1147, 327, -1148, 325, -1133, 320, -1110, 313, -1090, 313, -1082, 313,
+			-1082, 318, -1065, 318, -1061, 314, -1056, 311, -1050, 306, -1047, 301, -1045, 296, -1039, 293,
+			-1031, 290, -1025, 298, -1017, 298, -1010, 294, -1005, 287, -1001, 281, -995, 275, -993, 268, -990,
+			264, -982, 261, -975, 258, -971, 259, -975, 250, -977, 243, -978, 229, -979, 224, -977, 219, -974,
+			214, -972, 206, -965, 199, -963, 193, -959, 188, -948, 186, -944, 181, -935, 184, -928, 185, -920,
+			187, -914, 189, -908, 193, -905, 199, -905, 207, -903, 210, -896, 213, -885, 215, -877, 215, -871,
+			215, -868, 213, -868, 208, -874, 203, -876, 196, -874, 195, -876, 190, -878, 183, -881, 185, -883,
+			185, -885, 185, -888, 179, -890, 180, -892, 180, -891, 178, -901, 178, -910, 178, -910, 173, -915,
+			173, -911, 169, -907, 167, -906, 165, -904, 164, -905, 161, -917, 161, -922, 153, -921, 151, -922,
+			148, -922, 145, -934, 156, -939, 159, -947, 162, -953, 161, -961, 158, -966, 157, -973, 159, -980,
+			161, -989, 166, -997, 167, -1008, 172, -1017, 176, -1019, 179, -1025, 180, -1035, 183, -1039, 187,
+			-1050, 193, -1055, 199, -1057, 204, -1054, 205, -1055, 208, -1053, 211, -1053, 214, -1056, 219,
+			-1057, 223, -1060, 228, -1069, 238, -1079, 245, -1084, 252, -1093, 256, -1094, 258, -1093, 264,
+			-1098, 267, -1104, 272, -1106, 279, -1112, 279, -1118, 285, -1122, 290, -1123, 293, -1128, 300,
+			-1132, 308, -1131, 312, -1139, 316, -1142, 315, -1148, 318, -1149, 314, -1148, 309, -1147, 302,
+			-1143, 298, -1136, 291, -1134, 288, -1133, 288, -1131, 284, -1130, 284, -1128, 278, -1125, 275,
+			-1122, 272, -1116, 267, -1113, 257, -1110, 253, -1107, 248, -1107, 243, -1102, 243, -1098, 238,
+			-1094, 234, -1094, 232, -1099, 228, -1100, 228, -1103, 234, -1109, 240, -1117, 245, -1122, 247,
+			-1121, 255, -1123, 260, -1128, 263, -1135, 268, -1136, 266, -1138, 269, -1145, 271, -1151, 277,
+			-1150, 278, -1146, 277, -1142, 281, -1142, 286, -1149, 293, -1155, 296, -1159, 302, -1163, 308,
+			-1167, 316,
+		},
+	},
+	"MY": {
+		{
+			1001, 65, 1003, 66, 1011, 62, 1012, 57, 1018, 58, 1021, 62, 1024, 61, 1030, 55, 1034, 49, 1034, 42,
+			1033, 37, 1034, 34, 1035, 28, 1039, 25, 1042, 16, 1042, 13, 1035, 12, 1026, 20, 1014, 28, 1013, 33,
+			1007, 39, 1006, 48, 1002, 53, 1003, 60,
+		},
+		{
+			1179, 41, 1170, 43, 1159, 43, 1155, 32, 1151, 28, 1146, 14, 1138, 12, 1129, 15, 1124, 14, 1118, 9,
+			1112, 10, 1105, 8, 1098, 13, 1097, 20, 1104, 17, 1112, 19, 1114, 27, 1118, 29, 1130, 31, 1137, 39,
+			1142, 45, 1147, 40, 1149, 43, 1153, 43, 1154, 50, 1155, 54, 1162, 61, 1167, 69, 1171, 69, 1176, 64,
+			1177, 60, 1183, 57, 1192, 54, 1191, 50, 1184, 50, 1186, 45,
+		},
+	},
+	"MZ": {
+		{
+			346, -115, 353, -114, 365, -117, 368, -116, 375, -116, 378, -113, 384, -113, 395, -109, 403, -103,
+			405, -108, 404, -118, 406, -126, 406, -142, 408, -147, 405, -154, 401, -161, 395, -167, 385, -171,
+			374, -176, 363, -187, 359, -188, 352, -196, 348, -198, 347, -205, 352, -213, 354, -218, 354, -221,
+			356, -221, 355, -231, 354, -235, 356, -237, 355, -241, 350, -245, 342, -248, 330, -254, 326, -257,
+			327, -261, 329, -262, 328, -267, 321, -267, 320, -263, 318, -258, 318, -255, 319, -244, 317, -237,
+			312, -223, 322, -211, 325, -204, 327, -203, 328, -197, 326, -194, 327, -187, 328, -180, 328, -167,
+			323, -164, 319, -163, 316, -161, 312, -159, 303, -159, 303, -155, 302, -148, 332, -140, 338, -145,
+			341, -144, 345, -146, 345, -150, 343, -155, 344, -162, 350, -168, 353, -161, 358, -159, 357, -146,
+			353, -139, 349, -136, 346, -136, 343, -123,
+		},
+	},
+	"NA": {
+		{
+			199, -248, 199, -285, 190, -290, 185, -290, 178, -289, 174, -288, 172, -284, 168, -281, 163, -286,
+			156, -278, 152, -271, 150, -261, 147, -254, 144, -239, 144, -227, 143, -221, 139, -217, 134, -209,
+			128, -197, 126, -190, 118, -181, 117, -173, 122, -171, 128, -169, 135, -170, 141, -174, 142, -174,
+			183, -173, 190, -178, 214, -179, 232, -175, 240, -173, 247, -174, 251, -176, 251, -177, 245, -179,
+			242, -179, 236, -183, 232, -179, 217, -182, 209, -183, 209, -218, 199, -218,
+		},
+	},
+	"NC": {
+		{
+			1658, -211, 1666, -217, 1671, -222, 1667, -224, 1662, -221, 1655, -217, 1648, -211, 1642, -204,
+			1640, -201, 1645, -201, 1650, -205, 1655, -208,
+		},
+	},
+	"NE": {
+		{
+			149, 229, 151, 213, 155, 210, 155, 207, 159, 204, 157, 200, 153, 179, 152, 166, 140, 157, 135, 144,
+			140, 140, 140, 134, 146, 133, 145, 129, 142, 128, 142, 125, 140, 125, 133, 136, 131, 136, 123, 130,
+			115, 133, 110, 134, 107, 132, 101, 133, 95, 129, 90, 128, 78, 133, 73, 131, 68, 131, 64, 135, 54,
+			139, 44, 137, 41, 135, 40, 130, 37, 126, 36, 117, 28, 122, 25, 122, 22, 119, 22, 126, 10, 129, 10,
+			133, 4, 140, 3, 144, 4, 149, 10, 150, 14, 153, 27, 154, 36, 156, 37, 162, 43, 169, 43, 192, 57,
+			196, 86, 216, 120, 235, 136, 230, 141, 225,
+		},
+	},
+	"NG": {
+		{
+			27, 63, 27, 79, 27, 85, 29, 91, 32, 94, 37, 101, 36, 103, 38, 107, 36, 113, 36, 117, 37, 126, 40,
+			130, 41, 135, 44, 137, 54, 139, 64, 135, 68, 131, 73, 131, 78, 133, 90, 128, 95, 129, 101, 133,
+			107, 132, 110, 134, 115, 133, 123, 130, 131, 136, 133, 136, 140, 125, 142, 125, 146, 121, 145, 119,
+			144, 116, 136, 108, 133, 102, 132, 96, 130, 94, 128, 87, 122, 83, 121, 78, 118, 74, 117, 70, 111,
+			66, 105, 71, 101, 70, 95, 65, 92, 64, 88, 55, 85, 48, 75, 44, 71, 45, 67, 42, 59, 43, 54, 49, 50,
+			56, 43, 63, 36, 63,
+		},
+	},
+	"NI": {
+		{
+			-837, 109, -839, 107, -842, 108, -844, 110, -847, 111, -849, 110, -856, 112, -857, 111, -861, 114,
+			-865, 118, -867, 121, -872, 125, -877, 129, -876, 131, -874, 129, -873, 130, -870, 130, -869, 133,
+			-867, 133, -868, 138, -865, 138, -863, 138, -861, 140, -858, 138, -857, 140, -855, 141, -852, 144,
+			-851, 146, -849, 148, -848, 148, -846, 147, -844, 146, -842, 147, -840, 147, -836, 149, -835, 150,
+			-831, 150, -832, 149, -833, 147, -832, 143, -834, 140, -835, 136, -836, 131, -835, 129, -835, 124,
+			-836, 123, -837, 119, -837, 116, -839, 114, -838, 111,
+		},
+	},
+	"NL": {
+		{
+			69, 535, 71, 531, 68, 522, 66, 519, 60, 519, 62, 508, 56, 510, 50, 515, 40, 513, 33, 513, 38, 516,
+			47, 531, 61, 535,
+		},
+	},
+	"NO": {
+		{
+			151, 797, 155, 800, 170, 801, 183, 797, 215, 790, 190, 786, 185, 778, 176, 776, 171, 768, 159, 768,
+			138, 774, 147, 777, 132, 780, 112, 789, 104, 797, 132, 800, 137, 797,
+		},
+		{
+			311, 696, 294, 692, 286, 691, 290, 698, 277, 702, 262, 698, 257, 691, 247, 686, 237, 689, 224, 688,
+			212, 694, 206, 691, 200, 691, 199, 684, 180, 686, 177, 680, 168, 680, 161, 673, 151, 662, 136, 648,
+			139, 644, 136, 640, 126, 641, 119, 631, 120, 618, 126, 613, 123, 601, 115, 594, 110, 589, 104, 595,
+			84, 583, 70, 581, 57, 586, 53, 597, 50, 620, 59, 626, 86, 635, 105, 645, 124, 659, 148, 678, 164,
+			686, 192, 698, 214, 703, 230, 702, 245, 710, 264, 710, 282, 712, 313, 705, 300, 702,
+		},
+		{
+			274, 801, 259, 795, 230, 794, 201, 796, 199, 798, 185, 799, 174, 803, 205, 806, 219, 804, 229, 807,
+			254, 804,
+		},
+		{
+			247, 779, 225, 774, 207, 777, 214, 779, 208, 783, 229, 785, 233, 781,
+		},
+	},
+	"NP": {
+		{
+			881, 279, 880, 274, 882, 268, 881, 264, 872, 264, 860, 266, 853, 267, 847, 272, 833, 274, 820, 279,
+			811, 284, 801, 288, 805, 297, 811, 302, 815, 304, 823, 301, 833, 295, 839, 293, 842, 288, 850, 286,
+			858, 282, 870, 280,
+		},
+	},
+	"NZ": {
+		{
+			1769, -401, 1765, -406, 1760, -413, 1752, -417, 1751, -414, 1747, -413, 1752, -405, 1749, -399,
+			1738, -395, 1739, -391, 1746, -388, 1747, -380, 1747, -374, 1743, -367, 1743, -365, 1738, -361,
+			1731, -352, 1726, -345, 1730, -345, 1736, -350, 1743, -353, 1746, -362, 1753, -372, 1754, -365,
+			1758, -368, 1760, -376, 1768, -379, 1774, -380, 1780, -376, 1785, -377, 1783, -386, 1780, -392,
+			1772, -391, 1769, -394, 1770, -399,
+		},
+		{
+			1697, -436, 1705, -430, 1711, -425, 1716, -418, 1719, -415, 1721, -410, 1728, -405, 1730, -409,
+			1732, -413, 1740, -409, 1742, -413, 1742, -418, 1739, -422, 1732, -430, 1727, -434, 1731, -439,
+			1723, -439, 1715, -442, 1712, -449, 1706, -459, 1698, -464, 1693, -466, 1684, -466, 1678, -463,
+			1667, -462, 1665, -459, 1670, -451, 1683, -441, 1689, -439,
+		},
+	},
+	"OM": {
+		{
+			552, 227, 552, 231, 555, 235, 555, 239, 560, 241, 558, 243, 559, 249, 564, 249, 568, 242, 574, 239,
+			581, 237, 587, 236, 592, 230, 595, 227, 598, 225, 598, 223, 594, 217, 593, 214, 589, 211, 585, 204,
+			580, 205, 578, 202, 577, 197, 578, 191, 577, 189, 572, 189, 566, 186, 565, 181, 563, 179, 557, 179,
+			553, 176, 553, 172, 548, 170, 542, 170, 536, 167, 531, 167, 528, 173, 520, 190, 550, 200, 557, 220,
+		},
+		{
+			563, 257, 561, 261, 564, 264, 565, 263, 564, 259,
+		},
+	},
+	"PA": {
+		{
+			-774, 87, -775, 85, -772, 79, -774, 76, -778, 77, -779, 72, -782, 75, -784, 81, -782, 83, -784, 84,
+			-786, 87, -791, 90, -796, 89, -798, 86, -802, 83, -804, 83, -805, 81, -800, 75, -803, 74, -804, 73,
+			-809, 72, -811, 78, -812, 76, -815, 77, -817, 81, -821, 82, -824, 83, -828, 83, -829, 81, -830, 82,
+			-829, 84, -828, 86, -829, 88, -827, 89, -829, 91, -829, 95, -825, 96, -822, 92, -822, 90, -818, 90,
+			-817, 90, -814, 88, -809, 89, -805, 91, -799, 93, -796, 96, -790, 96, -791, 95, -785, 94, -781, 92,
+			-777, 89,
+		},
+	},
+	"PE": {
+		{
+			-699, -43, -708, -43, -709, -44, -717, -46, -729, -53, -730, -57, -732, -61, -731, -66, -737, -69,
+			-737, -73, -740, -75, -736, -84, -730, -90, -732, -95, -726, -95, -722, -101, -713, -101, -705,
+			-95, -705, -110, -701, -111, -695, -110, -687, -126, -689, -129, -689, -136, -689, -145, -693,
+			-150, -692, -153, -694, -157, -690, -165, -696, -176, -699, -181, -704, -183, -714, -178, -715,
+			-174, -734, -164, -752, -153, -760, -146, -764, -138, -763, -135, -771, -122, -781, -104, -790,
+			-84, -794, -79, -798, -72, -805, -65, -812, -61, -809, -57, -814, -47, -811, -40, -803, -34, -802,
+			-38, -805, -41, -804, -44, -800, -43, -796, -45, -792, -50, -786, -45, -785, -39, -778, -30, -766,
+			-26, -755, -16, -752, -9, -754, -2, -751, -1, -744, -5, -741, -10, -737, -13, -731, -23, -723, -24,
+			-718, -22, -714, -23, -708, -23, -700, -27, -707, -37, -704, -38,
+		},
+	},
+	"PG": {
+		{
+			1410, -26, 1427, -33, 1446, -39, 1453, -44, 1458, -49, 1460, -55, 1476, -61, 1479, -66, 1470, -67,
+			1472, -74, 1481, -80, 1487, -91, 1493, -91, 1493, -95, 1500, -97, 1497, -99, 1508, -103, 1507,
+			-106, 1500, -107, 1498, -104, 1489, -103, 1479, -101, 1471, -95, 1466, -89, 1460, -81, 1447, -76,
+			1439, -79, 1433, -82, 1434, -90, 1426, -93, 1421, -92, 1410, -91, 1410, -59,
+		},
+		{
+			1526, -37, 1530, -40, 1531, -45, 1528, -48, 1526, -42, 1524, -38, 1520, -35, 1514, -30, 1507, -27,
+			1509, -25, 1515, -28, 1518, -30, 1522, -32,
+		},
+		{
+			1513, -58, 1508, -61, 1502, -63, 1497, -63, 1489, -60, 1483, -57, 1484, -54, 1493, -56, 1498, -55,
+			1500, -50, 1501, -50, 1502, -55, 1508, -55, 1511, -51, 1516, -48, 1515, -42, 1521, -41, 1523, -43,
+			1523, -49, 1520, -55, 1515, -56,
+		},
+		{
+			1548, -53, 1551, -56, 1555, -62, 1560, -65, 1559, -68, 1556, -69, 1552, -65, 1547, -59, 1545, -51,
+			1547, -50,
+		},
+	},
+	"PH": {
+		{
+			1208, 127, 1203, 135, 1212, 134, 1215, 131, 1213, 122,
+		},
+		{
+			1226, 100, 1228, 103, 1229, 109, 1235, 109, 1233, 103, 1241, 112, 1240, 103, 1236, 100, 1233, 93,
+			1230, 90, 1224, 97,
+		},
+		{
+			1264, 84, 1265, 78, 1265, 72, 1262, 63, 1258, 73, 1254, 68, 1257, 60, 1254, 56, 1242, 62, 1239, 69,
+			1242, 74, 1236, 78, 1233, 74, 1228, 75, 1221, 69, 1219, 72, 1223, 80, 1229, 83, 1235, 87, 1238, 82,
+			1246, 85, 1248, 90, 1255, 90, 1254, 98, 1262, 93, 1263, 88,
+		},
+		{
+			1185, 93, 1172, 84, 1177, 91, 1184, 97, 1190, 104, 1195, 114, 1197, 106, 1190, 100,
+		},
+		{
+			1223, 182, 1222, 178, 1225, 171, 1223, 163, 1217, 159, 1215, 151, 1217, 143, 1223, 142, 1227, 143,
+			1240, 138, 1239, 132, 1242, 130, 1241, 125, 1233, 130, 1229, 136, 1227, 132, 1220, 138, 1211, 136,
+			1206, 139, 1207, 143, 1210, 145, 1207, 148, 1206, 144, 1201, 150, 1199, 154, 1199, 164, 1203, 160,
+			1204, 176, 1207, 185, 1213, 185, 1219, 182, 1222, 185,
+		},
+		{
+			1220, 114, 1219, 119, 1225, 116, 1231, 116, 1231, 112, 1226, 107, 1220, 104, 1220, 109,
+		},
+		{
+			1255, 122, 1258, 110, 1250, 113, 1250, 110, 1253, 104, 1248, 101, 1248, 108, 1245, 109, 1243, 115,
+			1249, 114, 1249, 118, 1243, 126, 1252, 125,
+		},
+	},
+	"PK": {
+		{
+			778, 355, 769, 347, 758, 345, 742, 347, 737, 343, 741, 334, 745, 328, 753, 323, 744, 317, 744, 310,
+			735, 300, 728, 290, 718, 279, 706, 280, 695, 269, 702, 265, 703, 257, 708, 252, 710, 244, 688, 244,
+			682, 237, 674, 239, 671, 247, 664, 254, 645, 252, 629, 252, 615, 251, 619, 262, 633, 268, 632, 272,
+			628, 274, 627, 283, 618, 287, 614, 293, 609, 298, 625, 293, 636, 295, 641, 293, 644, 296, 650, 295,
+			663, 299, 664, 307, 669, 313, 677, 313, 678, 316, 686, 317, 689, 316, 693, 319, 693, 325, 697, 331,
+			703, 334, 699, 340, 709, 340, 712, 343, 711, 347, 716, 352, 715, 357, 713, 361, 718, 365, 729, 367,
+			741, 368, 746, 370, 752, 371, 759, 367, 762, 359,
+		},
+	},
+	"PL": {
+		{
+			235, 539, 235, 535, 238, 531, 238, 527, 232, 525, 235, 520, 235, 516, 240, 507, 239, 504, 234, 503,
+			225, 495, 228, 490, 226, 491, 216, 495, 209, 493, 204, 494, 198, 492, 193, 496, 189, 494, 189, 495,
+			184, 500, 176, 500, 176, 504, 169, 505, 167, 502, 162, 504, 162, 507, 155, 508, 150, 511, 146, 517,
+			147, 521, 144, 526, 141, 530, 144, 532, 141, 538, 148, 541, 164, 545, 176, 549, 186, 547, 187, 544,
+			197, 544, 209, 543, 227, 543, 232, 542,
+		},
+	},
+	"PR": {
+		{
+			-663, 185, -658, 184, -656, 182, -658, 180, -666, 180, -672, 179, -672, 184, -671, 185,
+		},
+	},
+	"PS": {
+		{
+			354, 315, 349, 314, 350, 316, 352, 318, 350, 319, 352, 325, 355, 324, 355, 318,
+		},
+	},
+	"PT": {
+		{
+			-90, 419, -87, 421, -83, 423, -80, 418, -74, 418, -73, 419, -67, 419, -64, 414, -69, 411, -69, 403,
+			-70, 402, -71, 397, -75, 396, -71, 390, -74, 384, -70, 381, -72, 378, -75, 374, -75, 371, -79, 368,
+			-84, 370, -89, 369, -87, 377, -88, 383, -93, 384, -95, 387, -94, 394, -90, 398, -90, 402, -88, 408,
+			-88, 412, -90, 415,
+		},
+	},
+	"PY": {
+		{
+			-582, -202, -579, -207, -579, -221, -569, -223, -565, -221, -558, -224, -556, -227, -555, -236,
+			-554, -240, -550, -240, -547, -238, -543, -240, -543, -246, -544, -252, -546, -257, -548, -266,
+			-557, -274, -565, -275, -576, -274, -586, -271, -576, -256, -578, -252, -588, -248, -600, -240,
+			-608, -239, -627, -222, -623, -211, -623, -205, -618, -196, -600, -193, -591, -194, -582, -199,
+		},
+	},
+	"QA": {
+		{
+			508, 248, 507, 255, 510, 260, 513, 261, 516, 258, 516, 252, 514, 246, 511, 246,
+		},
+	},
+	"RO": {
+		{
+			282, 455, 287, 453, 291, 455, 296, 453, 296, 450, 291, 448, 288, 449, 286, 437, 280, 438, 272, 442,
+			261, 439, 256, 437, 241, 437, 233, 439, 229, 438, 227, 442, 225, 444, 227, 446, 225, 447, 221, 445,
+			216, 448, 215, 452, 209, 454, 208, 457, 202, 461, 210, 463, 216, 470, 221, 477, 227, 479, 231, 481,
+			238, 480, 244, 480, 249, 477, 252, 479, 259, 480, 262, 482, 266, 482, 269, 481, 272, 478, 276, 474,
+			281, 468, 282, 464, 281, 459,
+		},
+	},
+	"RS": {
+		{
+			188, 459, 196, 462, 202, 461, 208, 457, 209, 454, 215, 452, 216, 448, 221, 445, 225, 447, 227, 446,
+			225, 444, 227, 442, 224, 440, 225, 436, 230, 432, 226, 429, 224, 426, 225, 425, 224, 423, 219, 423,
+			216, 422, 215, 423, 217, 424, 218, 427, 216, 427, 214, 429, 213, 429, 211, 431, 210, 431, 208, 433,
+			206, 432, 205, 429, 203, 428, 203, 429, 200, 431, 196, 432, 195, 434, 192, 435, 195, 436, 196, 440,
+			191, 444, 194, 449, 190, 449, 194, 452, 191, 455,
+		},
+	},
+	"RU": {
+		{
+			1787, 711, 1800, 715, 1800, 708, 1789, 708,
+		},
+		{
+			491, 464, 486, 458, 477, 456, 467, 446, 476, 437, 475, 430, 486, 418, 480, 414, 478, 412, 474, 412,
+			467, 418, 464, 419, 458, 421, 455, 425, 445, 427, 439, 426, 438, 427, 424, 432, 409, 434, 401, 436,
+			400, 434, 387, 443, 375, 447, 367, 452, 374, 454, 382, 462, 377, 466, 391, 470, 391, 473, 382, 471,
+			383, 475, 388, 478, 397, 479, 399, 482, 397, 488, 401, 493, 401, 496, 386, 499, 380, 499, 374, 504,
+			366, 502, 354, 506, 354, 508, 350, 512, 342, 513, 341, 516, 344, 518, 338, 523, 327, 522, 324, 523,
+			322, 521, 318, 521, 315, 527, 313, 531, 315, 532, 323, 531, 327, 534, 324, 536, 317, 538, 318, 540,
+			314, 542, 308, 548, 310, 551, 309, 556, 299, 558, 294, 557, 292, 559, 282, 562, 279, 568, 278, 572,
+			273, 575, 277, 578, 274, 587, 281, 593, 280, 595, 291, 600, 281, 605, 302, 618, 311, 624, 315, 629,
+			300, 636, 304, 642, 295, 649, 302, 658, 291, 669, 300, 677, 284, 684, 286, 691, 294, 692, 311, 696,
+			321, 699, 338, 693, 365, 691, 403, 679, 411, 675, 411, 668, 400, 663, 384, 660, 339, 668, 332, 666,
+			348, 659, 349, 654, 349, 644, 362, 641, 370, 638, 371, 643, 365, 648, 372, 651, 396, 645, 404, 648,
+			398, 655, 421, 665, 430, 664, 439, 661, 445, 668, 437, 674, 442, 680, 435, 686, 462, 682, 468, 677,
+			456, 676, 456, 670, 463, 667, 479, 669, 481, 675, 502, 680, 537, 689, 545, 688, 535, 682, 547, 681,
+			554, 684, 573, 685, 588, 689, 599, 683, 611, 689, 600, 695, 606, 698, 635, 695, 649, 692, 685, 681,
+			692, 686, 682, 691, 681, 694, 669, 695, 673, 699, 667, 707, 667, 710, 685, 719, 692, 728, 699, 730,
+			726, 728, 728, 722, 718, 714, 725, 711, 728, 704, 726, 690, 737, 684, 732, 677, 713, 663, 724, 662,
+			728, 665, 739, 668, 742, 673, 751, 678, 745, 683, 749, 690, 738, 691, 736, 696, 744, 706, 731, 714,
+			749, 721, 747, 728, 752, 729, 757, 723, 753, 713, 764, 712, 759, 719, 776, 723, 797, 723, 815, 718,
+			806, 726, 805, 736, 822, 738, 847, 738, 868, 739, 860, 745, 872, 751, 883, 751, 903, 756, 929, 758,
+			932, 760, 959, 761, 967, 759, 989, 764, 1008, 764, 1010, 769, 1020, 773, 1044, 777, 1061, 774,
+			1047, 771, 1070, 770, 1072, 765, 1082, 767, 1111, 767, 1133, 762, 1141, 758, 1139, 753, 1128, 750,
+			1102, 745, 1094, 742, 1106, 740, 1121, 738, 1130, 740, 1135, 733, 1140, 736, 1156, 738, 1188, 736,
+			1190, 731, 1232, 730, 1233, 737, 1254, 736, 1270, 736, 1286, 730, 1291, 724, 1285, 720, 1297, 712,
+			1313, 708, 1323, 718, 1339, 714, 1356, 717, 1375, 713, 1382, 716, 1399, 715, 1391, 724, 1405, 728,
+			1495, 722, 1504, 716, 1530, 708, 1570, 710, 1590, 709, 1598, 705, 1597, 697, 1609, 694, 1623, 696,
+			1641, 697, 1659, 695, 1678, 696, 1696, 687, 1708, 690, 1700, 697, 1705, 701, 1736, 698, 1757, 699,
+			1786, 694, 1800, 690, 1800, 650, 1787, 645, 1774, 646, 1783, 641, 1789, 633, 1794, 630, 1795, 626,
+			1792, 623, 1774, 625, 1746, 618, 1737, 617, 1722, 610, 1707, 603, 1703, 599, 1689, 606, 1663, 598,
+			1658, 602, 1649, 597, 1635, 599, 1632, 592, 1620, 582, 1621, 578, 1632, 576, 1631, 562, 1621, 561,
+			1617, 553, 1621, 549, 1604, 543, 1600, 532, 1585, 530, 1582, 519, 1568, 510, 1564, 517, 1560, 532,
+			1554, 554, 1559, 568, 1568, 574, 1568, 578, 1584, 581, 1602, 593, 1619, 603, 1637, 611, 1645, 626,
+			1633, 625, 1627, 616, 1601, 605, 1593, 618, 1567, 614, 1542, 598, 1550, 591, 1528, 589, 1513, 588,
+			1513, 595, 1498, 597, 1485, 592, 1455, 593, 1422, 590, 1390, 571, 1351, 547, 1367, 546, 1372, 540,
+			1382, 538, 1388, 543, 1399, 542, 1413, 531, 1414, 522, 1406, 512, 1405, 500, 1401, 484, 1386, 470,
+			1382, 463, 1369, 451, 1355, 440, 1349, 434, 1335, 428, 1329, 428, 1323, 433, 1309, 426, 1308, 422,
+			1306, 424, 1306, 429, 1311, 429, 1313, 441, 1310, 450, 1319, 453, 1331, 451, 1338, 461, 1341, 472,
+			1345, 476, 1350, 485, 1334, 482, 1325, 478, 1310, 478, 1306, 487, 1294, 494, 1277, 498, 1273, 507,
+			1269, 514, 1266, 518, 1259, 528, 1251, 532, 1236, 535, 1222, 534, 1210, 533, 1202, 528, 1207, 525,
+			1207, 520, 1202, 516, 1193, 506, 1193, 501, 1179, 495, 1167, 499, 1155, 498, 1150, 501, 1144, 502,
+			1129, 495, 1116, 494, 1107, 491, 1094, 493, 1085, 493, 1079, 498, 1069, 503, 1059, 504, 1046, 503,
+			1037, 501, 1023, 505, 1021, 513, 1009, 515, 1000, 516, 989, 520, 978, 510, 982, 504, 973, 497, 958,
+			500, 948, 500, 941, 505, 931, 505, 922, 508, 907, 503, 888, 495, 878, 493, 874, 492, 868, 498, 855,
+			497, 851, 501, 844, 503, 839, 509, 834, 511, 819, 508, 806, 514, 800, 509, 778, 534, 765, 542, 769,
+			545, 744, 535, 734, 535, 735, 540, 722, 544, 712, 541, 709, 552, 691, 554, 682, 550, 657, 546, 652,
+			544, 614, 540, 610, 537, 617, 530, 607, 527, 609, 524, 600, 520, 616, 513, 613, 508, 599, 508, 596,
+			505, 584, 511, 568, 510, 557, 506, 545, 510, 523, 517, 508, 517, 487, 506, 486, 499, 475, 505, 468,
+			494, 470, 492, 465, 484, 473, 477, 481, 477, 487, 471, 486, 466,
+		},
+		{
+			938, 810, 959, 813, 979, 807, 1002, 798, 999, 789, 978, 788, 950, 790, 933, 794, 925, 801, 912,
+			803,
+		},
+		{
+			1028, 793, 1054, 787, 1051, 783, 994, 779, 1013, 792, 1021, 793,
+		},
+		{
+			1388, 761, 1415, 761, 1451, 756, 1443, 748, 1406, 748, 1390, 746, 1370, 753, 1375, 759,
+		},
+		{
+			1482, 753, 1507, 751, 1496, 747, 1480, 748, 1461, 752, 1464, 755,
+		},
+		{
+			1399, 734, 1408, 738, 1421, 739, 1435, 735, 1436, 732, 1421, 732, 1400, 733,
+		},
+		{
+			448, 806, 468, 808, 483, 808, 485, 805, 491, 808, 500, 809, 515, 807, 511, 805, 498, 804, 489, 803,
+			488, 802, 476, 800, 465, 802, 471, 806,
+		},
+		{
+			227, 543, 209, 543, 197, 544, 199, 549, 213, 552, 223, 550, 228, 549, 227, 546,
+		},
+		{
+			535, 737, 559, 746, 556, 751, 579, 756, 612, 763, 645, 764, 662, 768, 682, 769, 689, 765, 682, 762,
+			646, 757, 616, 753, 585, 743, 570, 733, 554, 724, 556, 715, 575, 707, 569, 706, 537, 708, 534, 712,
+			516, 715, 515, 720, 525, 722, 524, 728, 544, 736,
+		},
+		{
+			1429, 537, 1433, 527, 1432, 518, 1436, 507, 1447, 490, 1432, 493, 1426, 479, 1435, 468, 1435, 461,
+			1427, 467, 1421, 460, 1419, 468, 1420, 478, 1419, 489, 1421, 496, 1422, 510, 1416, 519, 1417, 533,
+			1426, 538, 1422, 542, 1427, 544,
+		},
+		{
+			-1749, 672, -1750, 666, -1743, 663, -1746, 671, -1719, 669, -1699, 660, -1709, 655, -1725, 654,
+			-1726, 645, -1730, 643, -1739, 643, -1747, 646, -1760, 649, -1762, 654, -1772, 655, -1784, 654,
+			-1789, 657, -1787, 661, -1799, 659, -1794, 654, -1800, 650, -1800, 690, -1776, 682,
+		},
+		{
+			-1787, 709, -1800, 708, -1800, 715, -1799, 716, -1790, 716, -1776, 713, -1777, 711,
+		},
+		{
+			334, 460, 337, 462, 344, 460, 347, 460, 349, 458, 350, 457, 355, 454, 365, 455, 363, 451, 352, 449,
+			339, 444, 333, 446, 335, 450, 325, 453, 326, 455, 336, 459,
+		},
+	},
+	"RW": {
+		{
+			304, -11, 308, -17, 308, -23, 305, -24, 299, -23, 296, -29, 290, -28, 291, -23, 293, -22, 293, -16,
+			296, -13, 298, -14,
+		},
+	},
+	"SA": {
+		{
+			350, 294, 361, 292, 365, 295, 367, 299, 375, 300, 377, 303, 380, 305, 370, 315, 390, 320, 392, 322,
+			404, 319, 419, 312, 447, 292, 466, 291, 475, 290, 477, 285, 484, 286, 488, 277, 493, 275, 495, 271,
+			502, 267, 502, 263, 501, 259, 502, 256, 505, 253, 507, 250, 508, 248, 511, 246, 514, 246, 516, 242,
+			516, 240, 520, 230, 550, 225, 552, 227, 557, 220, 550, 200, 520, 190, 491, 186, 482, 182, 475, 171,
+			470, 169, 467, 173, 464, 172, 454, 173, 452, 174, 441, 174, 438, 173, 434, 176, 431, 171, 432, 167,
+			428, 163, 426, 168, 423, 171, 423, 175, 418, 178, 412, 187, 409, 195, 402, 202, 398, 203, 391, 213,
+			390, 220, 391, 226, 385, 237, 380, 241, 375, 243, 372, 249, 372, 251, 369, 256, 366, 258, 362, 266,
+			356, 274, 351, 281, 346, 281, 348, 286, 348, 290,
+		},
+	},
+	"SB": {
+		{
+			1621, -105, 1624, -108, 1617, -108, 1613, -102, 1619, -104,
+		},
+		{
+			1617, -96, 1615, -98, 1608, -89, 1606, -83, 1609, -83, 1613, -91,
+		},
+		{
+			1609, -99, 1605, -99, 1598, -98, 1596, -96, 1597, -92, 1604, -94, 1607, -96,
+		},
+		{
+			1596, -80, 1599, -83, 1599, -85, 1591, -81, 1586, -78, 1582, -74, 1584, -73, 1588, -76,
+		},
+		{
+			1571, -70, 1575, -73, 1573, -74, 1569, -72, 1565, -68, 1565, -66,
+		},
+	},
+	"SD": {
+		{
+			246, 82, 238, 87, 235, 90, 234, 93, 236, 97, 236, 101, 230, 107, 229, 111, 229, 114, 225, 117, 225,
+			123, 223, 126, 219, 126, 220, 130, 223, 134, 222, 138, 225, 141, 223, 143, 226, 149, 230, 157, 239,
+			156, 238, 196, 238, 200, 250, 200, 250, 220, 290, 220, 329, 220, 369, 220, 372, 210, 370, 208, 371,
+			198, 375, 186, 379, 184, 384, 180, 379, 174, 372, 173, 369, 170, 368, 163, 363, 148, 364, 144, 363,
+			136, 359, 126, 353, 121, 348, 113, 347, 109, 343, 106, 340, 96, 340, 87, 340, 95, 338, 95, 338,
+			100, 337, 103, 332, 107, 331, 114, 332, 122, 327, 122, 327, 120, 321, 120, 323, 117, 324, 111, 319,
+			105, 314, 98, 308, 97, 300, 103, 296, 101, 295, 98, 290, 96, 290, 94, 280, 94, 278, 96, 271, 96,
+			268, 95, 265, 96, 260, 101, 258, 104, 251, 103, 248, 98, 245, 89, 242, 87, 239, 86,
+		},
+	},
+	"SE": {
+		{
+			110, 589, 115, 594, 123, 601, 126, 613, 120, 618, 119, 631, 126, 641, 136, 640, 139, 644, 136, 648,
+			151, 662, 161, 673, 168, 680, 177, 680, 180, 686, 199, 684, 200, 691, 206, 691, 220, 686, 235, 679,
+			236, 664, 239, 660, 222, 657, 212, 650, 214, 644, 198, 636, 178, 627, 171, 613, 178, 606, 188, 601,
+			179, 590, 168, 587, 164, 570, 159, 561, 147, 562, 141, 554, 129, 554, 126, 563, 118, 574,
+		},
+	},
+	"SI": {
+		{
+			138, 465, 146, 464, 151, 467, 160, 467, 162, 469, 164, 468, 166, 465, 158, 462, 157, 458, 153, 457,
+			153, 455, 149, 455, 146, 456, 144, 455, 137, 455, 139, 456, 137, 460,
+		},
+	},
+	"SK": {
+		{
+			226, 491, 223, 488, 221, 484, 219, 483, 208, 486, 205, 486, 202, 483, 198, 482, 197, 483, 192, 481,
+			188, 481, 187, 479, 179, 478, 175, 479, 170, 481, 169, 485, 170, 486, 171, 488, 175, 488, 179, 489,
+			179, 490, 181, 490, 182, 493, 184, 493, 186, 495, 189, 495, 189, 494, 193, 496, 198, 492, 204, 494,
+			209, 493, 216, 495,
+		},
+	},
+	"SL": {
+		{
+			-132, 89, -127, 93, -126, 96, -124, 98, -122, 99, -119, 100, -111, 100, -108, 97, -106, 93, -107,
+			90, -105, 87, -105, 83, -102, 84, -107, 79, -111, 74, -112, 71, -114, 68, -117, 69, -124, 73, -129,
+			78, -131, 82,
+		},
+	},
+	"SN": {
+		{
+			-167, 136, -171, 144, -176, 147, -172, 149, -167, 156, -165, 161, -161, 165, -156, 164, -151, 166,
+			-146, 166, -141, 163, -134, 160, -128, 153, -122, 146, -121, 140, -119, 134, -116, 131, -115, 128,
+			-115, 124, -117, 124, -122, 125, -123, 124, -125, 123, -132, 126, -137, 126, -155, 126, -158, 125,
+			-161, 125, -167, 124, -168, 132, -159, 131, -157, 133, -155, 133, -151, 135, -147, 133, -143, 133,
+			-138, 135, -140, 138, -144, 136, -147, 136, -151, 139, -154, 139, -156, 136,
+		},
+	},
+	"SO": {
+		{
+			416, -17, 410, -9, 410, 28, 419, 39, 421, 42, 428, 43, 437, 50, 450, 50, 478, 80, 485, 88, 489, 95,
+			489, 100, 489, 110, 489, 114, 493, 114, 497, 116, 503, 117, 507, 120, 511, 120, 511, 117, 510, 112,
+			510, 106, 508, 103, 506, 92, 501, 81, 495, 68, 486, 53, 477, 42, 466, 29, 456, 20, 441, 11, 431, 3,
+			420, -9, 418, -14,
+		},
+	},
+	"SR": {
+		{
+			-545, 23, -551, 25, -556, 24, -560, 25, -561, 22, -559, 20, -560, 18, -565, 19, -572, 28, -573, 33,
+			-576, 33, -580, 41, -579, 46, -579, 48, -573, 51, -571, 60, -559, 58, -558, 60, -550, 60, -540, 58,
+			-545, 49, -544, 42, -540, 36, -542, 32, -543, 27,
+		},
+	},
+	"SS": {
+		{
+			308, 35, 300, 42, 297, 46, 292, 44, 287, 45, 284, 43, 280, 44, 274, 52, 272, 56, 265, 59, 262, 65,
+			258, 70, 251, 75, 251, 78, 246, 82, 239, 86, 242, 87, 245, 89, 248, 98, 251, 103, 258, 104, 260,
+			101, 265, 96, 268, 95, 271, 96, 278, 96, 280, 94, 290, 94, 290, 96, 295, 98, 296, 101, 300, 103,
+			308, 97, 314, 98, 319, 105, 324, 111, 323, 117, 321, 120, 327, 120, 327, 122, 332, 122, 331, 114,
+			332, 107, 337, 103, 338, 100, 338, 95, 340, 95, 340, 87, 338, 84, 333, 84, 330, 78, 336, 77, 341,
+			72, 343, 68, 347, 66, 353, 55, 346, 48, 340, 42, 334, 38, 327, 38, 319, 36, 312, 38,
+		},
+	},
+	"SV": {
+		{
+			-894, 144, -891, 143, -888, 141, -885, 140, -885, 138, -881, 140, -879, 139, -877, 138, -878, 134,
+			-879, 131, -885, 132, -888, 133, -893, 135, -898, 135, -901, 137, -901, 139, -897, 141, -895, 142,
+			-896, 144,
+		},
+	},
+	"SY": {
+		{
+			357, 327, 358, 329, 358, 333, 361, 338, 366, 342, 364, 346, 360, 346, 359, 354, 361, 358, 364, 360,
+			367, 363, 367, 368, 371, 366, 382, 369, 387, 367, 395, 367, 407, 371, 412, 371, 423, 372, 418, 366,
+			413, 364, 414, 356, 410, 344, 388, 334, 368, 323,
+		},
+	},
+	"SZ": {
+		{
+			321, -267, 319, -272, 313, -273, 307, -267, 307, -264, 309, -260, 310, -257, 313, -257, 318, -258,
+			320, -263,
+		},
+	},
+	"TD": {
+		{
+			238, 196, 239, 156, 230, 157, 226, 149, 223, 143, 225, 141, 222, 138, 223, 134, 220, 130, 219, 126,
+			223, 126, 225, 123, 225, 117, 229, 114, 229, 111, 222, 110, 217, 106, 210, 95, 201, 90, 191, 91,
+			188, 90, 189, 86, 184, 83, 180, 79, 167, 75, 165, 77, 163, 78, 161, 75, 153, 74, 154, 77, 151, 84,
+			150, 88, 145, 90, 140, 95, 142, 100, 146, 99, 149, 100, 155, 100, 149, 109, 150, 116, 149, 122,
+			145, 129, 146, 133, 140, 134, 140, 140, 135, 144, 140, 157, 152, 166, 153, 179, 157, 200, 159, 204,
+			155, 207, 155, 210, 151, 213, 149, 229, 159, 234, 198, 215,
+		},
+	},
+	"TF": {
+		{
+			689, -486, 696, -489, 705, -491, 706, -493, 703, -497, 687, -498, 687, -492, 689, -488,
+		},
+	},
+	"TG": {
+		{
+			9, 110, 8, 105, 11, 102, 14, 98, 15, 93, 17, 91, 16, 68, 19, 61, 11, 59, 8, 63, 6, 69, 5, 74, 7,
+			83, 5, 87, 4, 95, 4, 102, 0, 107, 0, 110,
+		},
+	},
+	"TH": {
+		{
+			1052, 143, 1043, 144, 1030, 142, 1023, 134, 1026, 122, 1017, 126, 1008, 126, 1010, 134, 1001, 134,
+			1000, 123, 995, 108, 992, 100, 992, 92, 999, 92, 1003, 83, 1005, 74, 1010, 69, 1016, 67, 1021, 62,
+			1018, 58, 1012, 57, 1011, 62, 1003, 66, 1001, 65, 997, 68, 995, 73, 990, 79, 985, 84, 983, 78, 982,
+			84, 983, 90, 986, 99, 990, 110, 996, 119, 992, 128, 992, 133, 991, 138, 984, 146, 982, 151, 985,
+			153, 989, 162, 985, 168, 979, 176, 974, 184, 978, 186, 983, 197, 990, 198, 995, 202, 1001, 204,
+			1005, 201, 1006, 195, 1013, 195, 1010, 184, 1011, 175, 1021, 181, 1024, 179, 1030, 180, 1032, 183,
+			1040, 182, 1047, 174, 1048, 164, 1056, 156, 1055, 147,
+		},
+	},
+	"TJ": {
+		{
+			678, 371, 684, 382, 682, 389, 674, 391, 677, 396, 685, 395, 690, 401, 693, 407, 707, 410, 705, 405,
+			706, 402, 710, 402, 706, 399, 696, 401, 695, 395, 705, 396, 718, 393, 737, 394, 739, 385, 743, 386,
+			749, 384, 748, 380, 750, 374, 739, 374, 733, 375, 726, 370, 722, 369, 718, 367, 714, 371, 715, 379,
+			712, 380, 713, 383, 708, 385, 704, 381, 703, 377, 701, 376, 695, 376, 692, 372, 689, 373, 681, 370,
+		},
+	},
+	"TL": {
+		{
+			1250, -89, 1251, -87, 1259, -84, 1266, -84, 1270, -83, 1273, -84, 1270, -87, 1259, -91, 1251, -94,
+			1251, -91,
+		},
+	},
+	"TM": {
+		{
+			525, 418, 529, 421, 541, 423, 548, 420, 555, 413, 560, 413, 571, 413, 569, 418, 578, 422, 586, 428,
+			600, 422, 601, 414, 605, 412, 615, 413, 619, 411, 624, 401, 635, 394, 642, 389, 652, 384, 665, 380,
+			665, 374, 662, 374, 657, 377, 656, 373, 647, 371, 645, 363, 640, 360, 632, 359, 630, 354, 622, 353,
+			612, 357, 611, 365, 604, 365, 592, 374, 584, 375, 573, 380, 566, 381, 562, 379, 555, 380, 548, 374,
+			539, 372, 537, 379, 539, 390, 531, 393, 534, 400, 527, 400, 529, 409, 539, 406, 547, 410, 540, 416,
+			537, 421, 529, 419, 528, 411,
+		},
+	},
+	"TN": {
+		{
+			95, 303, 91, 321, 84, 325, 84, 327, 76, 333, 75, 341, 81, 347, 84, 355, 82, 364, 84, 369, 95, 373,
+			102, 372, 102, 367, 110, 371, 111, 369, 106, 364, 106, 359, 109, 357, 108, 348, 101, 343, 103, 338,
+			109, 338, 111, 333, 115, 331, 114, 324, 109, 321, 106, 318, 100, 314, 101, 310, 100, 305,
+		},
+	},
+	"TR": {
+		{
+			448, 372, 443, 370, 439, 373, 428, 374, 423, 372, 412, 371, 407, 371, 395, 367, 387, 367, 382, 369,
+			371, 366, 367, 368, 367, 363, 364, 360, 361, 358, 358, 363, 362, 367, 356, 366, 347, 368, 340, 362,
+			325, 361, 317, 366, 306, 367, 304, 363, 297, 361, 287, 367, 276, 367, 270, 377, 263, 382, 268, 390,
+			262, 395, 273, 404, 288, 405, 292, 412, 311, 411, 323, 417, 335, 420, 352, 420, 369, 413, 383, 409,
+			395, 411, 404, 410, 416, 415, 426, 416, 436, 411, 438, 407, 437, 403, 444, 400, 448, 397, 441, 394,
+			444, 383, 442, 380,
+		},
+		{
+			261, 418, 271, 421, 280, 420, 281, 416, 290, 413, 288, 411, 276, 410, 272, 407, 264, 402, 260, 406,
+			261, 408, 263, 409, 266, 416,
+		},
+	},
+	"TT": {
+		{
+			-617, 108, -611, 109, -609, 109, -609, 101, -618, 100, -620, 101, -617, 104,
+		},
+	},
+	"TW": {
+		{
+			1218, 244, 1212, 228, 1207, 220, 1202, 228, 1201, 236, 1207, 245, 1215, 253, 1220, 250,
+		},
+	},
+	"TZ": {
+		{
+			339, -10, 341, -11, 377, -31, 378, -37, 392, -47, 387, -59, 388, -65, 394, -68, 395, -71, 392, -77,
+			393, -80, 392, -85, 395, -91, 399, -101, 403, -103, 395, -109, 384, -113, 378, -113, 375, -116,
+			368, -116, 365, -117, 353, -114, 346, -115, 343, -102, 339, -97, 337, -94, 328, -92, 322, -89, 316,
+			-88, 312, -86, 307, -83, 302, -71, 296, -65, 294, -59, 295, -54, 293, -45, 298, -45, 301, -41, 305,
+			-36, 308, -34, 307, -30, 305, -28, 305, -24, 308, -23, 308, -17, 304, -11, 308, -10, 319, -10,
+		},
+	},
+	"UA": {
+		{
+			318, 521, 322, 521, 324, 523, 327, 522, 338, 523, 344, 518, 341, 516, 342, 513, 350, 512, 354, 508,
+			354, 506, 366, 502, 374, 504, 380, 499, 386, 499, 401, 496, 401, 493, 397, 488, 399, 482, 397, 479,
+			388, 478, 383, 475, 382, 471, 374, 470, 368, 467, 358, 466, 350, 463, 350, 457, 349, 458, 347, 460,
+			344, 460, 337, 462, 334, 460, 333, 461, 317, 463, 317, 467, 307, 466, 304, 460, 296, 453, 291, 455,
+			287, 453, 282, 455, 285, 456, 287, 459, 289, 463, 289, 464, 291, 465, 292, 464, 298, 463, 300, 464,
+			298, 465, 299, 467, 296, 469, 294, 473, 291, 475, 291, 478, 287, 481, 283, 482, 275, 485, 269, 484,
+			266, 482, 262, 482, 259, 480, 252, 479, 249, 477, 244, 480, 238, 480, 231, 481, 227, 479, 226, 482,
+			221, 484, 223, 488, 226, 491, 228, 490, 225, 495, 234, 503, 239, 504, 240, 507, 235, 516, 240, 516,
+			246, 519, 253, 519, 263, 518, 275, 516, 282, 516, 286, 514, 290, 516, 293, 514, 302, 514, 306, 513,
+			306, 518, 309, 520,
+		},
+	},
+	"UG": {
+		{
+			339, -10, 319, -10, 308, -10, 304, -11, 298, -14, 296, -13, 296, -6, 298, -2, 299, 6, 301, 11, 305,
+			16, 309, 18, 312, 22, 308, 23, 308, 35, 312, 38, 319, 36, 327, 38, 334, 38, 340, 42, 345, 36, 346,
+			31, 350, 19, 347, 12, 342, 5, 339, 1,
+		},
+	},
+	"US": {
+		{
+			-1228, 490, -1200, 490, -1170, 490, -1160, 490, -1130, 490, -1100, 490, -1070, 490, -1040, 490,
+			-1006, 490, -972, 490, -952, 490, -952, 494, -948, 494, -946, 488, -943, 487, -936, 486, -926, 484,
+			-916, 481, -908, 483, -896, 480, -893, 480, -884, 483, -874, 479, -865, 476, -857, 472, -849, 469,
+			-848, 466, -845, 465, -846, 464, -843, 464, -841, 465, -841, 463, -839, 461, -836, 461, -835, 460,
+			-836, 458, -826, 453, -823, 444, -821, 436, -824, 430, -829, 424, -831, 421, -831, 420, -830, 418,
+			-827, 417, -824, 417, -813, 422, -802, 424, -789, 429, -789, 430, -790, 433, -792, 435, -787, 436,
+			-777, 436, -768, 436, -765, 440, -764, 441, -753, 448, -749, 450, -733, 450, -715, 450, -714, 453,
+			-711, 453, -707, 455, -703, 459, -700, 467, -692, 474, -689, 472, -682, 474, -678, 471, -678, 457,
+			-671, 451, -670, 448, -680, 443, -691, 440, -701, 437, -706, 431, -708, 429, -708, 423, -705, 418,
+			-701, 418, -702, 421, -699, 419, -700, 416, -706, 415, -711, 415, -719, 413, -723, 413, -729, 412,
+			-737, 409, -722, 411, -719, 409, -733, 406, -740, 406, -740, 408, -743, 405, -740, 404, -742, 397,
+			-749, 389, -750, 392, -752, 392, -755, 395, -753, 390, -751, 388, -751, 384, -754, 380, -759, 372,
+			-760, 373, -757, 379, -762, 383, -764, 392, -765, 387, -763, 381, -770, 382, -763, 379, -763, 370,
+			-760, 369, -759, 366, -757, 356, -764, 348, -774, 345, -781, 339, -786, 339, -791, 335, -792, 332,
+			-803, 325, -809, 320, -813, 314, -815, 307, -813, 300, -810, 292, -805, 285, -805, 280, -801, 269,
+			-801, 262, -801, 258, -804, 252, -807, 251, -812, 252, -813, 256, -817, 259, -822, 267, -827, 275,
+			-829, 279, -826, 286, -829, 291, -837, 299, -841, 301, -851, 296, -853, 297, -858, 302, -864, 304,
+			-875, 303, -884, 304, -892, 303, -896, 302, -894, 299, -894, 295, -892, 293, -894, 292, -898, 293,
+			-902, 291, -909, 291, -916, 297, -925, 296, -932, 298, -938, 297, -947, 295, -956, 287, -966, 283,
+			-971, 278, -974, 274, -974, 267, -973, 262, -971, 259, -975, 258, -982, 261, -990, 264, -993, 268,
+			-995, 275, -1001, 281, -1005, 287, -1010, 294, -1017, 298, -1025, 298, -1031, 290, -1039, 293,
+			-1045, 296, -1047, 301, -1050, 306, -1056, 311, -1061, 314, -1065, 318, -1082, 318, -1082, 313,
+			-1090, 313, -1110, 313, -1133, 320, -1148, 325, -1147, 327, -1160, 326, -1171, 325, -1173, 330,
+			-1179, 336, -1184, 337, -1185, 340, -1191, 341, -1194, 343, -1204, 344, -1206, 346, -1207, 352,
+			-1217, 362, -1225, 376, -1225, 378, -1230, 381, -1237, 390, -1239, 398, -1244, 403, -1242, 411,
+			-1242, 420, -1245, 428, -1241, 437, -1240, 446, -1239, 455, -1241, 469, -1244, 477, -1247, 482,
+			-1246, 484, -1231, 480, -1226, 471, -1223, 474, -1225, 482,
+		},
+		{
+			-1554, 201, -1552, 200, -1551, 199, -1548, 195, -1552, 192, -1555, 191, -1557, 189, -1559, 191,
+			-1559, 193, -1561, 197, -1560, 198, -1559, 200, -1559, 202, -1559, 203, -1558, 202,
+		},
+		{
+			-1560, 208, -1561, 206, -1564, 206, -1566, 208, -1567, 209, -1566, 210, -1563, 209,
+		},
+		{
+			-1568, 212, -1568, 211, -1573, 211, -1573, 212,
+		},
+		{
+			-1580, 217, -1579, 217, -1577, 213, -1578, 213, -1581, 213, -1583, 215, -1583, 216,
+		},
+		{
+			-1594, 222, -1593, 220, -1595, 219, -1598, 221, -1597, 221, -1596, 222,
+		},
+		{
+			-1665, 604, -1657, 603, -1656, 599, -1662, 598, -1668, 599, -1675, 602,
+		},
+		{
+			-1532, 580, -1526, 579, -1521, 576, -1530, 571, -1540, 567, -1545, 570, -1547, 575, -1538, 578,
+		},
+		{
+			-1410, 697, -1410, 660, -1410, 603, -1400, 603, -1390, 600, -1383, 596, -1375, 589, -1365, 595,
+			-1355, 598, -1349, 593, -1343, 589, -1334, 584, -1327, 577, -1317, 566, -1300, 559, -1300, 553,
+			-1305, 548, -1311, 552, -1320, 555, -1323, 564, -1335, 572, -1341, 581, -1350, 582, -1366, 582,
+			-1378, 585, -1399, 595, -1408, 597, -1426, 601, -1440, 600, -1459, 605, -1471, 609, -1482, 607,
+			-1480, 600, -1486, 599, -1497, 597, -1506, 594, -1517, 592, -1519, 597, -1514, 607, -1503, 610,
+			-1506, 613, -1519, 607, -1526, 601, -1540, 594, -1533, 589, -1542, 581, -1553, 577, -1563, 574,
+			-1566, 570, -1581, 565, -1584, 560, -1596, 556, -1603, 556, -1612, 554, -1622, 550, -1631, 547,
+			-1648, 544, -1649, 546, -1638, 550, -1629, 553, -1618, 559, -1606, 560, -1601, 564, -1587, 570,
+			-1585, 572, -1577, 576, -1576, 583, -1570, 589, -1582, 586, -1585, 588, -1591, 584, -1597, 589,
+			-1600, 586, -1604, 591, -1614, 587, -1620, 587, -1621, 593, -1619, 596, -1625, 600, -1638, 598,
+			-1647, 603, -1653, 605, -1654, 611, -1661, 615, -1657, 621, -1649, 626, -1646, 631, -1638, 632,
+			-1631, 631, -1623, 635, -1615, 635, -1608, 638, -1610, 642, -1615, 644, -1608, 648, -1614, 648,
+			-1625, 646, -1628, 643, -1635, 646, -1650, 644, -1664, 647, -1668, 651, -1681, 657, -1667, 661,
+			-1645, 666, -1637, 666, -1638, 661, -1617, 661, -1625, 667, -1637, 671, -1644, 676, -1654, 680,
+			-1668, 684, -1662, 689, -1644, 689, -1632, 694, -1629, 699, -1619, 703, -1609, 704, -1590, 709,
+			-1581, 708, -1566, 714, -1551, 711, -1543, 707, -1539, 709, -1522, 708, -1523, 706, -1507, 704,
+			-1497, 705, -1476, 702, -1457, 701, -1449, 700, -1436, 702, -1421, 699,
+		},
+		{
+			-1717, 638, -1711, 636, -1705, 637, -1697, 634, -1687, 633, -1688, 632, -1695, 630, -1703, 632,
+			-1707, 634, -1716, 633, -1718, 634,
+		},
+	},
+	"UY": {
+		{
+			-576, -302, -570, -301, -560, -309, -556, -309, -546, -315, -538, -320, -532, -327, -537, -332,
+			-534, -338, -538, -344, -549, -350, -557, -348, -562, -349, -571, -344, -578, -345, -584, -339,
+			-583, -333, -581, -330, -581, -320, -579, -310,
+		},
+	},
+	"UZ": {
+		{
+			560, 413, 559, 450, 585, 456, 587, 455, 602, 448, 611, 444, 620, 435, 632, 437, 649, 437, 661, 430,
+			660, 420, 665, 420, 667, 412, 680, 411, 683, 407, 686, 407, 691, 414, 704, 421, 710, 423, 713, 422,
+			704, 415, 712, 411, 719, 414, 731, 409, 718, 401, 710, 402, 706, 402, 705, 405, 707, 410, 693, 407,
+			690, 401, 685, 395, 677, 396, 674, 391, 682, 389, 684, 382, 678, 371, 671, 374, 665, 374, 665, 380,
+			652, 384, 642, 389, 635, 394, 624, 401, 619, 411, 615, 413, 605, 412, 601, 414, 600, 422, 586, 428,
+			578, 422, 569, 418, 571, 413,
+		},
+	},
+	"VE": {
+		{
+			-607, 52, -606, 49, -610, 45, -621, 42, -628, 40, -631, 38, -639, 40, -646, 41, -648, 41, -644, 38,
+			-644, 31, -643, 25, -634, 24, -634, 22, -641, 19, -642, 15, -646, 13, -654, 11, -655, 8, -663, 7,
+			-669, 13, -672, 23, -674, 26, -678, 28, -673, 33, -673, 35, -676, 38, -678, 45, -677, 52, -675, 56,
+			-673, 61, -677, 63, -683, 62, -690, 62, -694, 61, -701, 70, -707, 71, -720, 70, -722, 73, -724, 74,
+			-725, 76, -724, 80, -724, 84, -727, 86, -728, 91, -733, 92, -730, 97, -729, 105, -726, 108, -722,
+			111, -720, 116, -713, 118, -714, 115, -719, 114, -716, 110, -716, 104, -721, 99, -717, 91, -713,
+			91, -710, 99, -714, 102, -714, 110, -702, 114, -703, 118, -699, 122, -696, 115, -689, 114, -682,
+			109, -682, 106, -673, 105, -662, 106, -657, 102, -649, 101, -643, 104, -643, 106, -631, 107, -619,
+			107, -627, 104, -624, 99, -616, 99, -608, 94, -607, 86, -602, 86, -598, 84, -606, 78, -606, 74,
+			-603, 70, -605, 69, -612, 67, -611, 62, -614, 60,
+		},
+	},
+	"VN": {
+		{
+			1043, 105, 1052, 109, 1062, 110, 1058, 116, 1075, 123, 1076, 135, 1074, 142, 1076, 152, 1073, 159,
+			1066, 166, 1059, 175, 1051, 187, 1039, 193, 1042, 196, 1048, 199, 1044, 208, 1032, 208, 1028, 217,
+			1022, 225, 1027, 227, 1035, 227, 1045, 228, 1053, 234, 1058, 230, 1067, 228, 1066, 222, 1070, 218,
+			1081, 216, 1067, 207, 1059, 198, 1057, 191, 1064, 180, 1074, 167, 1083, 161, 1089, 153, 1093, 134,
+			1092, 117, 1084, 110, 1072, 104, 1064, 95, 1052, 86, 1048, 92, 1051, 99,
+		},
+	},
+	"VU": {
+		{
+			1672, -159, 1678, -165, 1675, -166, 1672, -162,
+		},
+		{
+			1668, -157, 1666, -154, 1666, -146, 1671, -149, 1673, -157, 1670, -156,
+		},
+	},
+	"XK": {
+		{
+			206, 419, 205, 422, 203, 423, 201, 426, 203, 428, 205, 429, 206, 432, 208, 433, 210, 431, 211, 431,
+			213, 429, 214, 429, 216, 427, 218, 427, 217, 424, 215, 423, 216, 422, 214, 422, 208, 421, 207, 418,
+		},
+	},
+	"YE": {
+		{
+			520, 190, 528, 173, 531, 167, 524, 164, 522, 159, 522, 156, 512, 152, 496, 147, 487, 140, 482, 139,
+			479, 140, 474, 136, 467, 134, 459, 133, 456, 133, 454, 130, 451, 130, 450, 127, 445, 127, 442, 126,
+			435, 126, 432, 132, 433, 138, 431, 141, 429, 148, 426, 152, 428, 153, 427, 157, 428, 159, 428, 163,
+			432, 167, 431, 171, 434, 176, 438, 173, 441, 174, 452, 174, 454, 173, 464, 172, 467, 173, 470, 169,
+			475, 171, 482, 182, 491, 186,
+		},
+	},
+	"ZA": {
+		{
+			163, -286, 168, -281, 172, -284, 174, -288, 178, -289, 185, -290, 190, -290, 199, -285, 199, -248,
+			202, -249, 208, -259, 207, -265, 209, -268, 216, -267, 221, -263, 226, -260, 228, -255, 233, -253,
+			237, -254, 242, -257, 250, -257, 257, -255, 258, -252, 259, -247, 265, -246, 268, -242, 271, -236,
+			280, -228, 294, -221, 298, -221, 303, -223, 307, -222, 312, -223, 317, -237, 319, -244, 318, -255,
+			318, -258, 313, -257, 310, -257, 309, -260, 307, -264, 307, -267, 313, -273, 319, -272, 321, -267,
+			328, -267, 326, -275, 325, -283, 322, -288, 315, -293, 313, -294, 309, -299, 306, -304, 301, -311,
+			289, -322, 282, -328, 275, -332, 264, -336, 259, -337, 258, -339, 252, -338, 247, -340, 236, -338,
+			230, -339, 226, -339, 215, -343, 207, -344, 201, -348, 196, -348, 192, -345, 189, -344, 184, -340,
+			184, -341, 182, -339, 183, -333, 179, -326, 182, -324, 182, -317, 176, -307, 171, -299,
+		},
+	},
+	"ZM": {
+		{
+			307, -83, 312, -86, 316, -88, 322, -89, 328, -92, 332, -97, 335, -105, 333, -108, 331, -116, 333,
+			-124, 330, -128, 327, -137, 332, -140, 302, -148, 303, -155, 295, -156, 289, -160, 288, -164, 285,
+			-165, 276, -173, 270, -179, 267, -180, 264, -178, 253, -177, 251, -177, 251, -176, 247, -174, 240,
+			-173, 232, -175, 226, -169, 219, -161, 219, -129, 240, -129, 239, -126, 241, -122, 239, -117, 240,
+			-112, 239, -109, 243, -110, 243, -113, 248, -112, 254, -113, 258, -118, 266, -119, 272, -116, 274,
+			-121, 282, -123, 285, -127, 289, -132, 297, -133, 296, -122, 293, -124, 286, -120, 284, -118, 285,
+			-108, 287, -96, 284, -92, 287, -85, 290, -84, 303, -82,
+		},
+	},
+	"ZW": {
+		{
+			312, -223, 307, -222, 303, -223, 298, -221, 294, -221, 288, -216, 280, -215, 277, -209, 277, -205,
+			273, -204, 262, -193, 259, -187, 256, -185, 253, -177, 264, -178, 267, -180, 270, -179, 276, -173,
+			285, -165, 288, -164, 289, -160, 295, -156, 303, -155, 303, -159, 312, -159, 316, -161, 319, -163,
+			323, -164, 328, -167, 328, -180, 327, -187, 326, -194, 328, -197, 327, -203, 325, -204, 322, -211,
+		},
+	},
+}
@@ -0,0 +1,210 @@
+package core
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// Signatures are minisign compatible, they can be verified with either
+// `takuan verify` or `minisign -V`.
+const (
+	signatureSuffix = ".minisig"
+	// prehashed with blake2b-512, "Ed" is the legacy algorithm signing the whole file
+	sigAlgPrehashed = "ED"
+	sigAlgLegacy    = "Ed"
+	keyAlg          = "Ed"
+	kdfNone         = "\x00\x00"
+	chkAlg          = "B2"
+)
+
+// Signer adds a detached signature to every published file.
+type Signer struct {
+	Enabled bool `yaml:"enabled"`
+	// unencrypted minisign secret key, as generated by `takuan keygen` or `minisign -G -W`
+	KeyFile string `yaml:"key"`
+
+	keyID [8]byte
+	key   ed25519.PrivateKey
+}
+
+func keyIDString(id [8]byte) string {
+	return fmt.Sprintf("%016X", binary.LittleEndian.Uint64(id[:]))
+}
+
+// reads the base64 payload of a minisign file, skipping the untrusted comment
+func decodeMinisign(data []byte) ([]byte, error) {
+	for _, line := range strings.Split(string(data), "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "untrusted comment:") {
+			return base64.StdEncoding.DecodeString(line)
+		}
+	}
+	return nil, fmt.Errorf("no key found")
+}
+
+func (s *Signer) Init() error {
+	data, err := ioutil.ReadFile(s.KeyFile)
+	if err != nil {
+		return fmt.Errorf("error reading %s: %v", s.KeyFile, err)
+	}
+
+	raw, err := decodeMinisign(data)
+	if err != nil {
+		return fmt.Errorf("error decoding %s: %v", s.KeyFile, err)
+	}
+
+	// sig_alg(2) kdf_alg(2) chk_alg(2) salt(32) opslimit(8) memlimit(8) keynum(8) sk(64) chk(32)
+	if len(raw) != 158 || string(raw[:2]) != keyAlg || string(raw[4:6]) != chkAlg {
+		return fmt.Errorf("%s is not a minisign secret key", s.KeyFile)
+	} else if string(raw[2:4]) != kdfNone {
+		return fmt.Errorf("%s is encrypted, only unencrypted keys are supported", s.KeyFile)
+	}
+
+	copy(s.keyID[:], raw[54:62])
+	s.key = ed25519.PrivateKey(raw[62:126])
+
+	if chk := secretKeyChecksum(s.keyID, s.key); !bytes.Equal(chk[:], raw[126:158]) {
+		return fmt.Errorf("%s is corrupted, wrong checksum", s.KeyFile)
+	}
+
+	return nil
+}
+
+func secretKeyChecksum(keyID [8]byte, key ed25519.PrivateKey) [32]byte {
+	buf := append([]byte(keyAlg), keyID[:]...)
+	return blake2b.Sum256(append(buf, key...))
+}
+
+// Sign returns the content of the .minisig file for data.
+func (s *Signer) Sign(name string, node string, data []byte) []byte {
+	hash := blake2b.Sum512(data)
+	sig := ed25519.Sign(s.key, hash[:])
+	trusted := fmt.Sprintf("timestamp:%d\tfile:%s\tnode:%s", time.Now().Unix(), name, node)
+	global := ed25519.Sign(s.key, append(sig, []byte(trusted)...))
+
+	payload := append([]byte(sigAlgPrehashed), s.keyID[:]...)
+	payload = append(payload, sig...)
+
+	return []byte(fmt.Sprintf("untrusted comment: signature from takuan secret key %s\n%s\ntrusted comment: %s\n%s\n",
+		keyIDString(s.keyID),
+		base64.StdEncoding.EncodeToString(payload),
+		trusted,
+		base64.StdEncoding.EncodeToString(global)))
+}
+
+func (s *Signer) sign(pub *Publication) {
+	signatures := make([]*ReportFile, 0, len(pub.Files))
+	for _, file := range pub.Files {
+		signatures = append(signatures, &ReportFile{
+			Name: file.Name + signatureSuffix,
+			Data: s.Sign(file.Name, pub.Node, file.Data),
+		})
+	}
+	pub.Files = append(pub.Files, signatures...)
+}
+
+// GenerateKeyPair returns a new unencrypted minisign secret key and its public key.
+func GenerateKeyPair() (secret []byte, public []byte, err error) {
+	pk, sk, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var keyID [8]byte
+	if _, err = rand.Read(keyID[:]); err != nil {
+		return nil, nil, err
+	}
+
+	raw := bytes.Buffer{}
+	raw.WriteString(keyAlg)
+	raw.WriteString(kdfNone)
+	raw.WriteString(chkAlg)
+	raw.Write(make([]byte, 32+8+8))
+	raw.Write(keyID[:])
+	raw.Write(sk)
+	chk := secretKeyChecksum(keyID, sk)
+	raw.Write(chk[:])
+
+	secret = []byte(fmt.Sprintf("untrusted comment: takuan secret key %s\n%s\n",
+		keyIDString(keyID), base64.StdEncoding.EncodeToString(raw.Bytes())))
+
+	public = []byte(fmt.Sprintf("untrusted comment: minisign public key %s\n%s\n",
+		keyIDString(keyID), base64.StdEncoding.EncodeToString(append(append([]byte(keyAlg), keyID[:]...), pk...))))
+
+	return secret, public, nil
+}
+
+type PublicKey struct {
+	ID  [8]byte
+	Key ed25519.PublicKey
+}
+
+// ParsePublicKey accepts either the content of a minisign .pub file or its base64 line.
+func ParsePublicKey(data []byte) (*PublicKey, error) {
+	raw, err := decodeMinisign(data)
+	if err != nil {
+		return nil, err
+	} else if len(raw) != 42 || string(raw[:2]) != keyAlg {
+		return nil, fmt.Errorf("not a minisign public key")
+	}
+
+	pk := &PublicKey{Key: ed25519.PublicKey(raw[10:])}
+	copy(pk.ID[:], raw[2:10])
+	return pk, nil
+}
+
+// Verify checks the .minisig signature of data and returns its trusted comment.
+func (k *PublicKey) Verify(data []byte, signature []byte) (string, error) {
+	lines := make([]string, 0)
+	for _, line := range strings.Split(string(signature), "\n") {
+		if line = strings.TrimRight(line, "\r"); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) != 4 || !strings.HasPrefix(lines[2], "trusted comment: ") {
+		return "", fmt.Errorf("malformed signature")
+	}
+
+	payload, err := base64.StdEncoding.DecodeString(lines[1])
+	if err != nil || len(payload) != 74 {
+		return "", fmt.Errorf("malformed signature")
+	}
+	global, err := base64.StdEncoding.DecodeString(lines[3])
+	if err != nil || len(global) != ed25519.SignatureSize {
+		return "", fmt.Errorf("malformed global signature")
+	}
+
+	alg, sig := string(payload[:2]), payload[10:]
+	if !bytes.Equal(payload[2:10], k.ID[:]) {
+		var sigID [8]byte
+		copy(sigID[:], payload[2:10])
+		return "", fmt.Errorf("signature key id %s doesn't match public key %s", keyIDString(sigID), keyIDString(k.ID))
+	}
+
+	signed := data
+	if alg == sigAlgPrehashed {
+		hash := blake2b.Sum512(data)
+		signed = hash[:]
+	} else if alg != sigAlgLegacy {
+		return "", fmt.Errorf("unsupported signature algorithm")
+	}
+
+	if !ed25519.Verify(k.Key, signed, sig) {
+		return "", fmt.Errorf("signature verification failed")
+	}
+
+	trusted := strings.TrimPrefix(lines[2], "trusted comment: ")
+	if !ed25519.Verify(k.Key, append(append([]byte{}, sig...), []byte(trusted)...), global) {
+		return "", fmt.Errorf("trusted comment verification failed")
+	}
+
+	return trusted, nil
+}
@@ -0,0 +1,128 @@
+package core
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// signature of "Hello World!\n" with the legacy algorithm, from the test data of aead.dev/minisign
+const (
+	minisignPublicKey = `untrusted comment: minisign public key C373193807678450
+RWRQhGcHOBlzw4CoKyugkk4ioDfoxlXxC9LBx+VNhJ3w9w+cAxgvPsuo
+`
+	minisignSignature = "untrusted comment: signature from minisign secret key\n" +
+		"RWRQhGcHOBlzwxrJCyuC+rJfHSfyRKRxkuwa3JJ0bWEs7RHjL1OUmqnTr+V1B9JzFuJIH/ybR2Eus9oEZKt9RbitpF/L4D3+5wg=\n" +
+		"trusted comment: timestamp:1614549543\tfile:message.txt\n" +
+		"P/722+ynQ+tIy0qadFHwLx5MsyNz/jDKJkDWQj4dDD2OKnVte8m/M14mwPE/1NMwzShPMSBhMXqZGdbe+UZjDg==\n"
+	minisignMessage = "Hello World!\n"
+)
+
+func TestSignVerify(t *testing.T) {
+	secret, public, err := GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyFile := filepath.Join(t.TempDir(), "takuan.key")
+	if err = ioutil.WriteFile(keyFile, secret, 0600); err != nil {
+		t.Fatal(err)
+	}
+	signer := &Signer{Enabled: true, KeyFile: keyFile}
+	if err = signer.Init(); err != nil {
+		t.Fatal(err)
+	}
+	pk, err := ParsePublicKey(public)
+	if err != nil {
+		t.Fatal(err)
+	} else if pk.ID != signer.keyID {
+		t.Fatalf("public key id %s doesn't match %s", keyIDString(pk.ID), keyIDString(signer.keyID))
+	}
+
+	data := []byte("address,events\n1.2.3.4,3\n")
+	signature := string(signer.Sign("report.csv", "node", data))
+	if !strings.HasPrefix(signature, "untrusted comment: signature from takuan secret key "+keyIDString(pk.ID)+"\n") {
+		t.Fatalf("unexpected signature:\n%s", signature)
+	}
+
+	trusted, err := pk.Verify(data, []byte(signature))
+	if err != nil {
+		t.Fatal(err)
+	} else if !strings.HasPrefix(trusted, "timestamp:") || !strings.HasSuffix(trusted, "\tfile:report.csv\tnode:node") {
+		t.Fatalf("unexpected trusted comment %s", trusted)
+	}
+
+	lines := strings.Split(signature, "\n")
+	flipped := "A"
+	if lines[1][20] == 'A' {
+		flipped = "B"
+	}
+
+	for _, test := range []struct {
+		name      string
+		data      string
+		signature string
+	}{
+		{"data", "address,events\n1.2.3.4,4\n", signature},
+		{"trusted comment", string(data), strings.Replace(signature, "file:report.csv", "file:other.csv", 1)},
+		{"signature", string(data), strings.Replace(signature, lines[1], lines[1][:20]+flipped+lines[1][21:], 1)},
+		{"truncated", string(data), strings.Join(lines[:3], "\n")},
+	} {
+		if _, err := pk.Verify([]byte(test.data), []byte(test.signature)); err == nil {
+			t.Errorf("verified with a tampered %s", test.name)
+		}
+	}
+}
+
+func TestVerifyWrongKey(t *testing.T) {
+	signers := make([]*Signer, 2)
+	keys := make([]*PublicKey, 2)
+	for i := range signers {
+		secret, public, err := GenerateKeyPair()
+		if err != nil {
+			t.Fatal(err)
+		}
+		keyFile := filepath.Join(t.TempDir(), "takuan.key")
+		if err = ioutil.WriteFile(keyFile, secret, 0600); err != nil {
+			t.Fatal(err)
+		}
+		signers[i] = &Signer{Enabled: true, KeyFile: keyFile}
+		if err = signers[i].Init(); err != nil {
+			t.Fatal(err)
+		} else if keys[i], err = ParsePublicKey(public); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	data := []byte("address,events\n1.2.3.4,3\n")
+	signature := signers[0].Sign("report.csv", "node", data)
+	if _, err := keys[1].Verify(data, signature); err == nil || !strings.Contains(err.Error(), "doesn't match") {
+		t.Fatalf("verified with the wrong key: %v", err)
+	}
+
+	// same key id, different key
+	keys[1].ID = keys[0].ID
+	if _, err := keys[1].Verify(data, signature); err == nil || !strings.Contains(err.Error(), "verification failed") {
+		t.Fatalf("verified with the wrong key: %v", err)
+	}
+}
+
+func TestVerifyMinisign(t *testing.T) {
+	pk, err := ParsePublicKey([]byte(minisignPublicKey))
+	if err != nil {
+		t.Fatal(err)
+	} else if keyIDString(pk.ID) != "C373193807678450" {
+		t.Fatalf("unexpected key id %s", keyIDString(pk.ID))
+	}
+
+	trusted, err := pk.Verify([]byte(minisignMessage), []byte(minisignSignature))
+	if err != nil {
+		t.Fatal(err)
+	} else if trusted != "timestamp:1614549543\tfile:message.txt" {
+		t.Fatalf("unexpected trusted comment %s", trusted)
+	}
+
+	if _, err = pk.Verify([]byte("Hello World?\n"), []byte(minisignSignature)); err == nil {
+		t.Fatal("verified tampered data")
+	}
+}
@@ -0,0 +1,123 @@
+package core
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	// slack truncates longer messages
+	slackMaxLength = 40000
+	// of the text of a section block
+	slackSectionMaxLength = 3000
+	discordMaxLength      = 2000
+)
+
+// SlackWebhook posts to a Slack compatible incoming webhook (Slack, Mattermost, Rocket.Chat ...).
+type SlackWebhook struct {
+	URL string `yaml:"url"`
+	// optional overrides, not every server allows them
+	Channel     string `yaml:"channel"`
+	Username    string `yaml:"username"`
+	IconEmoji   string `yaml:"icon_emoji"`
+	TimeoutSecs int    `yaml:"timeout"`
+
+	client *http.Client
+}
+
+func (s *SlackWebhook) Init() error {
+	if s.URL == "" {
+		return fmt.Errorf("url is required")
+	}
+	s.client = newNotifierClient(s.TimeoutSecs)
+	return nil
+}
+
+func (s *SlackWebhook) MaxLength() int {
+	return slackMaxLength
+}
+
+func (s *SlackWebhook) Length(text string) int {
+	return runeLength(text)
+}
+
+type slackBlock struct {
+	Type     string     `json:"type"`
+	Text     *slackText `json:"text,omitempty"`
+	ImageURL string     `json:"image_url,omitempty"`
+	AltText  string     `json:"alt_text,omitempty"`
+	Title    *slackText `json:"title,omitempty"`
+}
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// incoming webhooks can't upload files, the charts are linked as image blocks if
+// they were published to a public url
+func slackBlocks(n *Notification) []slackBlock {
+	blocks := make([]slackBlock, 0)
+	for _, chart := range n.Charts {
+		if strings.HasPrefix(chart.URL, "http://") || strings.HasPrefix(chart.URL, "https://") {
+			blocks = append(blocks, slackBlock{
+				Type:     "image",
+				ImageURL: chart.URL,
+				AltText:  chart.Title,
+				Title:    &slackText{Type: "plain_text", Text: chart.Title},
+			})
+		}
+	}
+	if len(blocks) == 0 {
+		return nil
+	}
+	return append([]slackBlock{{
+		Type: "section",
+		Text: &slackText{Type: "mrkdwn", Text: truncate(n.Text, slackSectionMaxLength, runeLength)},
+	}}, blocks...)
+}
+
+func (s *SlackWebhook) Notify(n *Notification) error {
+	return sendJSON(s.client, http.MethodPost, s.URL, struct {
+		Text      string       `json:"text"`
+		Blocks    []slackBlock `json:"blocks,omitempty"`
+		Channel   string       `json:"channel,omitempty"`
+		Username  string       `json:"username,omitempty"`
+		IconEmoji string       `json:"icon_emoji,omitempty"`
+	}{n.Text, slackBlocks(n), s.Channel, s.Username, s.IconEmoji}, nil, nil)
+}
+
+// DiscordWebhook posts to a Discord channel webhook.
+type DiscordWebhook struct {
+	URL         string `yaml:"url"`
+	Username    string `yaml:"username"`
+	AvatarURL   string `yaml:"avatar_url"`
+	TimeoutSecs int    `yaml:"timeout"`
+
+	client *http.Client
+}
+
+func (d *DiscordWebhook) Init() error {
+	if d.URL == "" {
+		return fmt.Errorf("url is required")
+	}
+	d.client = newNotifierClient(d.TimeoutSecs)
+	return nil
+}
+
+func (d *DiscordWebhook) MaxLength() int {
+	return discordMaxLength
+}
+
+func (d *DiscordWebhook) Length(text string) int {
+	return runeLength(text)
+}
+
+func (d *DiscordWebhook) Notify(n *Notification) error {
+	return sendJSON(d.client, http.MethodPost, d.URL, struct {
+		Content   string `json:"content"`
+		Username  string `json:"username,omitempty"`
+		AvatarURL string `json:"avatar_url,omitempty"`
+	}{n.Text, d.Username, d.AvatarURL}, nil, nil)
+}
@@ -1,22 +1,17 @@
 package core
 
 import (
-	"fmt"
-	"sort"
-	"strings"
-	"sync"
+	"strconv"
 
 	"github.com/dghubble/go-twitter/twitter"
 	"github.com/dghubble/oauth1"
 	"github.com/evilsocket/islazy/log"
-	"github.com/enescakir/emoji"
-
-	"github.com/evilsocket/takuan/models"
 )
 
-type Twitter struct {
-	sync.Mutex
+const twitterMaxLength = 280
 
+type Twitter struct {
+	// only used by the deprecated top level twitter section
 	Enabled        bool   `yaml:"enabled"`
 	ConsumerKey    string `yaml:"consumer_key"`
 	ConsumerSecret string `yaml:"consumer_secret"`
@@ -36,69 +31,47 @@ func (t *Twitter) Init() (err error) {
 	return
 }
 
-type countryCounter struct {
-	Country string
-	Count   int
+func (t *Twitter) DefaultTemplate() string {
+	return defaultReportTemplate + defaultHashtags
 }
 
-func (t *Twitter) OnBatch(events []models.Event, reportURL string) {
-	t.Lock()
-	defer t.Unlock()
-	if t.Enabled {
-		byCountry := make(map[string]int)
-		for _, event := range events {
-			if _, found := byCountry[event.CountryCode]; found {
-				byCountry[event.CountryCode]++
-			} else {
-				byCountry[event.CountryCode] = 1
-			}
-		}
-
-		// sort by number of events
-		countryCounters := make([]countryCounter, 0)
-		for country, count := range byCountry {
-			countryCounters = append(countryCounters, countryCounter{
-				Country: country,
-				Count:   count,
-			})
-		}
-		sort.Slice(countryCounters, func(i, j int) bool {
-			return countryCounters[i].Count > countryCounters[j].Count
-		})
+func (t *Twitter) MaxLength() int {
+	return twitterMaxLength
+}
 
-		countries := make([]string, 0)
-		for _, country := range countryCounters {
-			code := country.Country
-			if flag, err := emoji.CountryFlag(code); err == nil {
-				code = string(flag)
-			}
-			countries = append(countries, fmt.Sprintf("%s  (%d)", code, country.Count))
-		}
+func (t *Twitter) Length(text string) int {
+	return twitterLength(text)
+}
 
-		if len(countries) > 5 {
-			countries = append(countries[:5], "...")
-		}
+func (t *Twitter) Notify(n *Notification) error {
+	_, err := t.postUpdate(n.Text, 0)
+	return err
+}
 
-		numEvents := len(events)
-		plural := "s"
-		if numEvents == 1 {
-			plural = ""
+// every part is a reply to the previous one
+func (t *Twitter) NotifyThread(n *Notification, th *thread) error {
+	for !th.done() {
+		inReplyTo := int64(0)
+		if last := th.inReplyTo(); last != "" {
+			inReplyTo, _ = strconv.ParseInt(last, 10, 64)
 		}
-
-		tweet := fmt.Sprintf("%d new event%s from %s %s #takuan #threatreport", numEvents, plural,
-			strings.Join(countries, "," +
-			" "), reportURL)
-		if err := t.postUpdate(tweet); err != nil {
-			log.Error("error tweeting: %v", err)
+		id, err := t.postUpdate(th.parts[th.next()], inReplyTo)
+		if err != nil {
+			return err
 		}
+		th.posted(strconv.FormatInt(id, 10))
 	}
+	return nil
 }
 
-func (t *Twitter) postUpdate(status string) error {
+func (t *Twitter) postUpdate(status string, inReplyTo int64) (int64, error) {
 	log.Info("tweet> %s", status)
-	tweet, _, err := t.client.Statuses.Update(status, nil)
-	if err == nil {
-		log.Debug("tweet: %+v", tweet)
+	tweet, _, err := t.client.Statuses.Update(status, &twitter.StatusUpdateParams{
+		InReplyToStatusID: inReplyTo,
+	})
+	if err != nil {
+		return 0, err
 	}
-	return err
+	log.Debug("tweet: %+v", tweet)
+	return tweet.ID, nil
 }
@@ -0,0 +1,173 @@
+package core
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const defaultWebhookTimeoutSecs = 30
+
+// WebhookDestination sends each file of a report to an HTTP endpoint.
+type WebhookDestination struct {
+	// with PUT the file name is appended to the url, with POST it
+	// is sent in the X-Takuan-File header
+	URL         string            `yaml:"url"`
+	Method      string            `yaml:"method"`
+	Headers     map[string]string `yaml:"headers"`
+	TimeoutSecs int               `yaml:"timeout"`
+	// optional public url the files can be downloaded from
+	HTTP string `yaml:"http"`
+
+	client *http.Client
+}
+
+func (d *WebhookDestination) Init() error {
+	if d.URL == "" {
+		return fmt.Errorf("url is required")
+	}
+
+	d.Method = strings.ToUpper(d.Method)
+	if d.Method == "" {
+		d.Method = http.MethodPost
+	} else if d.Method != http.MethodPost && d.Method != http.MethodPut {
+		return fmt.Errorf("unsupported webhook method '%s', use POST or PUT", d.Method)
+	}
+
+	if d.TimeoutSecs <= 0 {
+		d.TimeoutSecs = defaultWebhookTimeoutSecs
+	}
+	d.client = &http.Client{Timeout: time.Duration(d.TimeoutSecs) * time.Second}
+
+	return nil
+}
+
+func (d *WebhookDestination) urlFor(file *ReportFile) string {
+	if d.Method == http.MethodPut {
+		return fileURL(d.URL, file.Name)
+	}
+	return d.URL
+}
+
+func (d *WebhookDestination) send(pub *Publication, file *ReportFile) error {
+	req, err := http.NewRequest(d.Method, d.urlFor(file), bytes.NewReader(file.Data))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", contentType(file.Name))
+	req.Header.Set("X-Takuan-Node", pub.Node)
+	req.Header.Set("X-Takuan-Report", pub.Name)
+	req.Header.Set("X-Takuan-File", file.Name)
+	for name, value := range d.Headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s %s: %s (%s)", d.Method, req.URL, resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+func (d *WebhookDestination) Publish(pub *Publication) (string, error) {
+	for _, file := range pub.Files {
+		if err := d.send(pub, file); err != nil {
+			return "", err
+		}
+	}
+
+	if d.HTTP != "" {
+		return fileURL(d.HTTP, pub.Files[0].Name), nil
+	} else if d.Method == http.MethodPut {
+		return d.urlFor(pub.Files[0]), nil
+	}
+	return "", nil
+}
+
+// WebhookNotifier posts notifications as json documents, signed with HMAC-SHA256
+// in the X-Takuan-Signature header when a secret is configured.
+type WebhookNotifier struct {
+	URL         string            `yaml:"url"`
+	Secret      string            `yaml:"secret"`
+	Headers     map[string]string `yaml:"headers"`
+	TimeoutSecs int               `yaml:"timeout"`
+
+	client *http.Client
+}
+
+type webhookReport struct {
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	Addresses int       `json:"addresses"`
+	Events    int       `json:"events"`
+}
+
+type webhookNotification struct {
+	ID      string         `json:"id"`
+	Node    string         `json:"node"`
+	Subject string         `json:"subject"`
+	Text    string         `json:"text"`
+	URL     string         `json:"url"`
+	Report  *webhookReport `json:"report,omitempty"`
+	Alert   *Alert         `json:"alert,omitempty"`
+	Digest  *DigestStats   `json:"digest,omitempty"`
+}
+
+func (w *WebhookNotifier) Init() error {
+	if w.URL == "" {
+		return fmt.Errorf("url is required")
+	}
+	w.client = newNotifierClient(w.TimeoutSecs)
+	return nil
+}
+
+func (w *WebhookNotifier) Notify(n *Notification) error {
+	payload := webhookNotification{
+		ID:      n.ID,
+		Node:    n.Node,
+		Subject: n.Subject,
+		Text:    n.Text,
+		URL:     n.URL,
+		Alert:   n.Alert,
+		Digest:  n.Digest,
+	}
+	if n.Report != nil {
+		payload.Report = &webhookReport{
+			Name:      n.Report.Name,
+			CreatedAt: n.Report.CreatedAt,
+			Addresses: len(n.Report.Entries),
+			Events:    n.Report.Events,
+		}
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	headers := map[string]string{
+		"X-Takuan-Node":         n.Node,
+		"X-Takuan-Notification": n.ID,
+	}
+	if w.Secret != "" {
+		headers["X-Takuan-Signature"] = "sha256=" + hex.EncodeToString(hmacSHA256([]byte(w.Secret), string(body)))
+	}
+	for name, value := range w.Headers {
+		headers[name] = value
+	}
+
+	return sendJSON(w.client, http.MethodPost, w.URL, body, headers, nil)
+}
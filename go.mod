@@ -10,10 +10,16 @@ require (
 	github.com/go-git/go-git/v5 v5.1.0
 	github.com/jinzhu/gorm v1.9.12
 	github.com/oschwald/geoip2-golang v1.4.0
+	github.com/oschwald/maxminddb-golang v1.6.0
+	github.com/pkg/sftp v1.11.0
+	github.com/prometheus/client_golang v1.7.1
 	github.com/robertkrimen/otto v0.0.0-20191219234010-c382bd3c16ff // indirect
+	github.com/robfig/cron/v3 v3.0.1
 	github.com/t-tiger/gorm-bulk-insert v1.3.0
 	github.com/t-tiger/gorm-bulk-insert/v2 v2.0.1
 	github.com/teris-io/shortid v0.0.0-20171029131806-771a37caa5cf
+	github.com/wcharczuk/go-chart/v2 v2.1.0
+	golang.org/x/crypto v0.0.0-20200406173513-056763e48d71
 	gopkg.in/sourcemap.v1 v1.0.5 // indirect
 	gopkg.in/src-d/go-git.v4 v4.13.1 // indirect
 	gopkg.in/yaml.v2 v2.3.0
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+)
+
+// Digest records the periodic summaries, so that each window is published once.
+type Digest struct {
+	ID          uint       `gorm:"primary_key" json:"id"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	PublishedAt *time.Time `json:"published_at"`
+	NodeName    string     `gorm:"size:191;uniqueIndex:idx_digest_node_name" json:"node_name"`
+	Name        string     `gorm:"size:191;uniqueIndex:idx_digest_node_name" json:"name"`
+	Start       time.Time  `json:"start"`
+	End         time.Time  `json:"end"`
+	URL         string     `json:"url"`
+	Events      int        `json:"events"`
+	Status      string     `gorm:"size:20;index" json:"status"`
+	Error       string     `json:"error"`
+}
@@ -13,8 +13,14 @@ type Event struct {
 	Address     string     `gorm:"index" gorm:"size:50; not null" json:"address"`
 	CountryCode string     `gorm:"index" gorm:"size:5;" json:"country_code"`
 	CountryName string     `json:"country_name"`
+	City        string     `json:"city"`
+	ASN         uint       `gorm:"index" json:"asn"`
+	ASOrg       string     `json:"as_org"`
+	Hostname    string     `json:"hostname"`
+	Tags        string     `json:"tags"`
 	Sensor      string     `gorm:"index" json:"sensor"`
 	Rule        string     `gorm:"index" json:"rule"`
 	Payload     string     `json:"payload"`
-	ReportedAt  *time.Time  `gorm:"index" json:"reported_at"`
+	ReportedAt  *time.Time `gorm:"index" json:"reported_at"`
+	ReportID    *uint      `gorm:"index" json:"report_id"`
 }
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	ReportPending   = "pending"
+	ReportPublished = "published"
+	ReportFailed    = "failed"
+)
+
+// Report is the ledger entry of a report, events are linked to it by ReportID.
+type Report struct {
+	ID          uint       `gorm:"primary_key" json:"id"`
+	CreatedAt   time.Time  `gorm:"index" json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	PublishedAt *time.Time `json:"published_at"`
+	NodeName    string     `gorm:"size:191;uniqueIndex:idx_report_node_name" json:"node_name"`
+	Name        string     `gorm:"size:191;uniqueIndex:idx_report_node_name" json:"name"`
+	URL         string     `json:"url"`
+	Formats     string     `json:"formats"`
+	// json list of the published files with their size and hash
+	Files     string `gorm:"type:text" json:"files"`
+	Addresses int    `json:"addresses"`
+	Events    int    `json:"events"`
+	Status    string `gorm:"size:20;index" json:"status"`
+	Error     string `json:"error"`
+	// failed publications, they are retried less and less often
+	Attempts int `json:"attempts"`
+	// comma separated names of the destinations that failed, the report is published again to them
+	Pending string `json:"pending"`
+	// the scheduled window of the events, empty for the reports of older versions
+	WindowStart *time.Time `json:"window_start"`
+	WindowEnd   *time.Time `gorm:"index" json:"window_end"`
+}
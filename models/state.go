@@ -1,8 +1,20 @@
 package models
 
+import (
+	"time"
+)
+
 type SensorState struct {
 	ID           uint   `gorm:"primary_key" json:"-"`
 	NodeName     string `gorm:"index" gorm:"column:node_name"`
 	SensorName   string `gorm:"index" gorm:"column:sensor_name"`
 	LastPosition int64  `gorm:"index" gorm:"column:last_position"`
 }
+
+// ReportState is the end of the last report window processed by a node, including the windows
+// without events that have no report.
+type ReportState struct {
+	ID         uint      `gorm:"primary_key" json:"-"`
+	NodeName   string    `gorm:"size:191;uniqueIndex" json:"node_name"`
+	LastWindow time.Time `json:"last_window"`
+}